@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/BRO3886/rem/internal/parser"
 	"github.com/BRO3886/rem/internal/reminder"
 	"github.com/BRO3886/rem/internal/ui"
 	"github.com/spf13/cobra"
@@ -18,6 +17,7 @@ var (
 	addNotes    string
 	addURL      string
 	addFlagged  bool
+	addRepeat   string
 	addInteractive bool
 )
 
@@ -29,6 +29,8 @@ var addCmd = &cobra.Command{
 	Example: `  rem add "Buy groceries" --list Personal --due tomorrow --priority high
   rem add "Review PR" --due "next friday at 2pm" --url https://github.com/org/repo/pull/123
   rem add "Call dentist" --due "in 2 days" --notes "Ask about cleaning"
+  rem add "Standup" --repeat "weekdays at 9:30am"
+  rem add "Pay rent" --due 2026-03-01 --repeat "FREQ=MONTHLY;BYMONTHDAY=1"
   rem add -i  # Interactive mode`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if addInteractive {
@@ -49,22 +51,37 @@ var addCmd = &cobra.Command{
 		}
 
 		if addDue != "" {
-			dueDate, err := parser.ParseDate(addDue)
+			dueDate, err := parseDate(addDue)
 			if err != nil {
 				return fmt.Errorf("invalid due date: %w", err)
 			}
 			r.DueDate = &dueDate
 		}
 
+		if addRepeat != "" {
+			rec, first, err := parseRecurrence(addRepeat)
+			if err != nil {
+				return fmt.Errorf("invalid repeat: %w", err)
+			}
+			// Without --due, the series starts at its first occurrence.
+			if r.DueDate == nil {
+				r.DueDate = &first
+			}
+			r.Recurrence = rec
+		}
+
 		id, err := reminderSvc.CreateReminder(r)
 		if err != nil {
 			return err
 		}
 
-		format := ui.ParseOutputFormat(outputFormat)
-		if format == ui.FormatJSON {
-			fmt.Fprintf(os.Stdout, `{"id": "%s", "name": "%s"}`+"\n", id, r.Name)
-		} else {
+		switch {
+		case output == ui.FormatTemplate:
+			r.ID = id
+			ui.PrintReminderDetail(os.Stdout, r, output)
+		case output.Structured():
+			ui.PrintCreated(os.Stdout, id, r.Name, output)
+		default:
 			fmt.Fprintf(os.Stdout, "Created reminder: %s (ID: %s)\n", r.Name, shortIDStr(id))
 		}
 
@@ -79,6 +96,7 @@ func init() {
 	addCmd.Flags().StringVarP(&addNotes, "notes", "n", "", "Notes/body for the reminder")
 	addCmd.Flags().StringVarP(&addURL, "url", "u", "", "URL to attach to the reminder")
 	addCmd.Flags().BoolVarP(&addFlagged, "flagged", "f", false, "Flag the reminder")
+	addCmd.Flags().StringVarP(&addRepeat, "repeat", "r", "", "Repeat rule (e.g., 'daily', 'every 2 weeks on tue,thu', or an RRULE)")
 	addCmd.Flags().BoolVarP(&addInteractive, "interactive", "i", false, "Create reminder interactively")
 
 	rootCmd.AddCommand(addCmd)
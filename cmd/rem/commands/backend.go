@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/BRO3886/rem/internal/service"
+)
+
+// backendName selects the storage backend: "eventkit" or "file".
+// Defaults to $REM_BACKEND, then to the platform default.
+var backendName string
+
+// newBackend creates the backend selected by --backend.
+func newBackend() (service.Backend, error) {
+	name := backendName
+	if name == "" {
+		name = defaultBackendName
+	}
+
+	switch name {
+	case "eventkit":
+		return newEventKitBackend()
+	case "file":
+		path, err := service.DefaultStorePath()
+		if err != nil {
+			return nil, err
+		}
+		return service.NewFileBackend(path)
+	default:
+		return nil, fmt.Errorf("unknown backend: %s (use eventkit or file)", name)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&backendName, "backend", os.Getenv("REM_BACKEND"),
+		"Storage backend: eventkit (macOS Reminders) or file (JSON store, $REM_STORE)")
+}
@@ -0,0 +1,21 @@
+//go:build darwin
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/BRO3886/go-eventkit/reminders"
+	"github.com/BRO3886/rem/internal/service"
+)
+
+const defaultBackendName = "eventkit"
+
+// newEventKitBackend creates the EventKit backend and requests Reminders access.
+func newEventKitBackend() (service.Backend, error) {
+	client, err := reminders.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Reminders access: %w", err)
+	}
+	return service.NewEventKitBackend(client, service.NewExecutor()), nil
+}
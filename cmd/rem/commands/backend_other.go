@@ -0,0 +1,16 @@
+//go:build !darwin
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/BRO3886/rem/internal/service"
+)
+
+const defaultBackendName = "file"
+
+// newEventKitBackend reports that the EventKit backend is unavailable off macOS.
+func newEventKitBackend() (service.Backend, error) {
+	return nil, fmt.Errorf("the eventkit backend requires macOS (use --backend file)")
+}
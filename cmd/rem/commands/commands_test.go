@@ -0,0 +1,935 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/BRO3886/rem/internal/service"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// newTestBackend points the commands at a fresh in-memory backend.
+func newTestBackend(t *testing.T) *service.MemoryBackend {
+	t.Helper()
+	b := service.NewMemoryBackend()
+	setBackend(b)
+	// Keep the user's working calendar out of the tests.
+	t.Setenv("REM_CALENDAR", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Cleanup(func() {
+		reminderSvc = nil
+		listSvc = nil
+	})
+	return b
+}
+
+// run executes the root command with args and returns what it wrote to stdout.
+func run(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := runErr(t, args...)
+	if err != nil {
+		t.Fatalf("rem %s: %v", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+// runErr is like run but returns the command's error.
+func runErr(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	resetFlags(rootCmd)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+
+	rootCmd.SetArgs(args)
+	execErr := rootCmd.Execute()
+
+	w.Close()
+	os.Stdout = stdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String(), execErr
+}
+
+// resetFlags restores every flag to its default so runs don't leak state.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		f.Value.Set(f.DefValue)
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
+func TestAddAndList(t *testing.T) {
+	b := newTestBackend(t)
+	if _, err := b.CreateList("Work"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := run(t, "add", "Review PR", "--list", "Work", "--due", "2026-02-15 14:30", "--priority", "high")
+	if !strings.Contains(out, "Created reminder: Review PR") {
+		t.Errorf("unexpected add output: %q", out)
+	}
+	run(t, "add", "Buy milk")
+
+	out = run(t, "list", "--list", "Work", "-o", "plain")
+	if !strings.Contains(out, "Review PR") || strings.Contains(out, "Buy milk") {
+		t.Errorf("list --list Work output = %q", out)
+	}
+
+	all, _ := b.Reminders(nil)
+	if len(all) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(all))
+	}
+	if all[0].Priority != reminder.PriorityHigh {
+		t.Errorf("Priority = %v, want high", all[0].Priority)
+	}
+}
+
+func TestCompleteAndStats(t *testing.T) {
+	b := newTestBackend(t)
+	id, _ := b.CreateReminder(&reminder.Reminder{Name: "Done soon"})
+	b.CreateReminder(&reminder.Reminder{Name: "Later"})
+
+	run(t, "complete", id[:8])
+
+	out := run(t, "stats", "-o", "json")
+	if !strings.Contains(out, `"total": 2`) || !strings.Contains(out, `"completed": 1`) {
+		t.Errorf("unexpected stats output: %s", out)
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Imported")
+
+	path := filepath.Join(t.TempDir(), "in.json")
+	data := `[{"name": "First", "list_name": "Work"}, {"name": "Second", "list_name": "Work"}]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run(t, "import", path, "--list", "Imported")
+
+	all, _ := b.Reminders(&reminder.ListFilter{ListName: "Imported"})
+	if len(all) != 2 {
+		t.Errorf("expected 2 imported reminders, got %d", len(all))
+	}
+}
+
+func TestAddRepeatAndUpcoming(t *testing.T) {
+	b := newTestBackend(t)
+
+	run(t, "add", "Standup", "--due", "yesterday", "--repeat", "daily")
+
+	all, _ := b.Reminders(nil)
+	if len(all) != 1 || all[0].Recurrence == nil {
+		t.Fatalf("expected one repeating reminder, got %+v", all)
+	}
+
+	out := run(t, "upcoming", "--days", "3", "-o", "plain")
+	if n := strings.Count(out, "Standup"); n != 3 {
+		t.Errorf("expected 3 upcoming occurrences, got %d:\n%s", n, out)
+	}
+
+	run(t, "update", all[0].ID, "--repeat", "none")
+	r, _ := b.Reminder(all[0].ID)
+	if r.Recurrence != nil {
+		t.Errorf("expected recurrence to be cleared, got %v", r.Recurrence)
+	}
+}
+
+func TestUpcomingBusinessDays(t *testing.T) {
+	b := newTestBackend(t)
+
+	now := time.Now()
+	calendar := fmt.Sprintf("workdays: [mon, tue, wed, thu, fri, sat, sun]\nholidays:\n  - %s\n  - %s\n",
+		now.AddDate(0, 0, 1).Format("2006-01-02"), now.AddDate(0, 0, 2).Format("2006-01-02"))
+	path := filepath.Join(t.TempDir(), "calendar.yaml")
+	if err := os.WriteFile(path, []byte(calendar), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("REM_CALENDAR", path)
+
+	run(t, "add", "Standup", "--due", "yesterday", "--repeat", "daily")
+	if all, _ := b.Reminders(nil); len(all) != 1 {
+		t.Fatalf("expected one reminder, got %d", len(all))
+	}
+
+	// One working day from now skips the two holidays: a three-day window.
+	out := run(t, "upcoming", "--days", "1", "--business-days", "-o", "plain")
+	if n := strings.Count(out, "Standup"); n != 3 {
+		t.Errorf("expected 3 occurrences in one business day, got %d:\n%s", n, out)
+	}
+	out = run(t, "upcoming", "--days", "1", "-o", "plain")
+	if n := strings.Count(out, "Standup"); n != 1 {
+		t.Errorf("expected 1 occurrence in one day, got %d:\n%s", n, out)
+	}
+}
+
+func TestTimeZoneFlag(t *testing.T) {
+	b := newTestBackend(t)
+
+	run(t, "--tz", "UTC+2", "add", "Call Berlin", "--due", "2026-03-01 10:00")
+	all, _ := b.Reminders(nil)
+	want := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	if len(all) != 1 || all[0].DueDate == nil || !all[0].DueDate.Equal(want) {
+		t.Fatalf("expected due date %v, got %+v", want, all)
+	}
+
+	out := run(t, "--tz", "UTC-1", "show", all[0].ID, "-o", "plain")
+	if !strings.Contains(out, "Due: 2026-03-01 07:00") {
+		t.Errorf("expected due date in UTC-1, got:\n%s", out)
+	}
+
+	out = run(t, "--tz", "UTC", "export", "--rfc3339")
+	if !strings.Contains(out, `"due_date": "2026-03-01T08:00:00Z"`) {
+		t.Errorf("expected RFC 3339 due date in UTC, got:\n%s", out)
+	}
+}
+
+func TestQuickAdd(t *testing.T) {
+	b := newTestBackend(t)
+	if _, err := b.CreateList("Groceries"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := run(t, "q", "Buy milk tomorrow 5pm @groceries !high #errand", "--explain")
+	for _, want := range []string{"Title:    Buy milk", `(from "tomorrow 5pm")`, "List:     Groceries", "Priority: high", "Tags:     #errand", "Nothing was created"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	out = run(t, "q", "Buy milk tomorrow 5pm @groceries !high #errand", "--explain", "-o", "json")
+	var preview map[string]any
+	if err := json.Unmarshal([]byte(out), &preview); err != nil || preview["name"] != "Buy milk" {
+		t.Errorf("expected the reminder as JSON, got %v:\n%s", err, out)
+	}
+	if all, _ := b.Reminders(nil); len(all) != 0 {
+		t.Fatalf("--explain created %d reminders", len(all))
+	}
+
+	out = run(t, "q", "Buy milk tomorrow 5pm @groceries !high #errand")
+	if !strings.Contains(out, "Created reminder: Buy milk") {
+		t.Errorf("unexpected output: %q", out)
+	}
+	all, _ := b.Reminders(nil)
+	if len(all) != 1 {
+		t.Fatalf("expected 1 reminder, got %d", len(all))
+	}
+	r := all[0]
+	if r.Name != "Buy milk" || r.ListName != "Groceries" || r.Priority != reminder.PriorityHigh || r.Body != "#errand" {
+		t.Errorf("unexpected reminder: %+v", r)
+	}
+	if r.DueDate == nil || r.DueDate.Hour() != 17 || r.DueDate.Day() != time.Now().AddDate(0, 0, 1).Day() {
+		t.Errorf("expected due tomorrow at 17:00, got %v", r.DueDate)
+	}
+}
+
+func TestDateExplain(t *testing.T) {
+	newTestBackend(t)
+
+	out := run(t, "date", "2026-02-15")
+	for _, want := range []string{"Input:    2026-02-15", "Resolved: Sun Feb 15, 2026 at 12:00 AM", "Rules:    standard format (2006-01-02)", "Warning:  no time given, using midnight"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out = run(t, "date", "--tz", "UTC", "next", "friday", "at", "2pm", "-o", "json")
+	var got struct {
+		Input string   `json:"input"`
+		Time  string   `json:"time"`
+		Rules []string `json:"rules"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if got.Input != "next friday at 2pm" || !strings.HasSuffix(got.Time, "T14:00:00Z") || strings.Join(got.Rules, ",") != "next weekday,time of day" {
+		t.Errorf("unexpected explanation: %+v", got)
+	}
+}
+
+func TestExportImportICS(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	run(t, "add", "Ship release", "--list", "Work", "--due", "2026-03-01 10:00", "--priority", "high", "--flagged")
+
+	path := filepath.Join(t.TempDir(), "out.ics")
+	run(t, "export", "--format", "ics", "--output-file", path)
+
+	b = newTestBackend(t)
+	b.CreateList("Work")
+	run(t, "import", path)
+
+	all, _ := b.Reminders(nil)
+	if len(all) != 1 {
+		t.Fatalf("expected 1 imported reminder, got %d", len(all))
+	}
+	r := all[0]
+	if r.Name != "Ship release" || r.ListName != "Work" || r.Priority != reminder.PriorityHigh || !r.Flagged {
+		t.Errorf("unexpected reminder: %+v", r)
+	}
+	if r.DueDate == nil || !r.DueDate.Equal(time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)) {
+		t.Errorf("unexpected due date: %v", r.DueDate)
+	}
+}
+
+func TestTodoTxtExportImportSync(t *testing.T) {
+	b := newTestBackend(t)
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	b.CreateList("Work")
+	run(t, "add", "Ship release", "--list", "Work", "--due", "2026-03-01", "--priority", "high")
+
+	out := run(t, "export", "--format", "todotxt")
+	if !strings.HasPrefix(out, "(A) ") || !strings.Contains(out, "Ship release +Work due:2026-03-01 rem:") {
+		t.Errorf("unexpected todo.txt export: %q", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	if err := os.WriteFile(path, []byte(out+"(C) Write notes +Work @docs\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out = run(t, "sync", "todotxt", path)
+	if !strings.Contains(out, "created reminder: Write notes") {
+		t.Errorf("unexpected sync output: %q", out)
+	}
+	if out = run(t, "sync", "todotxt", path); !strings.Contains(out, "Already in sync.") {
+		t.Errorf("expected second sync to be a no-op, got %q", out)
+	}
+
+	b = newTestBackend(t)
+	b.CreateList("Work")
+	run(t, "import", path)
+	all, _ := b.Reminders(nil)
+	if len(all) != 2 || all[1].Name != "Write notes" || all[1].Priority != reminder.PriorityLow || all[1].Body != "#docs" {
+		t.Errorf("unexpected imported reminders: %+v", all)
+	}
+}
+
+func TestExportImportMarkdown(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	run(t, "add", "Review PR", "--list", "Work", "--due", "2026-02-15 14:30", "--priority", "medium", "--url", "https://example.com/pr/1", "--notes", "Check auth")
+
+	out := run(t, "export", "--format", "markdown")
+	want := "## Work\n\n- [ ] [Review PR](https://example.com/pr/1) 🔼 ➕ "
+	if !strings.HasPrefix(out, want) || !strings.Contains(out, " 📅 2026-02-15 14:30\n    Check auth\n") {
+		t.Errorf("unexpected Markdown export:\n%s", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("# Standup\n\n- [ ] Follow up with design ⏫\n- [x] Merge fix\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run(t, "import", path, "--list", "Work")
+	all, _ := b.Reminders(&reminder.ListFilter{ListName: "Work"})
+	if len(all) != 3 || all[1].Name != "Follow up with design" || all[1].Priority != reminder.PriorityHigh || !all[2].Completed {
+		t.Errorf("unexpected reminders after import: %+v", all)
+	}
+}
+
+func TestExportImportOrgAndTaskPaper(t *testing.T) {
+	for _, format := range []string{"org", "taskpaper"} {
+		t.Run(format, func(t *testing.T) {
+			b := newTestBackend(t)
+			b.CreateList("Work")
+			run(t, "add", "Ship release", "--list", "Work", "--due", "2026-03-01 10:00", "--priority", "high", "--flagged", "--repeat", "every 2 weeks", "--notes", "Tag the build")
+
+			path := filepath.Join(t.TempDir(), "out."+format)
+			run(t, "export", "--format", format, "--output-file", path)
+
+			b = newTestBackend(t)
+			b.CreateList("Work")
+			run(t, "import", path)
+			all, _ := b.Reminders(nil)
+			if len(all) != 1 {
+				t.Fatalf("expected 1 imported reminder, got %d", len(all))
+			}
+			r := all[0]
+			if r.Name != "Ship release" || r.ListName != "Work" || r.Priority != reminder.PriorityHigh || !r.Flagged || r.Body != "Tag the build" {
+				t.Errorf("unexpected reminder: %+v", r)
+			}
+			if r.Recurrence == nil || r.Recurrence.RRule() != "FREQ=WEEKLY;INTERVAL=2" {
+				t.Errorf("unexpected recurrence: %v", r.Recurrence)
+			}
+			if r.DueDate == nil || !r.DueDate.Equal(time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)) {
+				t.Errorf("unexpected due date: %v", r.DueDate)
+			}
+		})
+	}
+}
+
+func TestNDJSONOutputAndImport(t *testing.T) {
+	b := newTestBackend(t)
+	run(t, "add", "First", "--priority", "high")
+	run(t, "add", "Second")
+
+	out := run(t, "list", "-o", "ndjson")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"id":`) || !strings.Contains(lines[0], `"name":"First"`) {
+		t.Fatalf("unexpected NDJSON output:\n%s", out)
+	}
+	if out := run(t, "overdue", "-o", "ndjson"); out != "" {
+		t.Errorf("expected no output for no overdue reminders, got %q", out)
+	}
+
+	// Feed the first line back in through stdin.
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin.WriteString(lines[0] + "\n")
+	stdin.Seek(0, io.SeekStart)
+	orig := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = orig }()
+
+	b = newTestBackend(t)
+	b.CreateList(service.DefaultListName)
+	run(t, "import", "-", "--format", "ndjson")
+	all, _ := b.Reminders(nil)
+	if len(all) != 1 || all[0].Name != "First" || all[0].Priority != reminder.PriorityHigh {
+		t.Errorf("unexpected reminders after import: %+v", all)
+	}
+}
+
+func TestImportDetectsFormat(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	b.CreateList("Launch")
+
+	dir := t.TempDir()
+	todoist := filepath.Join(dir, "export.csv")
+	data := "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"section,Work,,,,,,,,\n" +
+		"task,Write report,,1,1,,,2026-02-15,en,\n"
+	if err := os.WriteFile(todoist, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	things := filepath.Join(dir, "things.json")
+	data = `[{"type": "project", "attributes": {"title": "Launch", "items": [{"type": "to-do", "attributes": {"title": "Ship it"}}]}}]`
+	if err := os.WriteFile(things, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run(t, "import", todoist)
+	run(t, "import", things)
+
+	work, _ := b.Reminders(&reminder.ListFilter{ListName: "Work"})
+	if len(work) != 1 || work[0].Name != "Write report" || work[0].Priority != reminder.PriorityHigh {
+		t.Errorf("unexpected Todoist import: %+v", work)
+	}
+	launch, _ := b.Reminders(&reminder.ListFilter{ListName: "Launch"})
+	if len(launch) != 1 || launch[0].Name != "Ship it" {
+		t.Errorf("unexpected Things import: %+v", launch)
+	}
+}
+
+func TestImportCSVWithMapping(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Ops")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sheet.csv")
+	data := "Task;Team;Deadline;Urgency\nRotate keys;Ops;28/02/2026;high\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mapFile := filepath.Join(dir, "sheet.map")
+	if err := os.WriteFile(mapFile, []byte("name = Task\nlist_name = Team\ndelimiter = ;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run(t, "import", path, "--map-file", mapFile, "--map", "due_date=Deadline,priority=Urgency", "--date-layout", "02/01/2006")
+
+	all, _ := b.Reminders(&reminder.ListFilter{ListName: "Ops"})
+	if len(all) != 1 || all[0].Name != "Rotate keys" || all[0].Priority != reminder.PriorityHigh {
+		t.Fatalf("unexpected import: %+v", all)
+	}
+	if want := time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local); all[0].DueDate == nil || !all[0].DueDate.Equal(want) {
+		t.Errorf("due = %v, want %v", all[0].DueDate, want)
+	}
+}
+
+func TestImportStrictAndReport(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "team.csv")
+	data := "name,list_name,due_date,priority\n" +
+		"Good,Work,2026-02-15T10:00:00,high\n" +
+		",Work,,\n" +
+		"Bad date,Work,next-ish,5\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reportPath := filepath.Join(dir, "report.json")
+
+	if _, err := runErr(t, "import", path, "--strict", "--report", reportPath); err == nil || !strings.Contains(err.Error(), "2 of 3 rows have errors or warnings") {
+		t.Fatalf("expected --strict to refuse the import, got %v", err)
+	}
+	if all, _ := b.Reminders(nil); len(all) != 0 {
+		t.Fatalf("expected nothing imported with --strict, got %+v", all)
+	}
+
+	run(t, "import", path, "--report", reportPath)
+	all, _ := b.Reminders(nil)
+	if len(all) != 2 {
+		t.Fatalf("expected 2 imported reminders, got %+v", all)
+	}
+
+	raw, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		Format  string `json:"format"`
+		Created int    `json:"created"`
+		Skipped int    `json:"skipped"`
+		Failed  int    `json:"failed"`
+		Rows    []struct {
+			Row         int    `json:"row"`
+			Status      string `json:"status"`
+			ID          string `json:"id"`
+			Diagnostics []struct {
+				Field    string `json:"field"`
+				Value    string `json:"value"`
+				Severity string `json:"severity"`
+			} `json:"diagnostics"`
+		} `json:"rows"`
+	}
+	if err := json.Unmarshal(raw, &report); err != nil {
+		t.Fatalf("invalid report: %v\n%s", err, raw)
+	}
+	if report.Format != "csv" || report.Created != 2 || report.Skipped != 1 || report.Failed != 0 || len(report.Rows) != 3 {
+		t.Fatalf("unexpected report:\n%s", raw)
+	}
+	if r := report.Rows[0]; r.Status != "created" || r.ID == "" {
+		t.Errorf("row 1 = %+v", r)
+	}
+	if r := report.Rows[1]; r.Status != "skipped" || len(r.Diagnostics) != 1 || r.Diagnostics[0].Severity != "error" {
+		t.Errorf("row 2 = %+v", r)
+	}
+	if r := report.Rows[2]; r.Status != "created" || len(r.Diagnostics) != 1 || r.Diagnostics[0].Field != "due_date" || r.Diagnostics[0].Value != "next-ish" {
+		t.Errorf("row 3 = %+v", r)
+	}
+}
+
+func TestImportUpsertAndSync(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	reportID, _ := b.CreateReminder(&reminder.Reminder{Name: "Write report", ListName: "Work"})
+	b.CreateReminder(&reminder.Reminder{Name: "Old task", ListName: "Work"})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "work.json")
+	data := `[{"id":"` + reportID + `","name":"Write report","list_name":"Work","priority":1},` +
+		`{"name":"Plan offsite","list_name":"Work"}]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := run(t, "import", path, "--mode", "sync", "--dry-run")
+	for _, want := range []string{"~ Write report [Work]", `priority: "none" → "high"`, "+ Plan offsite [Work]", "- Old task [Work] (completed)", "[dry-run] 1 created, 1 updated, 0 unchanged, 1 completed, 0 deleted"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry-run output missing %q:\n%s", want, out)
+		}
+	}
+	if all, _ := b.Reminders(nil); len(all) != 2 {
+		t.Fatalf("dry run changed reminders: %+v", all)
+	}
+
+	run(t, "import", path, "--mode", "upsert")
+	run(t, "import", path, "--mode", "upsert", "--key", "list+name")
+	all, _ := b.Reminders(nil)
+	if len(all) != 3 {
+		t.Fatalf("expected upsert not to duplicate reminders, got %+v", all)
+	}
+	if r, _ := b.Reminder(reportID); r.Priority != reminder.PriorityHigh {
+		t.Errorf("expected Write report to be updated, got %+v", r)
+	}
+
+	if _, err := runErr(t, "import", path, "--mode", "merge"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestExportImportBackup(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	created := time.Date(2025, 11, 3, 9, 15, 0, 0, time.Local)
+	done := time.Date(2025, 11, 6, 8, 30, 0, 0, time.Local)
+	b.CreateReminder(&reminder.Reminder{Name: "Ship release", ListName: "Work", Flagged: true, Completed: true, CompletionDate: &done, CreationDate: &created})
+	b.CreateReminder(&reminder.Reminder{Name: "Plan sprint", ListName: "Work"})
+
+	dir := t.TempDir()
+	for _, format := range []string{"json", "csv"} {
+		path := filepath.Join(dir, "backup."+format)
+		run(t, "export", "--format", format, "--output-file", path)
+
+		restored := newTestBackend(t)
+		run(t, "import", path)
+		all, _ := restored.Reminders(&reminder.ListFilter{ListName: "Work"})
+		if len(all) != 2 {
+			t.Fatalf("%s: expected 2 restored reminders in Work, got %+v", format, all)
+		}
+		var r *reminder.Reminder
+		for _, rem := range all {
+			if rem.Name == "Ship release" {
+				r = rem
+			}
+		}
+		if r == nil || !r.Completed || !r.Flagged {
+			t.Fatalf("%s: expected Ship release completed and flagged, got %+v", format, r)
+		}
+		if r.CompletionDate == nil || !r.CompletionDate.Equal(done) || r.CreationDate == nil || !r.CreationDate.Equal(created) {
+			t.Errorf("%s: dates not restored: completed %v, created %v", format, r.CompletionDate, r.CreationDate)
+		}
+		setBackend(b)
+	}
+}
+
+func TestBackupInTimeZone(t *testing.T) {
+	b := newTestBackend(t)
+	due := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	b.CreateReminder(&reminder.Reminder{Name: "Call Tokyo", DueDate: &due})
+
+	dir := t.TempDir()
+	for _, format := range []string{"json", "ndjson", "csv"} {
+		path := filepath.Join(dir, "backup."+format)
+		run(t, "--tz", "UTC+9", "export", "--format", format, "--output-file", path)
+		data, _ := os.ReadFile(path)
+		if !strings.Contains(string(data), "2026-03-02T00:00:00") {
+			t.Errorf("%s: expected the due date in UTC+9, got:\n%s", format, data)
+		}
+
+		restored := newTestBackend(t)
+		run(t, "--tz", "UTC+9", "import", path)
+		all, _ := restored.Reminders(nil)
+		if len(all) != 1 || all[0].DueDate == nil || !all[0].DueDate.Equal(due) {
+			t.Errorf("%s: expected due date %v, got %+v", format, due, all)
+		}
+		setBackend(b)
+	}
+}
+
+func TestBackupKeepsLocalTimes(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	local := time.Local
+	time.Local = ny
+	t.Cleanup(func() { time.Local = local })
+
+	b := newTestBackend(t)
+	due := time.Date(2026, 3, 1, 20, 0, 0, 0, ny)
+	b.CreateReminder(&reminder.Reminder{Name: "Take out bins", DueDate: &due})
+
+	dir := t.TempDir()
+	for i, args := range [][]string{{"--rfc3339", "--tz", "UTC"}, nil} {
+		path := filepath.Join(dir, fmt.Sprintf("backup%d.json", i))
+		run(t, append([]string{"export", "--output-file", path}, args...)...)
+
+		b = newTestBackend(t)
+		run(t, "import", path)
+		all, _ := b.Reminders(nil)
+		if len(all) != 1 || all[0].DueDate == nil || !all[0].DueDate.Equal(due) {
+			t.Fatalf("export %v: expected due date %v, got %+v", args, due, all)
+		}
+		out := run(t, "show", all[0].ID, "-o", "plain")
+		if !strings.Contains(out, "Due: 2026-03-01 20:00") {
+			t.Errorf("export %v: expected the due date in local time, got:\n%s", args, out)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	b.CreateList("Home")
+	invoiceID, _ := b.CreateReminder(&reminder.Reminder{Name: "Send invoice", Body: "invoice for ACME", ListName: "Work", Priority: reminder.PriorityHigh})
+	b.CreateReminder(&reminder.Reminder{Name: "Write report", ListName: "Work", Priority: reminder.PriorityLow})
+	b.CreateReminder(&reminder.Reminder{Name: "Fix sink", ListName: "Home", Priority: reminder.PriorityMedium, Flagged: true})
+
+	out := run(t, "list", "-q", `list:(Work or Home) and priority>=medium and not flagged`, "-o", "plain")
+	if !strings.Contains(out, "Send invoice") || strings.Contains(out, "Write report") || strings.Contains(out, "Fix sink") {
+		t.Errorf("list -q output = %q", out)
+	}
+
+	_, err := runErr(t, "list", "-q", "list:Work and priority>=urgent")
+	if err == nil || !strings.Contains(err.Error(), "column 25") {
+		t.Errorf("expected a parse error at column 25, got %v", err)
+	}
+
+	out = run(t, "stats", "-q", "list:Work", "-o", "json")
+	if !strings.Contains(out, `"total": 2`) {
+		t.Errorf("stats -q output = %s", out)
+	}
+
+	out = run(t, "complete", "-q", `list:Work and notes~invoice`)
+	if !strings.Contains(out, "Completed: Send invoice") {
+		t.Errorf("complete -q output = %q", out)
+	}
+	if r, _ := b.Reminder(invoiceID); !r.Completed {
+		t.Error("expected Send invoice to be completed")
+	}
+	if out := run(t, "flag", "-q", "list:Nowhere"); !strings.Contains(out, "No reminders match") {
+		t.Errorf("flag -q with no matches = %q", out)
+	}
+	if _, err := runErr(t, "complete", invoiceID, "-q", "list:Work"); err == nil {
+		t.Error("expected an error for both an ID and a query")
+	}
+}
+
+func TestViews(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	b.CreateList("Home")
+	b.CreateReminder(&reminder.Reminder{Name: "Send invoice", ListName: "Work", Priority: reminder.PriorityHigh, Flagged: true})
+	b.CreateReminder(&reminder.Reminder{Name: "Plan offsite", ListName: "Work", Priority: reminder.PriorityMedium})
+	b.CreateReminder(&reminder.Reminder{Name: "Write report", ListName: "Work", Priority: reminder.PriorityLow})
+	b.CreateReminder(&reminder.Reminder{Name: "Fix sink", ListName: "Home", Priority: reminder.PriorityHigh})
+
+	out := run(t, "view", "save", "work", "--list", "Work", "--incomplete", "--due-before", "next year", "-q", "priority>=medium or due:none")
+	if !strings.Contains(out, "Saved view: work") {
+		t.Errorf("view save output = %q", out)
+	}
+	if _, err := runErr(t, "view", "save", "broken", "-q", "priority>=urgent"); err == nil {
+		t.Error("expected an invalid query to be rejected when saving")
+	}
+
+	out = run(t, "view", "list")
+	if !strings.Contains(out, "work") || !strings.Contains(out, `--due-before "next year"`) || strings.Contains(out, "broken") {
+		t.Errorf("view list output = %q", out)
+	}
+
+	// Saving under the same name replaces the view.
+	run(t, "view", "save", "work", "--list", "Work", "-q", "priority>=medium")
+	out = run(t, "view", "run", "work", "-o", "plain")
+	if !strings.Contains(out, "Send invoice") || !strings.Contains(out, "Plan offsite") || strings.Contains(out, "Write report") || strings.Contains(out, "Fix sink") {
+		t.Errorf("view run output = %q", out)
+	}
+
+	out = run(t, "list", "--view", "work", "--flagged", "-o", "plain")
+	if !strings.Contains(out, "Send invoice") || strings.Contains(out, "Plan offsite") {
+		t.Errorf("list --view --flagged output = %q", out)
+	}
+	out = run(t, "list", "--view", "work", "--list", "Home", "-o", "plain")
+	if !strings.Contains(out, "Fix sink") || strings.Contains(out, "Send invoice") {
+		t.Errorf("list --view --list output = %q", out)
+	}
+
+	out = run(t, "export", "--view", "work", "--format", "json")
+	if !strings.Contains(out, "Plan offsite") || strings.Contains(out, "Fix sink") {
+		t.Errorf("export --view output = %s", out)
+	}
+
+	run(t, "view", "delete", "work")
+	if _, err := runErr(t, "view", "run", "work"); err == nil {
+		t.Error("expected an error running a deleted view")
+	}
+}
+
+func TestListSortGroupAndPage(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	b.CreateList("Home")
+	due := func(day int) *time.Time {
+		t := time.Date(2030, 3, day, 9, 0, 0, 0, time.Local)
+		return &t
+	}
+	b.CreateReminder(&reminder.Reminder{Name: "Plan offsite", ListName: "Work", DueDate: due(12), Priority: reminder.PriorityLow})
+	b.CreateReminder(&reminder.Reminder{Name: "Send invoice", ListName: "Work", DueDate: due(10), Priority: reminder.PriorityHigh})
+	b.CreateReminder(&reminder.Reminder{Name: "Fix sink", ListName: "Home", Priority: reminder.PriorityHigh})
+	b.CreateReminder(&reminder.Reminder{Name: "Water plants", ListName: "Home", DueDate: due(11)})
+
+	names := func(out string) string {
+		var got []string
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			for _, name := range []string{"Plan offsite", "Send invoice", "Fix sink", "Water plants"} {
+				if strings.Contains(line, name) {
+					got = append(got, name)
+				}
+			}
+		}
+		return strings.Join(got, ", ")
+	}
+
+	out := run(t, "list", "--sort", "due", "-o", "plain")
+	if got, want := names(out), "Send invoice, Water plants, Plan offsite, Fix sink"; got != want {
+		t.Errorf("--sort due = %s, want %s", got, want)
+	}
+	out = run(t, "list", "--sort", "-priority,name", "--offset", "1", "--limit", "2", "-o", "plain")
+	if got, want := names(out), "Send invoice, Plan offsite"; got != want {
+		t.Errorf("--sort -priority,name --offset 1 --limit 2 = %s, want %s", got, want)
+	}
+
+	out = run(t, "list", "--group-by", "list", "--sort", "due", "-o", "plain")
+	want := "Home (2)\n[ ] "
+	if !strings.HasPrefix(out, want) || !strings.Contains(out, "\n\nWork (2)\n") {
+		t.Errorf("--group-by list output:\n%s", out)
+	}
+	if got, want := names(out), "Water plants, Fix sink, Send invoice, Plan offsite"; got != want {
+		t.Errorf("--group-by list --sort due = %s, want %s", got, want)
+	}
+
+	out = run(t, "list", "--group-by", "priority", "-o", "json")
+	var groups []struct {
+		Group     string `json:"group"`
+		Count     int    `json:"count"`
+		Reminders []struct {
+			Name string `json:"name"`
+		} `json:"reminders"`
+	}
+	if err := json.Unmarshal([]byte(out), &groups); err != nil {
+		t.Fatalf("grouped JSON didn't parse: %v\n%s", err, out)
+	}
+	if len(groups) != 3 || groups[0].Group != "high" || groups[0].Count != 2 || groups[2].Group != "none" {
+		t.Errorf("--group-by priority JSON = %+v", groups)
+	}
+
+	out = run(t, "export", "--sort", "name", "--limit", "1", "--format", "csv")
+	if !strings.Contains(out, "Fix sink") || strings.Contains(out, "Plan offsite") {
+		t.Errorf("export --sort name --limit 1 = %s", out)
+	}
+
+	for _, args := range [][]string{
+		{"list", "--sort", "colour"},
+		{"search", "sink", "--group-by", "week"},
+		{"overdue", "--limit", "-1"},
+	} {
+		if _, err := runErr(t, args...); err == nil {
+			t.Errorf("rem %s: expected an error", strings.Join(args, " "))
+		}
+	}
+}
+
+func TestColumnsAndTemplate(t *testing.T) {
+	b := newTestBackend(t)
+	due := time.Date(2030, 3, 11, 15, 0, 0, 0, time.Local)
+	b.CreateReminder(&reminder.Reminder{Name: "Send the quarterly invoice", Body: "ACME\n#billing", DueDate: &due, Flagged: true})
+
+	out := run(t, "list", "--columns", "name,due,notes,tags")
+	for _, want := range []string{"NOTES", "TAGS", "ACME #billing", "billing", "Mar 11, 15:00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("--columns table missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "PRIORITY") {
+		t.Errorf("--columns table shows an unchosen column:\n%s", out)
+	}
+
+	out = run(t, "list", "-o", "plain", "--columns", "name,due,flagged")
+	if out != "Send the quarterly invoice\t2030-03-11 15:00\tyes\n" {
+		t.Errorf("--columns plain output = %q", out)
+	}
+
+	out = run(t, "list", "-o", `template={{.Name | truncate 12}} — {{due .DueDate "Mon 3pm"}}{{if .Flagged}} !{{end}}`)
+	if out != "Send the qu… — Mon 3pm !\n" {
+		t.Errorf("template output = %q", out)
+	}
+	out = run(t, "list", "-o", `template={{join .Tags ","}} {{date .CreationDate "2006"}}{{"\n"}}`)
+	if out != "billing "+time.Now().Format("2006")+"\n" {
+		t.Errorf("template output = %q", out)
+	}
+
+	for _, args := range [][]string{
+		{"list", "-o", "template={{.Nme}}"},
+		{"list", "-o", "template={{.Name"},
+		{"list", "--columns", "id,colour"},
+	} {
+		if _, err := runErr(t, args...); err == nil {
+			t.Errorf("rem %s: expected an error", strings.Join(args, " "))
+		}
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	b := newTestBackend(t)
+	b.CreateList("Work")
+	due := time.Date(2030, 3, 11, 15, 0, 0, 0, time.UTC)
+	id, _ := b.CreateReminder(&reminder.Reminder{Name: "Pay: rent | bills", Body: "line one\nline two", ListName: "Work", DueDate: &due, Priority: reminder.PriorityHigh})
+
+	out := run(t, "list", "-o", "yaml", "--tz", "UTC")
+	for _, want := range []string{"- id: " + id + "\n", `  name: "Pay: rent | bills"`, `  body: "line one\nline two"`, "  list_name: Work\n", "  priority_label: high\n", "  flagged: false\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("yaml output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = run(t, "list", "-o", "csv", "--tz", "UTC", "--columns", "id,name,due,notes")
+	want := "id,name,due,notes\n" + id + `,Pay: rent | bills,2030-03-11T15:00:00Z,"line one` + "\nline two\"\n"
+	if out != want {
+		t.Errorf("csv output = %q, want %q", out, want)
+	}
+
+	out = run(t, "list", "-o", "tsv", "--columns", "name,notes")
+	if out != "name\tnotes\nPay: rent | bills\tline one line two\n" {
+		t.Errorf("tsv output = %q", out)
+	}
+
+	out = run(t, "list", "-o", "markdown", "--columns", "name,list")
+	if out != "| Name | List |\n| --- | --- |\n| Pay: rent \\| bills | Work |\n" {
+		t.Errorf("markdown output = %q", out)
+	}
+
+	out = run(t, "show", id[:8], "-o", "md")
+	if !strings.Contains(out, "| Field | Value |") || !strings.Contains(out, "| Priority | high |") {
+		t.Errorf("show -o md output:\n%s", out)
+	}
+
+	out = run(t, "lists", "-o", "csv")
+	if !strings.HasPrefix(out, "id,name,color,count\n") || !strings.Contains(out, ",Work,") {
+		t.Errorf("lists -o csv output = %q", out)
+	}
+
+	out = run(t, "stats", "-o", "yaml")
+	if !strings.Contains(out, "total: 1\n") || !strings.Contains(out, "completion_rate: 0.0\n") {
+		t.Errorf("stats -o yaml output:\n%s", out)
+	}
+	out = run(t, "stats", "-o", "tsv")
+	if !strings.HasPrefix(out, "stat\tvalue\ntotal\t1\n") {
+		t.Errorf("stats -o tsv output = %q", out)
+	}
+
+	out = run(t, "add", "Call bank", "-o", "yaml")
+	if !strings.Contains(out, "name: Call bank\n") {
+		t.Errorf("add -o yaml output = %q", out)
+	}
+
+	b.CreateList("Odd")
+	for _, name := range []string{"42", "2030-03-11", "no", "3f2a plan", "-draft"} {
+		b.CreateReminder(&reminder.Reminder{Name: name, ListName: "Odd"})
+	}
+	out = run(t, "list", "-l", "Odd", "-o", "yaml")
+	for _, want := range []string{`  name: "42"` + "\n", `  name: "2030-03-11"` + "\n", `  name: "no"` + "\n", "  name: 3f2a plan\n", "  name: -draft\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("yaml output missing %q:\n%s", want, out)
+		}
+	}
+
+	_, err := runErr(t, "list", "-o", "xml")
+	if err == nil || !strings.Contains(err.Error(), "yaml, csv, tsv, markdown") {
+		t.Errorf("expected an error listing the valid formats, got %v", err)
+	}
+}
@@ -6,24 +6,35 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	completeQuery   string
+	uncompleteQuery string
+)
+
 var completeCmd = &cobra.Command{
 	Use:     "complete [id]",
 	Aliases: []string{"done"},
 	Short:   "Mark a reminder as complete",
 	Example: `  rem complete abc12345
-  rem done abc12345`,
-	Args: cobra.ExactArgs(1),
+  rem done abc12345
+  rem complete -q 'list:Groceries and not completed'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		r, err := findReminderByID(args[0])
+		targets, err := targetReminders(args, completeQuery)
 		if err != nil {
 			return err
 		}
-
-		if err := reminderSvc.CompleteReminder(r.ID); err != nil {
-			return err
+		if len(targets) == 0 {
+			fmt.Println("No reminders match the query.")
+			return nil
 		}
 
-		fmt.Printf("Completed: %s\n", r.Name)
+		for _, r := range targets {
+			if err := reminderSvc.CompleteReminder(r.ID); err != nil {
+				return err
+			}
+			fmt.Printf("Completed: %s\n", r.Name)
+		}
 		return nil
 	},
 }
@@ -31,23 +42,32 @@ var completeCmd = &cobra.Command{
 var uncompleteCmd = &cobra.Command{
 	Use:   "uncomplete [id]",
 	Short: "Mark a reminder as incomplete",
-	Args:  cobra.ExactArgs(1),
+	Example: `  rem uncomplete abc12345
+  rem uncomplete -q 'completed>=today'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		r, err := findReminderByID(args[0])
+		targets, err := targetReminders(args, uncompleteQuery)
 		if err != nil {
 			return err
 		}
-
-		if err := reminderSvc.UncompleteReminder(r.ID); err != nil {
-			return err
+		if len(targets) == 0 {
+			fmt.Println("No reminders match the query.")
+			return nil
 		}
 
-		fmt.Printf("Marked incomplete: %s\n", r.Name)
+		for _, r := range targets {
+			if err := reminderSvc.UncompleteReminder(r.ID); err != nil {
+				return err
+			}
+			fmt.Printf("Marked incomplete: %s\n", r.Name)
+		}
 		return nil
 	},
 }
 
 func init() {
+	completeCmd.Flags().StringVarP(&completeQuery, "query", "q", "", queryFlagUsage)
+	uncompleteCmd.Flags().StringVarP(&uncompleteQuery, "query", "q", "", queryFlagUsage)
 	rootCmd.AddCommand(completeCmd)
 	rootCmd.AddCommand(uncompleteCmd)
 }
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var dateCmd = &cobra.Command{
+	Use:   "date [expression]",
+	Short: "Show how a date expression is interpreted",
+	Long: `Show how rem reads a date expression, without creating anything: the
+resolved time, the grammar rules that matched, and warnings such as a time
+that has already passed today being moved to tomorrow. Use it to check what
+a --due value will become.`,
+	Example: `  rem date "next friday at 2pm"
+  rem date 5pm -o json
+  rem date "in 3 business days" --tz Europe/Berlin`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dates, err := newDateParser()
+		if err != nil {
+			return err
+		}
+		e, err := dates.Explain(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		explained := struct {
+			Input    string   `json:"input"`
+			Time     string   `json:"time"`
+			Rules    []string `json:"rules"`
+			Warnings []string `json:"warnings"`
+		}{e.Input, e.Time.Format(time.RFC3339), e.Rules, append([]string{}, e.Warnings...)}
+		switch output {
+		case ui.FormatJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(explained)
+		case ui.FormatYAML:
+			return ui.WriteYAML(os.Stdout, explained)
+		}
+
+		fmt.Printf("Input:    %s\n", e.Input)
+		fmt.Printf("Resolved: %s\n", ui.FormatDate(e.Time))
+		fmt.Printf("RFC 3339: %s\n", e.Time.Format(time.RFC3339))
+		fmt.Printf("Rules:    %s\n", strings.Join(e.Rules, " > "))
+		for _, w := range e.Warnings {
+			fmt.Printf("Warning:  %s\n", w)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dateCmd)
+}
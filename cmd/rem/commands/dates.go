@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/BRO3886/rem/internal/ui"
+)
+
+var (
+	// tzName is the --tz flag: the zone dates are shown and interpreted in.
+	// Defaults to $REM_TZ, then the local zone.
+	tzName string
+	// timeZone is the zone selected by --tz, or nil for the local zone.
+	timeZone *time.Location
+)
+
+// applyTimeZone resolves --tz and points the UI at the selected zone.
+func applyTimeZone() error {
+	timeZone = nil
+	if tzName != "" {
+		loc, err := parser.LoadLocation(tzName)
+		if err != nil {
+			return fmt.Errorf("invalid --tz: %w", err)
+		}
+		timeZone = loc
+	}
+	ui.SetLocation(timeZone)
+	return nil
+}
+
+// newDateParser returns the parser used for date arguments, resolving dates
+// in the --tz zone and with the working calendar from
+// parser.DefaultCalendarPath when there is one. extra options are applied
+// last.
+func newDateParser(extra ...parser.Option) (*parser.Parser, error) {
+	var opts []parser.Option
+	if timeZone != nil {
+		opts = append(opts, parser.WithLocation(timeZone))
+	}
+
+	path, err := parser.DefaultCalendarPath()
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		cal, err := parser.LoadCalendar(path)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, parser.WithCalendar(cal))
+	}
+	return parser.New(append(opts, extra...)...), nil
+}
+
+// parseDate parses a date argument with the configured date parser.
+func parseDate(input string) (time.Time, error) {
+	p, err := newDateParser()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return p.ParseDate(input)
+}
+
+// parseRecurrence parses a --repeat argument with the configured date parser.
+func parseRecurrence(input string) (*reminder.Recurrence, time.Time, error) {
+	p, err := newDateParser()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return p.ParseRecurrence(input)
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&tzName, "tz", os.Getenv("REM_TZ"),
+		"Time zone to show and interpret dates in, e.g. Europe/Berlin or PST (default: local)")
+}
@@ -9,23 +9,38 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var deleteForce bool
+var (
+	deleteForce bool
+	deleteQuery string
+)
 
 var deleteCmd = &cobra.Command{
 	Use:     "delete [id]",
 	Aliases: []string{"rm", "remove"},
 	Short:   "Delete a reminder",
 	Example: `  rem delete abc12345
-  rem rm abc12345 --force`,
-	Args: cobra.ExactArgs(1),
+  rem rm abc12345 --force
+  rem delete -q 'completed<"90 days ago"'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		r, err := findReminderByID(args[0])
+		targets, err := targetReminders(args, deleteQuery)
 		if err != nil {
 			return err
 		}
+		if len(targets) == 0 {
+			fmt.Println("No reminders match the query.")
+			return nil
+		}
 
 		if !deleteForce {
-			fmt.Printf("Delete reminder '%s'? (y/N): ", r.Name)
+			if len(targets) == 1 {
+				fmt.Printf("Delete reminder '%s'? (y/N): ", targets[0].Name)
+			} else {
+				for _, r := range targets {
+					fmt.Printf("  %s [%s]\n", r.Name, r.ListName)
+				}
+				fmt.Printf("Delete these %d reminders? (y/N): ", len(targets))
+			}
 			reader := bufio.NewReader(os.Stdin)
 			answer, _ := reader.ReadString('\n')
 			answer = strings.TrimSpace(strings.ToLower(answer))
@@ -35,16 +50,18 @@ var deleteCmd = &cobra.Command{
 			}
 		}
 
-		if err := reminderSvc.DeleteReminder(r.ID); err != nil {
-			return err
+		for _, r := range targets {
+			if err := reminderSvc.DeleteReminder(r.ID); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted: %s\n", r.Name)
 		}
-
-		fmt.Printf("Deleted: %s\n", r.Name)
 		return nil
 	},
 }
 
 func init() {
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Skip confirmation prompt")
+	deleteCmd.Flags().StringVarP(&deleteQuery, "query", "q", "", queryFlagUsage)
 	rootCmd.AddCommand(deleteCmd)
 }
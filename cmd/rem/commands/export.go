@@ -5,7 +5,6 @@ import (
 	"os"
 
 	"github.com/BRO3886/rem/internal/export"
-	"github.com/BRO3886/rem/internal/reminder"
 	"github.com/spf13/cobra"
 )
 
@@ -14,24 +13,51 @@ var (
 	exportFormat     string
 	exportOutputFile string
 	exportIncomplete bool
+	exportRFC3339    bool
+	exportQuery      string
+	exportView       string
 )
 
 var exportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export reminders to JSON or CSV",
+	Short: "Export reminders to JSON, NDJSON, CSV, iCalendar, todo.txt, Markdown, Org or TaskPaper",
+	Long: `Export reminders to JSON, NDJSON, CSV, iCalendar, todo.txt, Markdown, Org or TaskPaper.
+
+--sort, --limit and --offset work as in rem list. --group-by orders the
+reminders so that each group's reminders are together; the exported file
+itself stays flat.`,
 	Example: `  rem export --list Work --format json > work.json
   rem export --format csv --output reminders.csv
-  rem export --incomplete --format json`,
+  rem export --incomplete --format json
+  rem export -q 'list:Work and completed>"30 days ago"' --format csv
+  rem export --view work-week --format markdown
+  rem export --sort -modified --limit 50 --format csv
+  rem export --format ndjson | jq -c 'select(.flagged)'
+  rem export --rfc3339 --tz UTC > reminders.json
+  rem export --format ics > reminders.ics
+  rem export --incomplete --format todotxt > todo.txt
+  rem export --list Work --incomplete --format markdown
+  rem export --format org > reminders.org
+  rem export --format taskpaper > reminders.taskpaper`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		filter := &reminder.ListFilter{
-			ListName: exportList,
+		filter, viewQuery, err := viewFilter(exportView)
+		if err != nil {
+			return err
+		}
+		if exportList != "" {
+			filter.ListName = exportList
 		}
 		if exportIncomplete {
 			v := false
 			filter.Completed = &v
 		}
 
-		reminders, err := reminderSvc.ListReminders(filter)
+		reminders, err := selectReminders(filter, viewQuery, exportQuery)
+		if err != nil {
+			return err
+		}
+
+		reminders, _, err = exportListing.arrange(reminders)
 		if err != nil {
 			return err
 		}
@@ -48,19 +74,43 @@ var exportCmd = &cobra.Command{
 			w = os.Stdout
 		}
 
+		var opts []export.Option
+		if exportRFC3339 {
+			opts = append(opts, export.WithRFC3339())
+		}
+		if timeZone != nil {
+			opts = append(opts, export.WithLocation(timeZone))
+		}
+
 		switch exportFormat {
 		case "csv":
-			return export.ExportCSV(w, reminders)
+			return export.ExportCSV(w, reminders, opts...)
+		case "ndjson":
+			return export.ExportNDJSON(w, reminders, opts...)
+		case "ics":
+			return export.ExportICS(w, reminders, opts...)
+		case "todotxt":
+			return export.ExportTodoTxt(w, reminders, opts...)
+		case "markdown", "md":
+			return export.ExportMarkdown(w, reminders, opts...)
+		case "org":
+			return export.ExportOrg(w, reminders, opts...)
+		case "taskpaper":
+			return export.ExportTaskPaper(w, reminders, opts...)
 		default:
-			return export.ExportJSON(w, reminders)
+			return export.ExportJSON(w, reminders, opts...)
 		}
 	},
 }
 
 func init() {
 	exportCmd.Flags().StringVarP(&exportList, "list", "l", "", "Export reminders from a specific list")
-	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "Export format: json, csv")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "Export format: json, ndjson, csv, ics, todotxt, markdown, org, taskpaper")
 	exportCmd.Flags().StringVar(&exportOutputFile, "output-file", "", "Output file path (default: stdout)")
 	exportCmd.Flags().BoolVar(&exportIncomplete, "incomplete", false, "Export only incomplete reminders")
+	exportCmd.Flags().BoolVar(&exportRFC3339, "rfc3339", false, "Write timestamps as RFC 3339 with their UTC offset")
+	exportCmd.Flags().StringVarP(&exportQuery, "query", "q", "", queryFlagUsage)
+	exportListing.register(exportCmd)
+	exportCmd.Flags().StringVar(&exportView, "view", "", "Export the reminders of a saved view (see 'rem view')")
 	rootCmd.AddCommand(exportCmd)
 }
@@ -6,21 +6,33 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	flagQuery   string
+	unflagQuery string
+)
+
 var flagCmd = &cobra.Command{
 	Use:   "flag [id]",
 	Short: "Flag a reminder",
-	Args:  cobra.ExactArgs(1),
+	Example: `  rem flag abc12345
+  rem flag -q 'overdue and priority:high'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		r, err := findReminderByID(args[0])
+		targets, err := targetReminders(args, flagQuery)
 		if err != nil {
 			return err
 		}
-
-		if err := reminderSvc.FlagReminder(r.ID); err != nil {
-			return err
+		if len(targets) == 0 {
+			fmt.Println("No reminders match the query.")
+			return nil
 		}
 
-		fmt.Printf("Flagged: %s\n", r.Name)
+		for _, r := range targets {
+			if err := reminderSvc.FlagReminder(r.ID); err != nil {
+				return err
+			}
+			fmt.Printf("Flagged: %s\n", r.Name)
+		}
 		return nil
 	},
 }
@@ -28,23 +40,32 @@ var flagCmd = &cobra.Command{
 var unflagCmd = &cobra.Command{
 	Use:   "unflag [id]",
 	Short: "Remove flag from a reminder",
-	Args:  cobra.ExactArgs(1),
+	Example: `  rem unflag abc12345
+  rem unflag -q 'flagged and completed'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		r, err := findReminderByID(args[0])
+		targets, err := targetReminders(args, unflagQuery)
 		if err != nil {
 			return err
 		}
-
-		if err := reminderSvc.UnflagReminder(r.ID); err != nil {
-			return err
+		if len(targets) == 0 {
+			fmt.Println("No reminders match the query.")
+			return nil
 		}
 
-		fmt.Printf("Unflagged: %s\n", r.Name)
+		for _, r := range targets {
+			if err := reminderSvc.UnflagReminder(r.ID); err != nil {
+				return err
+			}
+			fmt.Printf("Unflagged: %s\n", r.Name)
+		}
 		return nil
 	},
 }
 
 func init() {
+	flagCmd.Flags().StringVarP(&flagQuery, "query", "q", "", queryFlagUsage)
+	unflagCmd.Flags().StringVarP(&unflagQuery, "query", "q", "", queryFlagUsage)
 	rootCmd.AddCommand(flagCmd)
 	rootCmd.AddCommand(unflagCmd)
 }
@@ -1,105 +1,520 @@
 package commands
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/BRO3886/rem/internal/export"
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/BRO3886/rem/internal/service"
 	"github.com/spf13/cobra"
 )
 
 var (
-	importList   string
-	importDryRun bool
+	importList       string
+	importDryRun     bool
+	importFormat     string
+	importMap        string
+	importMapFile    string
+	importDelimiter  string
+	importEncoding   string
+	importDateLayout string
+	importStrict     bool
+	importReportPath string
+	importMode       string
+	importKey        string
+	importMissing    string
 )
 
+// importFormats maps file extensions to import formats.
+var importFormats = map[string]string{
+	".csv":       "csv",
+	".json":      "json",
+	".ndjson":    "ndjson",
+	".jsonl":     "ndjson",
+	".ics":       "ics",
+	".txt":       "todotxt",
+	".md":        "markdown",
+	".markdown":  "markdown",
+	".org":       "org",
+	".taskpaper": "taskpaper",
+}
+
 var importCmd = &cobra.Command{
 	Use:   "import [file]",
-	Short: "Import reminders from JSON or CSV file",
+	Short: "Import reminders from rem, other task managers and plain-text formats",
+	Long: `Import reminders from a file. Use "-" to read from standard input.
+
+Formats:
+  json, ndjson, csv      rem's own exports
+  ics                    iCalendar VTODOs
+  todotxt, markdown,     plain-text task lists
+  org, taskpaper
+  todoist                Todoist CSV template export
+  things                 Things 3 JSON export
+  mstodo                 Microsoft To Do lists from the Graph API
+  googletasks            Google Tasks.json from Google Takeout
+
+The format is detected from the file's content, then from its extension,
+unless --format is given. todo.txt is only picked by its .txt extension.
+
+Lists that don't exist are created. Reminders come back completed and
+flagged as they were, and JSON, NDJSON and CSV exports also restore their
+completion, creation and modification dates, so rem export works as a
+backup.
+
+CSV files from other tools can be read as they are by mapping their
+columns to reminder fields with --map or a --map-file, which holds one
+"key = value" entry per line. Fields are id, name, body, list_name,
+due_date, remind_me_date, priority, flagged, completed, url, recurrence,
+completion_date, creation_date and modification_date; "<field>.layout"
+for the date fields, delimiter and encoding set the rest. Layouts are Go
+time layouts or "natural" for dates like "tomorrow 5pm". Any of these
+options implies --format csv.
+
+JSON, NDJSON and CSV rows are validated as they are read. Rows without a
+name are skipped and values that can't be read (dates, priorities,
+booleans, repeat rules) are dropped from their row, with a message on
+stderr for each. --strict imports nothing if any row has an error or a
+warning, so a single dropped value stops the import, and --report writes
+the outcome of every row as JSON.
+
+By default every row creates a reminder. --mode upsert matches rows to
+existing reminders by ID, or by a natural key such as --key list+name+due,
+and updates the fields that changed; fields a row leaves empty are kept.
+--mode sync also completes the reminders in the file's lists that the
+file no longer has, or deletes them with --missing delete. Both print the
+changes as a diff, which --dry-run previews without making them.`,
 	Example: `  rem import work.json
   rem import reminders.csv --list "Imported"
-  rem import --dry-run data.json`,
+  rem import tasks.ics
+  rem import todo.txt
+  rem import meeting-notes.md
+  rem import ~/org/tasks.org
+  rem import projects.taskpaper
+  rem import todoist-work.csv --list "Work"
+  rem import Takeout/Tasks/Tasks.json
+  rem import --dry-run data.json
+  rem import work.json --mode upsert
+  rem import --mode sync --dry-run work.json
+  rem import tasks.csv --mode upsert --key list+name+due
+  rem import --strict --report report.json team.csv
+  rem import tasks.csv --map name=Task,due_date=Deadline,list_name=Project --date-layout 02/01/2006
+  rem import export.csv --delimiter ';' --encoding windows-1252 --map-file team.map
+  rem export --format ndjson | jq -c 'select(.priority > 0)' | rem import - --format ndjson`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath := args[0]
 
-		f, err := os.Open(filePath)
+		var in io.Reader = os.Stdin
+		if filePath != "-" {
+			file, err := os.Open(filePath)
+			if err != nil {
+				return fmt.Errorf("failed to open file: %w", err)
+			}
+			defer file.Close()
+			in = file
+		}
+		f := bufio.NewReaderSize(in, export.DetectSize)
+
+		mapping, err := importCSVMapping()
 		if err != nil {
-			return fmt.Errorf("failed to open file: %w", err)
+			return err
+		}
+		importer, mode, err := newImporter()
+		if err != nil {
+			return err
+		}
+		format := importFormat
+		if format == "" && mapping != nil {
+			format = "csv"
+		}
+		if format == "" {
+			head, _ := f.Peek(export.DetectSize)
+			format = export.DetectFormat(head)
+		}
+		if format == "" {
+			ext := strings.ToLower(filepath.Ext(filePath))
+			format = importFormats[ext]
+			if format == "" {
+				if filePath == "-" {
+					filePath = "standard input"
+				}
+				return fmt.Errorf("could not detect the format of %s (use --format)", filePath)
+			}
 		}
-		defer f.Close()
 
-		ext := strings.ToLower(filepath.Ext(filePath))
+		// Diagnostics for a row arrive before the row itself is returned.
+		diags := map[int][]export.Diagnostic{}
+		opts := []export.Option{export.WithDiagnostics(func(d export.Diagnostic) {
+			diags[d.Row] = append(diags[d.Row], d)
+		})}
+		if mapping != nil {
+			opts = append(opts, export.WithCSVMapping(mapping))
+		}
+		next, err := importReader(f, format, opts...)
+		if err != nil {
+			return err
+		}
 
-		var importFunc func() error
+		report := &importReport{File: args[0], Format: format, Mode: string(mode), DryRun: importDryRun}
+		if importStrict {
+			// Validate every row before creating any.
+			var all []*reminder.Reminder
+			for {
+				r, err := next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				all = append(all, r)
+			}
+			if len(diags) > 0 {
+				for i, r := range all {
+					for _, d := range diags[i+1] {
+						fmt.Fprintf(os.Stderr, "%s: %s\n", d.Severity, d)
+					}
+					report.add(importRow{Row: i + 1, Name: r.Name, Status: importSkipped, Diagnostics: diags[i+1]})
+				}
+				if err := report.write(importReportPath); err != nil {
+					return err
+				}
+				return fmt.Errorf("%d of %d rows have errors or warnings; nothing was imported (--strict)", len(diags), len(all))
+			}
+			next = sliceReader(all)
+		}
 
-		switch ext {
-		case ".csv":
-			reminders, err := export.ImportCSV(f)
+		for row := 1; ; row++ {
+			r, err := next()
+			if err == io.EOF {
+				break
+			}
 			if err != nil {
+				if werr := report.write(importReportPath); werr != nil {
+					return werr
+				}
 				return err
 			}
-			importFunc = func() error {
-				for _, r := range reminders {
-					if importList != "" {
-						r.ListName = importList
-					}
-					if importDryRun {
-						dueStr := ""
-						if r.DueDate != nil {
-							dueStr = " (due: " + r.DueDate.Format("2006-01-02 15:04") + ")"
-						}
-						fmt.Printf("[dry-run] Would create: %s%s [%s]\n", r.Name, dueStr, r.ListName)
-						continue
-					}
-					id, err := reminderSvc.CreateReminder(r)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Warning: failed to create '%s': %v\n", r.Name, err)
-						continue
-					}
-					fmt.Printf("Created: %s (ID: %s)\n", r.Name, shortIDStr(id))
+
+			result := importRow{Row: row, Name: r.Name, Diagnostics: diags[row]}
+			delete(diags, row)
+			skip := false
+			for _, d := range result.Diagnostics {
+				if d.Severity == export.SeverityError {
+					skip = true
 				}
-				return nil
+				fmt.Fprintf(os.Stderr, "%s: %s\n", d.Severity, d)
+			}
+			if skip {
+				result.Status = importSkipped
+				report.add(result)
+				continue
 			}
-		case ".json":
-			reminders, err := export.ImportJSON(f)
+
+			if importList != "" {
+				r.ListName = importList
+			}
+			change, err := importer.Apply(r)
+			result.ID, result.Changes = change.ID, change.Fields
 			if err != nil {
-				return err
+				fmt.Fprintf(os.Stderr, "Warning: failed to import '%s': %v\n", r.Name, err)
+				result.Status, result.Error = importFailed, err.Error()
+				report.add(result)
+				continue
 			}
-			importFunc = func() error {
-				for _, r := range reminders {
-					if importList != "" {
-						r.ListName = importList
-					}
-					if importDryRun {
-						dueStr := ""
-						if r.DueDate != nil {
-							dueStr = " (due: " + r.DueDate.Format("2006-01-02 15:04") + ")"
-						}
-						fmt.Printf("[dry-run] Would create: %s%s [%s]\n", r.Name, dueStr, r.ListName)
-						continue
-					}
-					id, err := reminderSvc.CreateReminder(r)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Warning: failed to create '%s': %v\n", r.Name, err)
-						continue
+			result.Status = string(change.Action)
+			report.add(result)
+			if mode != service.ImportCreate {
+				printImportChange(change)
+			} else if importDryRun {
+				dueStr := ""
+				if r.DueDate != nil {
+					dueStr = " (due: " + r.DueDate.Format("2006-01-02 15:04") + ")"
+				}
+				fmt.Printf("[dry-run] Would create: %s%s [%s]\n", r.Name, dueStr, r.ListName)
+			} else {
+				fmt.Printf("Created: %s (ID: %s)\n", r.Name, shortIDStr(change.ID))
+			}
+		}
+
+		if mode == service.ImportSync {
+			if report.Skipped > 0 || report.Failed > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: %d rows were skipped or failed; reminders missing from the file were left alone\n", report.Skipped+report.Failed)
+			} else {
+				changes, err := importer.Finish()
+				for _, change := range changes {
+					printImportChange(change)
+					report.add(importRow{Name: change.Name, Status: string(change.Action), ID: change.ID})
+				}
+				if err != nil {
+					if werr := report.write(importReportPath); werr != nil {
+						return werr
 					}
-					fmt.Printf("Created: %s (ID: %s)\n", r.Name, shortIDStr(id))
+					return err
 				}
-				return nil
 			}
-		default:
-			return fmt.Errorf("unsupported file format: %s (use .json or .csv)", ext)
 		}
 
-		return importFunc()
+		switch {
+		case mode != service.ImportCreate:
+			summary := fmt.Sprintf("%d created, %d updated, %d unchanged", report.Created, report.Updated, report.Unchanged)
+			if mode == service.ImportSync {
+				summary += fmt.Sprintf(", %d completed, %d deleted", report.Completed, report.Deleted)
+			}
+			if report.Skipped > 0 || report.Failed > 0 {
+				summary += fmt.Sprintf(", %d skipped, %d failed", report.Skipped, report.Failed)
+			}
+			if importDryRun {
+				summary = "[dry-run] " + summary
+			}
+			fmt.Println(summary)
+		case report.Skipped > 0 || report.Failed > 0:
+			fmt.Fprintf(os.Stderr, "%d created, %d skipped, %d failed\n", report.Created, report.Skipped, report.Failed)
+		}
+		return report.write(importReportPath)
 	},
 }
 
+// Import row statuses, besides the service.ImportAction of each imported
+// row. In a dry run, the actions are what would have been done.
+const (
+	importCreated = string(service.ImportCreated)
+	importSkipped = "skipped"
+	importFailed  = "failed"
+)
+
+// importReport summarizes an import for --report.
+type importReport struct {
+	File      string      `json:"file"`
+	Format    string      `json:"format"`
+	Mode      string      `json:"mode"`
+	DryRun    bool        `json:"dry_run"`
+	Created   int         `json:"created"`
+	Updated   int         `json:"updated"`
+	Unchanged int         `json:"unchanged"`
+	Completed int         `json:"completed"`
+	Deleted   int         `json:"deleted"`
+	Skipped   int         `json:"skipped"`
+	Failed    int         `json:"failed"`
+	Rows      []importRow `json:"rows"`
+}
+
+// importRow is the outcome of one row. Reminders a sync completed or
+// deleted have no row.
+type importRow struct {
+	Row         int                   `json:"row,omitempty"`
+	Name        string                `json:"name"`
+	Status      string                `json:"status"`
+	ID          string                `json:"id,omitempty"`
+	Changes     []service.FieldChange `json:"changes,omitempty"`
+	Error       string                `json:"error,omitempty"`
+	Diagnostics []export.Diagnostic   `json:"diagnostics,omitempty"`
+}
+
+func (r *importReport) add(row importRow) {
+	switch row.Status {
+	case importCreated:
+		r.Created++
+	case string(service.ImportUpdated):
+		r.Updated++
+	case string(service.ImportUnchanged):
+		r.Unchanged++
+	case string(service.ImportCompleted):
+		r.Completed++
+	case string(service.ImportDeleted):
+		r.Deleted++
+	case importSkipped:
+		r.Skipped++
+	case importFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// write saves the report as JSON to path, if one was given.
+func (r *importReport) write(path string) error {
+	if path == "" {
+		return nil
+	}
+	if r.Rows == nil {
+		r.Rows = []importRow{}
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode import report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write import report: %w", err)
+	}
+	return nil
+}
+
+// importReader returns a function yielding the reminders in f one at a
+// time, and io.EOF after the last. NDJSON is decoded as it is read; the
+// other formats are parsed up front.
+func importReader(f io.Reader, format string, opts ...export.Option) (func() (*reminder.Reminder, error), error) {
+	if timeZone != nil {
+		opts = append(opts, export.WithLocation(timeZone))
+	}
+
+	var (
+		reminders []*reminder.Reminder
+		err       error
+	)
+	switch format {
+	case "ndjson":
+		return export.NewNDJSONDecoder(f, opts...).Decode, nil
+	case "csv":
+		reminders, err = export.ImportCSV(f, opts...)
+	case "json":
+		reminders, err = export.ImportJSON(f, opts...)
+	case "ics":
+		reminders, err = export.ImportICS(f, opts...)
+	case "todotxt":
+		reminders, err = export.ImportTodoTxt(f, opts...)
+	case "markdown", "md":
+		reminders, err = export.ImportMarkdown(f, opts...)
+	case "org":
+		reminders, err = export.ImportOrg(f, opts...)
+	case "taskpaper":
+		reminders, err = export.ImportTaskPaper(f, opts...)
+	case "todoist":
+		reminders, err = export.ImportTodoist(f, opts...)
+	case "things":
+		reminders, err = export.ImportThings(f, opts...)
+	case "mstodo":
+		reminders, err = export.ImportMSToDo(f, opts...)
+	case "googletasks":
+		reminders, err = export.ImportGoogleTasks(f, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s (use json, ndjson, csv, ics, todotxt, markdown, org, taskpaper, todoist, things, mstodo or googletasks)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sliceReader(reminders), nil
+}
+
+// sliceReader yields reminders one at a time, then io.EOF.
+func sliceReader(reminders []*reminder.Reminder) func() (*reminder.Reminder, error) {
+	return func() (*reminder.Reminder, error) {
+		if len(reminders) == 0 {
+			return nil, io.EOF
+		}
+		r := reminders[0]
+		reminders = reminders[1:]
+		return r, nil
+	}
+}
+
+// newImporter builds the importer for --mode, --key and --missing.
+func newImporter() (*service.Importer, service.ImportMode, error) {
+	mode, err := service.ParseImportMode(importMode)
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := service.ParseImportKey(importKey)
+	if err != nil {
+		return nil, "", err
+	}
+	var deleteMissing bool
+	switch importMissing {
+	case "complete":
+	case "delete":
+		deleteMissing = true
+	default:
+		return nil, "", fmt.Errorf("invalid --missing %q (use complete or delete)", importMissing)
+	}
+	return service.NewImporter(reminderSvc, listSvc, service.ImportOptions{
+		Mode:          mode,
+		Key:           key,
+		DeleteMissing: deleteMissing,
+		DryRun:        importDryRun,
+	}), mode, nil
+}
+
+// printImportChange prints a change made by an upsert or sync as a line
+// of a diff: + for creates, ~ for updates with a line per changed field,
+// and - for reminders a sync completed or deleted.
+func printImportChange(c service.ImportChange) {
+	list := ""
+	if c.List != "" {
+		list = " [" + c.List + "]"
+	}
+	switch c.Action {
+	case service.ImportCreated:
+		fmt.Printf("+ %s%s\n", c.Name, list)
+	case service.ImportUpdated:
+		fmt.Printf("~ %s%s\n", c.Name, list)
+		for _, f := range c.Fields {
+			fmt.Printf("    %s: %s → %s\n", f.Field, importValue(f.Old), importValue(f.New))
+		}
+	case service.ImportCompleted, service.ImportDeleted:
+		fmt.Printf("- %s%s (%s)\n", c.Name, list, c.Action)
+	}
+}
+
+// importValue quotes a field value for a diff line, showing empty values
+// as "(none)".
+func importValue(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+// importCSVMapping builds the CSV mapping from the mapping file and the
+// flags, which override it. It returns nil when none are given.
+func importCSVMapping() (*export.CSVMapping, error) {
+	if importMapFile == "" && importMap == "" && importDelimiter == "" && importEncoding == "" && importDateLayout == "" {
+		return nil, nil
+	}
+
+	m := &export.CSVMapping{}
+	if importMapFile != "" {
+		var err error
+		if m, err = export.LoadCSVMapping(importMapFile); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.ParseMap(importMap); err != nil {
+		return nil, fmt.Errorf("invalid --map: %w", err)
+	}
+	for _, f := range []struct{ flag, key, value string }{
+		{"delimiter", "delimiter", importDelimiter},
+		{"encoding", "encoding", importEncoding},
+		{"date-layout", "layout", importDateLayout},
+	} {
+		if f.value == "" {
+			continue
+		}
+		if err := m.Set(f.key, f.value); err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", f.flag, err)
+		}
+	}
+	return m, nil
+}
+
 func init() {
 	importCmd.Flags().StringVarP(&importList, "list", "l", "", "Import all reminders into this list")
 	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Preview import without creating reminders")
+	importCmd.Flags().StringVar(&importFormat, "format", "", "Input format: json, ndjson, csv, ics, todotxt, markdown, org, taskpaper, todoist, things, mstodo, googletasks (default: detected)")
+	importCmd.Flags().StringVar(&importMode, "mode", "create", "Import mode: create, upsert (update matching reminders) or sync (upsert, then handle reminders missing from the file)")
+	importCmd.Flags().StringVar(&importKey, "key", "id", "Match reminders on id, or fields joined with '+' from list, name, due and url")
+	importCmd.Flags().StringVar(&importMissing, "missing", "complete", "What sync does with reminders missing from the file: complete or delete")
+	importCmd.Flags().BoolVar(&importStrict, "strict", false, "Import nothing if any row has an error or a warning")
+	importCmd.Flags().StringVar(&importReportPath, "report", "", "Write a JSON report of created, skipped and failed rows to a file")
+	importCmd.Flags().StringVar(&importMap, "map", "", "Map CSV columns to fields, e.g. name=Title,due_date=Deadline")
+	importCmd.Flags().StringVar(&importMapFile, "map-file", "", "Read the CSV column mapping from a file")
+	importCmd.Flags().StringVar(&importDelimiter, "delimiter", "", "CSV field delimiter, e.g. ';' or tab (default: ',')")
+	importCmd.Flags().StringVar(&importEncoding, "encoding", "", "CSV character encoding: utf-8, utf-16, latin1, windows-1252 (default: utf-8)")
+	importCmd.Flags().StringVar(&importDateLayout, "date-layout", "", `CSV date layout, e.g. 02/01/2006 or "natural" (default: RFC 3339 or rem's layout)`)
 	rootCmd.AddCommand(importCmd)
 }
@@ -6,7 +6,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/BRO3886/rem/internal/parser"
 	"github.com/BRO3886/rem/internal/reminder"
 	"github.com/spf13/cobra"
 )
@@ -195,7 +194,7 @@ func runAddInteractive() error {
 	}
 
 	if dueStr != "" {
-		dueDate, err := parser.ParseDate(dueStr)
+		dueDate, err := parseDate(dueStr)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: could not parse due date '%s': %v\n", dueStr, err)
 		} else {
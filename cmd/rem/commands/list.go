@@ -2,11 +2,7 @@ package commands
 
 import (
 	"fmt"
-	"os"
 
-	"github.com/BRO3886/rem/internal/parser"
-	"github.com/BRO3886/rem/internal/reminder"
-	"github.com/BRO3886/rem/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -18,21 +14,36 @@ var (
 	listDueBefore  string
 	listDueAfter   string
 	listSearch     string
+	listQuery      string
+	listView       string
 )
 
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List reminders",
-	Long:    `List reminders with optional filtering by list, completion status, date range, and more.`,
+	Long: `List reminders with optional filtering by list, completion status, date range, and more.
+With --view, the saved view's filters apply first. Each filter flag given
+replaces the view's value for it (--list Home lists Home, whatever list the
+view names), and --query narrows the view's query.`,
 	Example: `  rem list --list Work --incomplete
   rem list --due-before "2026-02-15" --output json
   rem list --flagged
+  rem list -q 'list:(Work or Home) and due<eow and priority>=medium'
+  rem list --view work-week --flagged
+  rem list --incomplete --sort due,-priority --limit 10
+  rem list --group-by list --sort due
   rem ls`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		filter := &reminder.ListFilter{
-			ListName:    listListName,
-			SearchQuery: listSearch,
+		filter, viewQuery, err := viewFilter(listView)
+		if err != nil {
+			return err
+		}
+		if listListName != "" {
+			filter.ListName = listListName
+		}
+		if listSearch != "" {
+			filter.SearchQuery = listSearch
 		}
 
 		if listIncomplete {
@@ -48,27 +59,30 @@ var listCmd = &cobra.Command{
 			filter.Flagged = &v
 		}
 		if listDueBefore != "" {
-			t, err := parser.ParseDate(listDueBefore)
+			t, err := parseDate(listDueBefore)
 			if err != nil {
 				return fmt.Errorf("invalid --due-before date: %w", err)
 			}
 			filter.DueBefore = &t
 		}
 		if listDueAfter != "" {
-			t, err := parser.ParseDate(listDueAfter)
+			t, err := parseDate(listDueAfter)
 			if err != nil {
 				return fmt.Errorf("invalid --due-after date: %w", err)
 			}
 			filter.DueAfter = &t
 		}
 
-		reminders, err := reminderSvc.ListReminders(filter)
+		reminders, err := selectReminders(filter, viewQuery, listQuery)
 		if err != nil {
 			return err
 		}
 
-		format := ui.ParseOutputFormat(outputFormat)
-		ui.PrintReminders(os.Stdout, reminders, format)
+		reminders, group, err := listListing.arrange(reminders)
+		if err != nil {
+			return err
+		}
+		printListing(reminders, group, output)
 		return nil
 	},
 }
@@ -81,6 +95,9 @@ func init() {
 	listCmd.Flags().StringVar(&listDueBefore, "due-before", "", "Show reminders due before this date")
 	listCmd.Flags().StringVar(&listDueAfter, "due-after", "", "Show reminders due after this date")
 	listCmd.Flags().StringVarP(&listSearch, "search", "s", "", "Search in title and notes")
+	listCmd.Flags().StringVarP(&listQuery, "query", "q", "", queryFlagUsage)
+	listListing.register(listCmd)
+	listCmd.Flags().StringVar(&listView, "view", "", "Start from a saved view (see 'rem view'); filter flags replace its values and --query narrows it")
 
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/BRO3886/rem/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// listingOptions are the --sort, --group-by, --limit and --offset flags of
+// the commands that list reminders.
+type listingOptions struct {
+	sort    string
+	groupBy string
+	limit   int
+	offset  int
+}
+
+var (
+	listListing     listingOptions
+	searchListing   listingOptions
+	overdueListing  listingOptions
+	upcomingListing listingOptions
+	exportListing   listingOptions
+	viewRunListing  listingOptions
+)
+
+func (o *listingOptions) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.sort, "sort", "", "Sort by comma-separated keys: due, priority, name, created, modified, list ('-' for descending)")
+	cmd.Flags().StringVar(&o.groupBy, "group-by", "", "Group by list, due-day, priority or status")
+	cmd.Flags().IntVar(&o.limit, "limit", 0, "Show at most this many reminders (0 for all)")
+	cmd.Flags().IntVar(&o.offset, "offset", 0, "Skip this many reminders")
+}
+
+// arrange sorts reminders, brings the reminders of each group together and
+// applies --offset and --limit. Pages are taken after grouping, so groups
+// continue across pages.
+func (o *listingOptions) arrange(reminders []*reminder.Reminder) ([]*reminder.Reminder, ui.GroupBy, error) {
+	keys, err := reminder.ParseSortKeys(o.sort)
+	if err != nil {
+		return nil, ui.GroupNone, fmt.Errorf("invalid --sort: %w", err)
+	}
+	group, err := ui.ParseGroupBy(o.groupBy)
+	if err != nil {
+		return nil, ui.GroupNone, fmt.Errorf("invalid --group-by: %w", err)
+	}
+	if o.limit < 0 || o.offset < 0 {
+		return nil, ui.GroupNone, fmt.Errorf("--limit and --offset can't be negative")
+	}
+
+	reminder.Sort(reminders, keys)
+	ui.SortByGroup(reminders, group)
+
+	if o.offset >= len(reminders) {
+		return nil, group, nil
+	}
+	reminders = reminders[o.offset:]
+	if o.limit > 0 && o.limit < len(reminders) {
+		reminders = reminders[:o.limit]
+	}
+	return reminders, group, nil
+}
+
+// printListing prints arranged reminders, in sections when they are
+// grouped.
+func printListing(reminders []*reminder.Reminder, group ui.GroupBy, format ui.OutputFormat) {
+	if group == ui.GroupNone {
+		ui.PrintReminders(os.Stdout, reminders, format)
+		return
+	}
+	ui.PrintReminderGroups(os.Stdout, ui.GroupReminders(reminders, group), format)
+}
@@ -24,7 +24,7 @@ var listsCmd = &cobra.Command{
 			return err
 		}
 
-		format := ui.ParseOutputFormat(outputFormat)
+		format := output
 		ui.PrintLists(os.Stdout, lists, format, listsShowCount)
 		return nil
 	},
@@ -41,9 +41,8 @@ var listCreateCmd = &cobra.Command{
 			return err
 		}
 
-		format := ui.ParseOutputFormat(outputFormat)
-		if format == ui.FormatJSON {
-			fmt.Fprintf(os.Stdout, `{"id": "%s", "name": "%s"}`+"\n", list.ID, list.Name)
+		if output.Structured() {
+			ui.PrintCreated(os.Stdout, list.ID, list.Name, output)
 		} else {
 			fmt.Printf("Created list: %s\n", list.Name)
 		}
@@ -0,0 +1,48 @@
+package commands
+
+import "github.com/spf13/cobra"
+
+var templateHelpCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Choosing columns and formatting reminders with templates",
+	Long: `--columns picks the columns of table output, and of plain output, where
+the values are separated by tabs:
+
+  rem list --columns id,name,due,notes
+
+Columns: id, name, list, due, priority, status, notes, url, tags, flagged,
+repeat, remind, created, modified, completed. The default is
+id,name,list,due,priority,status.
+
+-o template='...' prints each reminder with a Go text/template
+(https://pkg.go.dev/text/template). The reminder's fields are ID, Name,
+Body, ListName, DueDate, RemindMeDate, CreationDate, ModificationDate,
+CompletionDate, Priority, Flagged, Completed, URL and Recurrence, and
+.Tags gives its #tags. A newline is added after each reminder unless the
+template ends in one.
+
+Functions:
+  date T [LAYOUT]    format a date with a Go layout (default "2006-01-02 15:04");
+                     empty when the date isn't set
+  due T [LAYOUT]     the same as date
+  relative T         "in 3 days", "2 hours ago", "now"
+  shortID ID         the first 8 characters of an ID
+  truncate N S       cut S to N characters, ending in "…"
+  join LIST SEP      join a list, e.g. {{join .Tags ", "}}
+  upper, lower       change case
+  red, green, yellow, blue, magenta, cyan, bold, dim
+                     color text; off with --no-color, NO_COLOR or when the
+                     output isn't a terminal
+
+Templates are checked before anything runs, so a misspelled field is an
+error rather than half an output.`,
+	Example: `  rem list --columns id,name,due,notes
+  rem list -o plain --columns id,name | fzf
+  rem list -o template='{{.Name}} — {{due .DueDate "Mon 3pm"}}'
+  rem upcoming -o template='{{if .Flagged}}{{red "!"}} {{end}}{{.Name | truncate 30}} ({{relative .DueDate}})'
+  rem list --incomplete --sort due --limit 1 -o template='{{.Name}}'`,
+}
+
+func init() {
+	rootCmd.AddCommand(templateHelpCmd)
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/query"
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/spf13/cobra"
+)
+
+// queryFlagUsage is the usage of the --query flag of every command that
+// takes one.
+const queryFlagUsage = `Filter with a query, e.g. 'list:Work and due<eow' (see "rem help query")`
+
+var queryHelpCmd = &cobra.Command{
+	Use:   "query",
+	Short: "The filter language of --query",
+	Long: `list, search, export, stats and the commands that change reminders
+(complete, uncomplete, flag, unflag, delete) take a --query (-q) that
+selects reminders by their fields:
+
+  rem list -q 'list:(Work or Home) and due<eow and priority>=medium and not flagged and notes~"invoice"'
+
+Conditions are joined with and, or and not, and grouped with parentheses;
+and binds tighter than or, and conditions written one after another are
+joined with and. A condition is a field, an operator and a value. Values
+with spaces or operator characters are double-quoted, and a list of values
+in parentheses, like list:(Work or Home), matches any of them.
+
+Fields:
+  list, name, notes, url, id   text: : or = (same, ignoring case), != and ~ (contains);
+                               id matches a prefix
+  tag                          a #tag in the notes: tag:errand, tag~err
+  due, remind, created,        dates: : (same day), !=, <, <=, >, >=; values are
+  modified, completed          dates like today, eow, "next friday", 2026-03-01 or
+                               2026-03-01T10:00-05:00, or none and any for a
+                               missing or set date
+  priority                     high, medium, low, none or 0-9, ordered from none to
+                               high: priority>=medium
+  flagged, completed,          on their own, or :true and :false
+  overdue, repeating
+
+A word or quoted string on its own matches the title or notes. Dates
+without a time of day stand for the whole day, so due<=friday includes
+reminders due on Friday afternoon.
+
+Errors point at the column of the query where the problem is.`,
+	Example: `  rem list -q 'due<=today and not completed'
+  rem list -q 'list:Work priority>=medium'
+  rem search -q 'tag:errand or "pick up"'
+  rem export -q 'completed>"30 days ago"' --format csv
+  rem stats -q 'list:Work'
+  rem complete -q 'list:Groceries and not completed'
+  rem delete -q 'completed<"90 days ago"' --force`,
+}
+
+// parseQuery parses a --query, resolving its relative dates now. It returns
+// nil for an empty query.
+func parseQuery(src string) (query.Expr, error) {
+	if src == "" {
+		return nil, nil
+	}
+	dates, err := newDateParser(parser.WithDefaultTime(0, 0))
+	if err != nil {
+		return nil, err
+	}
+	return query.Parse(src, dates)
+}
+
+// selectReminders lists the reminders matching filter and every non-empty
+// query.
+func selectReminders(filter *reminder.ListFilter, queries ...string) ([]*reminder.Reminder, error) {
+	var exprs []query.Expr
+	for _, q := range queries {
+		expr, err := parseQuery(q)
+		if err != nil {
+			return nil, err
+		}
+		if expr == nil {
+			continue
+		}
+		if query.Uses(expr, "flagged") {
+			if filter == nil {
+				filter = &reminder.ListFilter{}
+			}
+			filter.LoadFlags = true
+		}
+		exprs = append(exprs, expr)
+	}
+
+	reminders, err := reminderSvc.ListReminders(filter)
+	if err != nil || len(exprs) == 0 {
+		return reminders, err
+	}
+	matched := reminders[:0]
+next:
+	for _, r := range reminders {
+		for _, expr := range exprs {
+			if !expr.Match(r) {
+				continue next
+			}
+		}
+		matched = append(matched, r)
+	}
+	return matched, nil
+}
+
+// targetReminders returns the reminders a command that changes reminders
+// acts on: the one with the ID in args, or every one matching the query.
+func targetReminders(args []string, q string) ([]*reminder.Reminder, error) {
+	switch {
+	case len(args) > 0 && q != "":
+		return nil, fmt.Errorf("give a reminder ID or --query, not both")
+	case len(args) > 0:
+		r, err := findReminderByID(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return []*reminder.Reminder{r}, nil
+	case q != "":
+		return selectReminders(nil, q)
+	}
+	return nil, fmt.Errorf("requires a reminder ID or --query")
+}
+
+func init() {
+	rootCmd.AddCommand(queryHelpCmd)
+}
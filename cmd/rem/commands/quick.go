@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/BRO3886/rem/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var quickExplain bool
+
+var quickCmd = &cobra.Command{
+	Use:     "quick [text]",
+	Aliases: []string{"q"},
+	Short:   "Create a reminder from a single line of text",
+	Long: `Create a reminder from one line of text. rem picks out the list (@List),
+priority (!high, !medium, !low, !!!, !!, !1-!3), tags (#tag), a URL and the
+due date, and uses the rest as the title. Use underscores for spaces in list
+names (@Home_Stuff). Tags are added to the notes.
+
+--explain shows how the text was read and creates nothing, so a misread can
+be fixed before the reminder exists. With -o json (or another structured
+format) it prints the reminder that would be created.`,
+	Example: `  rem q "Buy milk tomorrow 5pm @Groceries !high #errand"
+  rem q Review https://github.com/org/repo/pull/123 by friday !!
+  rem q "Call dentist in 2 days" --explain`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dates, err := newDateParser()
+		if err != nil {
+			return err
+		}
+		q, err := dates.ParseQuickAdd(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		r := &reminder.Reminder{
+			Name:     q.Title,
+			URL:      q.URL,
+			Priority: q.Priority,
+			DueDate:  q.Due,
+		}
+		if q.ListName != "" {
+			r.ListName, err = resolveListName(q.ListName)
+			if err != nil {
+				return err
+			}
+		}
+		r.SetTags(q.Tags)
+
+		if quickExplain {
+			if output.Structured() {
+				ui.PrintReminderDetail(os.Stdout, r, output)
+				return nil
+			}
+			printQuickAdd(q, r)
+			fmt.Println("Nothing was created; run again without --explain to add it.")
+			return nil
+		}
+
+		id, err := reminderSvc.CreateReminder(r)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case output == ui.FormatTemplate:
+			r.ID = id
+			ui.PrintReminderDetail(os.Stdout, r, output)
+		case output.Structured():
+			ui.PrintCreated(os.Stdout, id, r.Name, output)
+		default:
+			fmt.Fprintf(os.Stdout, "Created reminder: %s (ID: %s)\n", r.Name, shortIDStr(id))
+		}
+		return nil
+	},
+}
+
+// resolveListName finds the list a quick-add @List refers to, ignoring case.
+func resolveListName(name string) (string, error) {
+	lists, err := listSvc.GetLists()
+	if err != nil {
+		return "", err
+	}
+	for _, l := range lists {
+		if l.Name == name {
+			return l.Name, nil
+		}
+	}
+	for _, l := range lists {
+		if strings.EqualFold(l.Name, name) {
+			return l.Name, nil
+		}
+	}
+	return "", fmt.Errorf("list not found: %s", name)
+}
+
+// printQuickAdd shows how a quick-add line was read.
+func printQuickAdd(q *parser.QuickAdd, r *reminder.Reminder) {
+	fmt.Printf("Title:    %s\n", r.Name)
+	if r.DueDate != nil {
+		fmt.Printf("Due:      %s (from %q)\n", ui.FormatDate(*r.DueDate), q.DueText)
+	} else {
+		fmt.Printf("Due:      -\n")
+	}
+	list := r.ListName
+	if list == "" {
+		list = "(default list)"
+	}
+	fmt.Printf("List:     %s\n", list)
+	fmt.Printf("Priority: %s\n", r.Priority.String())
+	if len(q.Tags) > 0 {
+		fmt.Printf("Tags:     #%s\n", strings.Join(q.Tags, " #"))
+	}
+	if r.URL != "" {
+		fmt.Printf("URL:      %s\n", r.URL)
+	}
+}
+
+func init() {
+	quickCmd.Flags().BoolVar(&quickExplain, "explain", false, "Show how the text was interpreted without creating the reminder")
+	rootCmd.AddCommand(quickCmd)
+}
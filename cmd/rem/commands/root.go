@@ -2,31 +2,29 @@ package commands
 
 import (
 	"fmt"
-	"os"
 
-	"github.com/BRO3886/go-eventkit/reminders"
 	"github.com/BRO3886/rem/internal/service"
+	"github.com/BRO3886/rem/internal/ui"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var (
-	outputFormat string
-	noColor      bool
+	outputFormat  string
+	outputColumns string
+	noColor       bool
+
+	// output is the format selected with --output.
+	output ui.OutputFormat
 
-	exec        *service.Executor
 	reminderSvc *service.ReminderService
 	listSvc     *service.ListService
 )
 
-func init() {
-	client, err := reminders.New()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: failed to initialize Reminders access: %v\n", err)
-		os.Exit(1)
-	}
-	exec = service.NewExecutor()
-	reminderSvc = service.NewReminderService(client, exec)
-	listSvc = service.NewListService(client, exec)
+// setBackend points the reminder and list services at the given backend.
+func setBackend(b service.Backend) {
+	reminderSvc = service.NewReminderService(b)
+	listSvc = service.NewListService(b)
 }
 
 var rootCmd = &cobra.Command{
@@ -37,13 +35,51 @@ It provides full CRUD operations for reminders and lists, natural language date
 import/export capabilities, and a clean terminal UI.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyTimeZone(); err != nil {
+			return err
+		}
+		if err := applyOutput(); err != nil {
+			return err
+		}
+		if reminderSvc != nil {
+			return nil
+		}
+		b, err := newBackend()
+		if err != nil {
+			return err
+		}
+		setBackend(b)
+		return nil
+	},
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, plain")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, ndjson, plain, yaml, csv, tsv, markdown, or template='{{.Name}}' (see \"rem help template\")")
+	rootCmd.PersistentFlags().StringVar(&outputColumns, "columns", "", "Columns of table and plain output, e.g. id,name,due,notes (see \"rem help template\")")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output")
 }
 
+// applyOutput parses --output and configures the UI for --columns,
+// -o template=... and --no-color.
+func applyOutput() error {
+	format, err := ui.ParseOutputFormat(outputFormat)
+	if err != nil {
+		return fmt.Errorf("invalid --output: %w", err)
+	}
+	output = format
+	if noColor {
+		color.NoColor = true
+	}
+	if err := ui.SetColumns(outputColumns); err != nil {
+		return fmt.Errorf("invalid --columns: %w", err)
+	}
+	if text, ok := ui.TemplateText(outputFormat); ok {
+		return ui.SetTemplate(text)
+	}
+	return nil
+}
+
 // Execute runs the root command.
 func Execute() error {
 	return rootCmd.Execute()
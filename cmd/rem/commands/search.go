@@ -5,25 +5,31 @@ import (
 	"os"
 
 	"github.com/BRO3886/rem/internal/reminder"
-	"github.com/BRO3886/rem/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
 	searchList       string
 	searchIncomplete bool
+	searchQuery      string
 )
 
 var searchCmd = &cobra.Command{
-	Use:   "search [query]",
+	Use:   "search [text]",
 	Short: "Search reminders by title and notes",
 	Example: `  rem search "groceries"
-  rem search "meeting" --list Work --incomplete`,
-	Args: cobra.ExactArgs(1),
+  rem search "meeting" --list Work --incomplete
+  rem search invoice -q 'due<eom and not completed'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && searchQuery == "" {
+			return fmt.Errorf("requires search text or --query")
+		}
 		filter := &reminder.ListFilter{
-			ListName:    searchList,
-			SearchQuery: args[0],
+			ListName: searchList,
+		}
+		if len(args) > 0 {
+			filter.SearchQuery = args[0]
 		}
 
 		if searchIncomplete {
@@ -31,18 +37,25 @@ var searchCmd = &cobra.Command{
 			filter.Completed = &v
 		}
 
-		reminders, err := reminderSvc.ListReminders(filter)
+		reminders, err := selectReminders(filter, searchQuery)
 		if err != nil {
 			return err
 		}
 
 		if len(reminders) == 0 {
-			fmt.Fprintf(os.Stderr, "No reminders matching '%s'\n", args[0])
+			what := searchQuery
+			if len(args) > 0 {
+				what = args[0]
+			}
+			fmt.Fprintf(os.Stderr, "No reminders matching '%s'\n", what)
 			return nil
 		}
 
-		format := ui.ParseOutputFormat(outputFormat)
-		ui.PrintReminders(os.Stdout, reminders, format)
+		reminders, group, err := searchListing.arrange(reminders)
+		if err != nil {
+			return err
+		}
+		printListing(reminders, group, output)
 		return nil
 	},
 }
@@ -50,5 +63,7 @@ var searchCmd = &cobra.Command{
 func init() {
 	searchCmd.Flags().StringVarP(&searchList, "list", "l", "", "Search within a specific list")
 	searchCmd.Flags().BoolVar(&searchIncomplete, "incomplete", false, "Search only incomplete reminders")
+	searchCmd.Flags().StringVarP(&searchQuery, "query", "q", "", queryFlagUsage)
+	searchListing.register(searchCmd)
 	rootCmd.AddCommand(searchCmd)
 }
@@ -26,7 +26,7 @@ var showCmd = &cobra.Command{
 			return err
 		}
 
-		format := ui.ParseOutputFormat(outputFormat)
+		format := output
 		ui.PrintReminderDetail(os.Stdout, r, format)
 		return nil
 	},
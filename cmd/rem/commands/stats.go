@@ -3,22 +3,25 @@ package commands
 import (
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/BRO3886/rem/internal/reminder"
 	"github.com/BRO3886/rem/internal/ui"
-	"github.com/olekukonko/tablewriter"
-	"github.com/olekukonko/tablewriter/tw"
 	"github.com/spf13/cobra"
 )
 
+var statsQuery string
+
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show reminder statistics",
+	Long: `Show reminder statistics. With --query, the counts cover only the
+reminders that match it.`,
+	Example: `  rem stats
+  rem stats -q 'list:Work and created>"30 days ago"'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		format := ui.ParseOutputFormat(outputFormat)
-
-		allReminders, err := reminderSvc.ListReminders(nil)
+		allReminders, err := selectReminders(nil, statsQuery)
 		if err != nil {
 			return err
 		}
@@ -27,68 +30,37 @@ var statsCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if statsQuery != "" {
+			perList := map[string]int{}
+			for _, r := range allReminders {
+				perList[r.ListName]++
+			}
+			for _, l := range lists {
+				l.Count = perList[l.Name]
+			}
+		}
 
-		total := len(allReminders)
-		completed := 0
-		flagged := 0
-		overdue := 0
+		stats := ui.Stats{Total: len(allReminders), Lists: len(lists), PerList: lists}
 		now := time.Now()
 
 		for _, r := range allReminders {
 			if r.Completed {
-				completed++
+				stats.Completed++
 			}
 			if r.Flagged {
-				flagged++
+				stats.Flagged++
 			}
 			if r.DueDate != nil && r.DueDate.Before(now) && !r.Completed {
-				overdue++
+				stats.Overdue++
 			}
 		}
 
-		incomplete := total - completed
-		completionRate := 0.0
-		if total > 0 {
-			completionRate = float64(completed) / float64(total) * 100
-		}
-
-		if format == ui.FormatJSON {
-			fmt.Fprintf(os.Stdout, `{
-  "total": %d,
-  "completed": %d,
-  "incomplete": %d,
-  "flagged": %d,
-  "overdue": %d,
-  "completion_rate": %.1f,
-  "lists": %d
-}
-`, total, completed, incomplete, flagged, overdue, completionRate, len(lists))
-			return nil
-		}
-
-		fmt.Println("Reminder Statistics")
-		fmt.Println("===================")
-		fmt.Printf("Total:           %d\n", total)
-		fmt.Printf("Completed:       %d\n", completed)
-		fmt.Printf("Incomplete:      %d\n", incomplete)
-		fmt.Printf("Flagged:         %d\n", flagged)
-		fmt.Printf("Overdue:         %d\n", overdue)
-		fmt.Printf("Completion Rate: %.1f%%\n", completionRate)
-		fmt.Printf("Lists:           %d\n", len(lists))
-
-		if len(lists) > 0 {
-			fmt.Println("\nPer List:")
-			table := tablewriter.NewTable(os.Stdout,
-				tablewriter.WithHeaderAlignment(tw.AlignLeft),
-				tablewriter.WithRowAlignment(tw.AlignLeft),
-			)
-			table.Header("List", "Reminders")
-			for _, l := range lists {
-				table.Append([]string{l.Name, fmt.Sprintf("%d", l.Count)})
-			}
-			table.Render()
+		stats.Incomplete = stats.Total - stats.Completed
+		if stats.Total > 0 {
+			stats.CompletionRate = ui.Percent(float64(stats.Completed) / float64(stats.Total) * 100)
 		}
 
+		ui.PrintStats(os.Stdout, stats, output)
 		return nil
 	},
 }
@@ -113,56 +85,112 @@ var overdueCmd = &cobra.Command{
 			}
 		}
 
-		format := ui.ParseOutputFormat(outputFormat)
+		overdue, group, err := overdueListing.arrange(overdue)
+		if err != nil {
+			return err
+		}
+
+		format := output
 		if len(overdue) == 0 {
-			if format != ui.FormatJSON {
+			if !format.Structured() {
 				fmt.Println("No overdue reminders!")
-			} else {
-				fmt.Println("[]")
+				return nil
 			}
-			return nil
 		}
 
-		ui.PrintReminders(os.Stdout, overdue, format)
+		printListing(overdue, group, format)
 		return nil
 	},
 }
 
-var upcomingDays int
+var (
+	upcomingDays         int
+	upcomingBusinessDays bool
+)
 
 var upcomingCmd = &cobra.Command{
 	Use:   "upcoming",
 	Short: "Show upcoming reminders",
+	Long: `Show incomplete reminders due in the next few days.
+Repeating reminders are listed once for every occurrence in the window.
+With --business-days, --days counts working days on the working calendar.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		incomplete := false
 		now := time.Now()
 		cutoff := now.AddDate(0, 0, upcomingDays)
+		unit := "days"
+		if upcomingBusinessDays {
+			dates, err := newDateParser()
+			if err != nil {
+				return err
+			}
+			cutoff = dates.Calendar().AddWorkdays(now, upcomingDays)
+			unit = "business days"
+		}
+		// No DueAfter: a repeating reminder whose due date has passed can
+		// still have occurrences inside the window.
 		reminders, err := reminderSvc.ListReminders(&reminder.ListFilter{
 			Completed: &incomplete,
 			DueBefore: &cutoff,
-			DueAfter:  &now,
 		})
 		if err != nil {
 			return err
 		}
+		reminders = expandOccurrences(reminders, now, cutoff)
+		reminders, group, err := upcomingListing.arrange(reminders)
+		if err != nil {
+			return err
+		}
 
-		format := ui.ParseOutputFormat(outputFormat)
+		format := output
 		if len(reminders) == 0 {
-			if format != ui.FormatJSON {
-				fmt.Printf("No reminders due in the next %d days.\n", upcomingDays)
-			} else {
-				fmt.Println("[]")
+			if !format.Structured() {
+				fmt.Printf("No reminders due in the next %d %s.\n", upcomingDays, unit)
+				return nil
 			}
-			return nil
 		}
 
-		ui.PrintReminders(os.Stdout, reminders, format)
+		printListing(reminders, group, format)
 		return nil
 	},
 }
 
+// expandOccurrences returns the reminders due within (from, to], with
+// repeating reminders replaced by one copy per occurrence in the window.
+// The result is ordered by due date.
+func expandOccurrences(reminders []*reminder.Reminder, from, to time.Time) []*reminder.Reminder {
+	var result []*reminder.Reminder
+	for _, r := range reminders {
+		if r.DueDate == nil {
+			continue
+		}
+		if r.Recurrence == nil {
+			if r.DueDate.After(from) && !r.DueDate.After(to) {
+				result = append(result, r)
+			}
+			continue
+		}
+		for _, t := range r.Recurrence.Occurrences(*r.DueDate, from, to) {
+			if !t.After(from) {
+				continue
+			}
+			occurrence := *r
+			occurrence.DueDate = &t
+			result = append(result, &occurrence)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].DueDate.Before(*result[j].DueDate)
+	})
+	return result
+}
+
 func init() {
+	statsCmd.Flags().StringVarP(&statsQuery, "query", "q", "", queryFlagUsage)
 	upcomingCmd.Flags().IntVar(&upcomingDays, "days", 7, "Number of days to look ahead")
+	upcomingCmd.Flags().BoolVar(&upcomingBusinessDays, "business-days", false, "Count --days as working days (see REM_CALENDAR)")
+	overdueListing.register(overdueCmd)
+	upcomingListing.register(upcomingCmd)
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(overdueCmd)
 	rootCmd.AddCommand(upcomingCmd)
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/BRO3886/rem/internal/export"
+	"github.com/BRO3886/rem/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var syncDryRun bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Keep reminders in sync with a file",
+	Long:  `Keep reminders in sync with a file. Use subcommands to pick the format.`,
+}
+
+var syncTodoTxtCmd = &cobra.Command{
+	Use:   "todotxt [file]",
+	Short: "Sync reminders with a todo.txt file in both directions",
+	Long: `Sync reminders with a todo.txt file in both directions.
+
+New lines in the file become reminders, and new reminders are added to the
+file. Edits, completions and deletions on either side are carried over to
+the other. Each synced line keeps its reminder ID as rem:<id>; don't edit it.
+When a task changed on both sides since the last sync, the reminder wins.
+
+The state of the last sync is kept under $XDG_STATE_HOME/rem/sync.`,
+	Example: `  rem sync todotxt ~/todo/todo.txt
+  rem sync todotxt todo.txt --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statePath, err := service.DefaultSyncStatePath(args[0])
+		if err != nil {
+			return err
+		}
+		var opts []export.Option
+		if timeZone != nil {
+			opts = append(opts, export.WithLocation(timeZone))
+		}
+
+		s := service.NewTodoTxtSync(reminderSvc, listSvc, opts...)
+		changes, err := s.Sync(args[0], statePath, syncDryRun)
+		if err != nil {
+			return err
+		}
+
+		prefix := ""
+		if syncDryRun {
+			prefix = "[dry-run] "
+		}
+		for _, c := range changes {
+			fmt.Printf("%s%s: %s\n", prefix, c.Action, c.Name)
+		}
+		if len(changes) == 0 {
+			fmt.Println("Already in sync.")
+		}
+		return nil
+	},
+}
+
+func init() {
+	syncTodoTxtCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would change without writing anything")
+	syncCmd.AddCommand(syncTodoTxtCmd)
+	rootCmd.AddCommand(syncCmd)
+}
@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 
-	"github.com/BRO3886/rem/internal/parser"
 	"github.com/BRO3886/rem/internal/reminder"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +14,7 @@ var (
 	updatePriority string
 	updateURL      string
 	updateFlagged  string
+	updateRepeat   string
 	updateInteractive bool
 )
 
@@ -25,7 +25,9 @@ var updateCmd = &cobra.Command{
 	Long:    `Update properties of an existing reminder by its ID.`,
 	Example: `  rem update abc12345 --due "next monday"
   rem update abc12345 --notes "Updated notes" --priority medium
-  rem edit abc12345 --name "New title"`,
+  rem edit abc12345 --name "New title"
+  rem update abc12345 --repeat "every other friday"
+  rem update abc12345 --repeat none`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
@@ -59,7 +61,7 @@ var updateCmd = &cobra.Command{
 			if updateDue == "" || updateDue == "none" {
 				updates["due_date"] = nil
 			} else {
-				t, err := parser.ParseDate(updateDue)
+				t, err := parseDate(updateDue)
 				if err != nil {
 					return fmt.Errorf("invalid due date: %w", err)
 				}
@@ -72,6 +74,20 @@ var updateCmd = &cobra.Command{
 		if cmd.Flags().Changed("flagged") {
 			updates["flagged"] = updateFlagged == "true" || updateFlagged == "yes"
 		}
+		if cmd.Flags().Changed("repeat") {
+			if updateRepeat == "" || updateRepeat == "none" {
+				updates["recurrence"] = nil
+			} else {
+				rec, first, err := parseRecurrence(updateRepeat)
+				if err != nil {
+					return fmt.Errorf("invalid repeat: %w", err)
+				}
+				if r.DueDate == nil && updates["due_date"] == nil {
+					updates["due_date"] = first
+				}
+				updates["recurrence"] = rec
+			}
+		}
 
 		if len(updates) == 0 {
 			return fmt.Errorf("no updates specified")
@@ -94,6 +110,7 @@ func init() {
 	updateCmd.Flags().StringVarP(&updatePriority, "priority", "p", "", "New priority: high, medium, low, none")
 	updateCmd.Flags().StringVarP(&updateURL, "url", "u", "", "New URL")
 	updateCmd.Flags().StringVar(&updateFlagged, "flagged", "", "Set flagged status: true/false")
+	updateCmd.Flags().StringVarP(&updateRepeat, "repeat", "r", "", "New repeat rule (use 'none' to stop repeating)")
 	updateCmd.Flags().BoolVarP(&updateInteractive, "interactive", "i", false, "Update interactively")
 
 	rootCmd.AddCommand(updateCmd)
@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/BRO3886/rem/internal/service"
+	"github.com/BRO3886/rem/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	viewList       string
+	viewIncomplete bool
+	viewCompleted  bool
+	viewFlagged    bool
+	viewDueBefore  string
+	viewDueAfter   string
+	viewSearch     string
+	viewQuery      string
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Save and run named filters (smart lists)",
+	Long: `Save the filters you use every day under a name and run them again with
+'rem view run NAME', 'rem list --view NAME' or 'rem export --view NAME'.
+
+Dates such as "eow" or "tomorrow" and the --query are saved as written and
+read again each time the view runs. Views are kept in
+$XDG_CONFIG_HOME/rem/views.json.`,
+}
+
+var viewSaveCmd = &cobra.Command{
+	Use:   "save [name]",
+	Short: "Save filters as a view",
+	Long: `Save filters as a view, replacing any view with the same name. The
+filter flags are the same as rem list's.`,
+	Example: `  rem view save work-week --list Work --incomplete --due-before eow -q 'priority>=medium'
+  rem view save errands -q 'tag:errand and not completed'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		v := &service.View{
+			Name:      args[0],
+			List:      viewList,
+			Flagged:   viewFlagged,
+			DueBefore: viewDueBefore,
+			DueAfter:  viewDueAfter,
+			Search:    viewSearch,
+			Query:     viewQuery,
+		}
+		if viewIncomplete {
+			completed := false
+			v.Completed = &completed
+		}
+		if viewCompleted {
+			completed := true
+			v.Completed = &completed
+		}
+
+		// Check the dates and query now rather than on every run.
+		if _, err := v.Filter(parseDate); err != nil {
+			return err
+		}
+		if _, err := parseQuery(v.Query); err != nil {
+			return err
+		}
+
+		store, err := openViewStore()
+		if err != nil {
+			return err
+		}
+		replaced, err := store.Save(v)
+		if err != nil {
+			return err
+		}
+		if replaced {
+			fmt.Printf("Updated view: %s\n", v.Name)
+		} else {
+			fmt.Printf("Saved view: %s\n", v.Name)
+		}
+		return nil
+	},
+}
+
+var viewListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved views",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openViewStore()
+		if err != nil {
+			return err
+		}
+		views := store.Views()
+
+		switch output {
+		case ui.FormatJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(views)
+		case ui.FormatNDJSON:
+			enc := json.NewEncoder(os.Stdout)
+			for _, v := range views {
+				if err := enc.Encode(v); err != nil {
+					return err
+				}
+			}
+			return nil
+		case ui.FormatYAML:
+			return ui.WriteYAML(os.Stdout, views)
+		case ui.FormatPlain:
+			for _, v := range views {
+				fmt.Println(v.Name)
+			}
+			return nil
+		}
+
+		if len(views) == 0 && !output.Structured() {
+			fmt.Println("No saved views. Create one with 'rem view save NAME [filters]'.")
+			return nil
+		}
+		rows := make([][]string, len(views))
+		for i, v := range views {
+			rows[i] = []string{v.Name, v.Describe()}
+		}
+		return ui.WriteTable(os.Stdout, []string{"View", "Filters"}, rows, output)
+	},
+}
+
+var viewRunCmd = &cobra.Command{
+	Use:     "run [name]",
+	Aliases: []string{"show"},
+	Short:   "List the reminders a view selects",
+	Example: `  rem view run work-week
+  rem view run work-week -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, q, err := viewFilter(args[0])
+		if err != nil {
+			return err
+		}
+		reminders, err := selectReminders(filter, q)
+		if err != nil {
+			return err
+		}
+		reminders, group, err := viewRunListing.arrange(reminders)
+		if err != nil {
+			return err
+		}
+		printListing(reminders, group, output)
+		return nil
+	},
+}
+
+var viewDeleteCmd = &cobra.Command{
+	Use:     "delete [name]",
+	Aliases: []string{"rm"},
+	Short:   "Delete a saved view",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openViewStore()
+		if err != nil {
+			return err
+		}
+		if err := store.Delete(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted view: %s\n", args[0])
+		return nil
+	},
+}
+
+func openViewStore() (*service.ViewStore, error) {
+	path, err := service.DefaultViewsPath()
+	if err != nil {
+		return nil, err
+	}
+	return service.OpenViewStore(path)
+}
+
+// viewFilter returns the filter and query of the view called name, with
+// its dates read now. For an empty name it returns an empty filter.
+func viewFilter(name string) (*reminder.ListFilter, string, error) {
+	if name == "" {
+		return &reminder.ListFilter{}, "", nil
+	}
+	store, err := openViewStore()
+	if err != nil {
+		return nil, "", err
+	}
+	v, err := store.Get(name)
+	if err != nil {
+		return nil, "", err
+	}
+	filter, err := v.Filter(parseDate)
+	if err != nil {
+		return nil, "", err
+	}
+	return filter, v.Query, nil
+}
+
+func init() {
+	viewSaveCmd.Flags().StringVarP(&viewList, "list", "l", "", "Filter by list name")
+	viewSaveCmd.Flags().BoolVar(&viewIncomplete, "incomplete", false, "Show only incomplete reminders")
+	viewSaveCmd.Flags().BoolVar(&viewCompleted, "completed", false, "Show only completed reminders")
+	viewSaveCmd.Flags().BoolVar(&viewFlagged, "flagged", false, "Show only flagged reminders")
+	viewSaveCmd.Flags().StringVar(&viewDueBefore, "due-before", "", "Show reminders due before this date")
+	viewSaveCmd.Flags().StringVar(&viewDueAfter, "due-after", "", "Show reminders due after this date")
+	viewSaveCmd.Flags().StringVarP(&viewSearch, "search", "s", "", "Search in title and notes")
+	viewSaveCmd.Flags().StringVarP(&viewQuery, "query", "q", "", queryFlagUsage)
+
+	viewRunListing.register(viewRunCmd)
+
+	viewCmd.AddCommand(viewSaveCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewRunCmd)
+	viewCmd.AddCommand(viewDeleteCmd)
+	rootCmd.AddCommand(viewCmd)
+}
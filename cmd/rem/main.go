@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"runtime"
 
 	"github.com/BRO3886/rem/cmd/rem/commands"
 )
@@ -15,11 +14,6 @@ var (
 )
 
 func main() {
-	if runtime.GOOS != "darwin" {
-		fmt.Fprintln(os.Stderr, "Error: rem requires macOS (uses AppleScript to interact with the Reminders app)")
-		os.Exit(2)
-	}
-
 	commands.Version = version
 	commands.BuildTime = buildTime
 
@@ -7,17 +7,20 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/BRO3886/rem/internal/reminder"
 )
 
 var csvHeaders = []string{
 	"id", "name", "body", "list_name", "due_date", "remind_me_date",
-	"priority", "priority_label", "flagged", "completed", "url",
+	"priority", "priority_label", "flagged", "completed", "url", "recurrence",
+	"completion_date", "creation_date", "modification_date",
 }
 
 // ExportCSV writes reminders as CSV to the writer.
-func ExportCSV(w io.Writer, reminders []*reminder.Reminder) error {
+func ExportCSV(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
+	o := newOptions(opts)
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
@@ -26,13 +29,11 @@ func ExportCSV(w io.Writer, reminders []*reminder.Reminder) error {
 	}
 
 	for _, r := range reminders {
-		dueDate := ""
-		if r.DueDate != nil {
-			dueDate = r.DueDate.Format(timeFormat)
-		}
-		remindDate := ""
-		if r.RemindMeDate != nil {
-			remindDate = r.RemindMeDate.Format(timeFormat)
+		date := func(t *time.Time) string {
+			if t == nil {
+				return ""
+			}
+			return o.formatTime(*t)
 		}
 
 		record := []string{
@@ -40,13 +41,17 @@ func ExportCSV(w io.Writer, reminders []*reminder.Reminder) error {
 			r.Name,
 			r.Body,
 			r.ListName,
-			dueDate,
-			remindDate,
+			date(r.DueDate),
+			date(r.RemindMeDate),
 			strconv.Itoa(int(r.Priority)),
 			r.Priority.String(),
 			strconv.FormatBool(r.Flagged),
 			strconv.FormatBool(r.Completed),
 			r.URL,
+			formatRecurrence(r.Recurrence),
+			date(r.CompletionDate),
+			date(r.CreationDate),
+			date(r.ModificationDate),
 		}
 
 		if err := writer.Write(record); err != nil {
@@ -57,11 +62,33 @@ func ExportCSV(w io.Writer, reminders []*reminder.Reminder) error {
 	return nil
 }
 
-// ImportCSV reads reminders from a CSV reader.
-func ImportCSV(r io.Reader) ([]*reminder.Reminder, error) {
+// ImportCSV reads reminders from a CSV reader. Columns are matched to
+// fields by header, case-insensitively. Without a mapping (see
+// WithCSVMapping) they must use the names ExportCSV writes and timestamps
+// may be RFC 3339 or the offset-less default layout. Priorities may be
+// numbers or labels. Values that can't be read are left unset and
+// reported through WithDiagnostics.
+func ImportCSV(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	m := o.csv
+	loc := o.location()
+
+	var encoding string
+	if m != nil {
+		encoding = m.Encoding
+	}
+	r, err := decodeReader(r, encoding)
+	if err != nil {
+		return nil, err
+	}
 	reader := csv.NewReader(r)
 	reader.LazyQuotes = true
 	reader.TrimLeadingSpace = true
+	if m != nil && m.Comma != 0 {
+		reader.Comma = m.Comma
+		// Leading-space trimming would swallow empty tab-separated fields.
+		reader.TrimLeadingSpace = !unicode.IsSpace(m.Comma)
+	}
 
 	// Read header
 	header, err := reader.Read()
@@ -74,6 +101,26 @@ func ImportCSV(r io.Reader) ([]*reminder.Reminder, error) {
 	for i, h := range header {
 		colMap[strings.TrimSpace(strings.ToLower(h))] = i
 	}
+	if m != nil {
+		for field, col := range m.Columns {
+			if _, ok := colMap[strings.ToLower(col)]; !ok {
+				return nil, fmt.Errorf("column %q for %s not found in CSV header", col, field)
+			}
+		}
+	}
+	v := newValidator(o)
+	field := func(record []string, name string) string {
+		idx, ok := colMap[strings.ToLower(m.column(name))]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+	date := func(record []string, name string) *time.Time {
+		return v.date(name, field(record, name), func(s string) (time.Time, error) {
+			return m.parseDate(name, s, loc)
+		})
+	}
 
 	var reminders []*reminder.Reminder
 	for {
@@ -85,44 +132,29 @@ func ImportCSV(r io.Reader) ([]*reminder.Reminder, error) {
 			return nil, fmt.Errorf("failed to read CSV record: %w", err)
 		}
 
-		rem := &reminder.Reminder{}
-
-		if idx, ok := colMap["name"]; ok && idx < len(record) {
-			rem.Name = record[idx]
-		}
-		if idx, ok := colMap["body"]; ok && idx < len(record) {
-			rem.Body = record[idx]
-		}
-		if idx, ok := colMap["list_name"]; ok && idx < len(record) {
-			rem.ListName = record[idx]
-		}
-		if idx, ok := colMap["url"]; ok && idx < len(record) {
-			rem.URL = record[idx]
-		}
-		if idx, ok := colMap["due_date"]; ok && idx < len(record) && record[idx] != "" {
-			t, err := time.ParseInLocation(timeFormat, record[idx], time.Now().Location())
-			if err == nil {
-				rem.DueDate = &t
-			}
-		}
-		if idx, ok := colMap["remind_me_date"]; ok && idx < len(record) && record[idx] != "" {
-			t, err := time.ParseInLocation(timeFormat, record[idx], time.Now().Location())
-			if err == nil {
-				rem.RemindMeDate = &t
-			}
-		}
-		if idx, ok := colMap["priority"]; ok && idx < len(record) {
-			p, err := strconv.Atoi(record[idx])
-			if err == nil {
-				rem.Priority = reminder.Priority(p)
-			}
-		}
-		if idx, ok := colMap["flagged"]; ok && idx < len(record) {
-			rem.Flagged = strings.ToLower(record[idx]) == "true"
+		line, _ := reader.FieldPos(0)
+		v.next(line)
+
+		rem := &reminder.Reminder{
+			ID:           field(record, "id"),
+			Name:         field(record, "name"),
+			Body:         field(record, "body"),
+			ListName:     field(record, "list_name"),
+			URL:          field(record, "url"),
+			DueDate:      date(record, "due_date"),
+			RemindMeDate: date(record, "remind_me_date"),
+			Priority:     v.priority(field(record, "priority")),
+			Flagged:      v.boolean("flagged", field(record, "flagged")),
+			Completed:    v.boolean("completed", field(record, "completed")),
+			Recurrence:   v.recurrence(field(record, "recurrence"), loc),
+
+			CreationDate:     date(record, "creation_date"),
+			ModificationDate: date(record, "modification_date"),
 		}
-		if idx, ok := colMap["completed"]; ok && idx < len(record) {
-			rem.Completed = strings.ToLower(record[idx]) == "true"
+		if rem.Completed {
+			rem.CompletionDate = date(record, "completion_date")
 		}
+		v.name(rem.Name)
 
 		reminders = append(reminders, rem)
 	}
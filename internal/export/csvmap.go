@@ -0,0 +1,255 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/BRO3886/rem/internal/parser"
+)
+
+// csvFields are the reminder fields a CSV column can be mapped to.
+var csvFields = map[string]bool{
+	"id": true, "name": true, "body": true, "list_name": true,
+	"due_date": true, "remind_me_date": true, "priority": true,
+	"flagged": true, "completed": true, "url": true, "recurrence": true,
+	"completion_date": true, "creation_date": true, "modification_date": true,
+}
+
+// csvDateFields are the fields read as dates.
+var csvDateFields = map[string]bool{
+	"due_date": true, "remind_me_date": true,
+	"completion_date": true, "creation_date": true, "modification_date": true,
+}
+
+// NaturalLayout is the date layout that reads dates in natural language
+// ("tomorrow 5pm", "March 3") instead of a fixed layout.
+const NaturalLayout = "natural"
+
+// CSVMapping describes a CSV file that doesn't use rem's own columns, so
+// that spreadsheets from other tools can be imported as they are.
+type CSVMapping struct {
+	// Columns maps reminder fields ("name", "due_date", ...) to the
+	// file's headers. Unmapped fields use their own name.
+	Columns map[string]string
+	// Layouts holds a Go time layout, or NaturalLayout, per date field.
+	// The entry for "" applies to date fields without one. Dates without
+	// a layout are read as RFC 3339 or rem's default layout.
+	Layouts map[string]string
+	// Comma is the field delimiter (default ',').
+	Comma rune
+	// Encoding is the file's character encoding: utf-8 (default),
+	// utf-16, latin1 or windows-1252.
+	Encoding string
+}
+
+// Set applies one mapping entry. Keys are a reminder field, mapping it to
+// a column, "<field>.layout" or "layout" for date layouts, "delimiter" and
+// "encoding".
+func (m *CSVMapping) Set(key, value string) error {
+	key = strings.ToLower(strings.TrimSpace(key))
+	value = strings.TrimSpace(value)
+	switch {
+	case key == "delimiter":
+		comma, err := parseDelimiter(value)
+		if err != nil {
+			return err
+		}
+		m.Comma = comma
+	case key == "encoding":
+		if _, err := decodeReader(strings.NewReader(""), value); err != nil {
+			return err
+		}
+		m.Encoding = value
+	case key == "layout" || strings.HasSuffix(key, ".layout"):
+		field := strings.TrimSuffix(strings.TrimSuffix(key, "layout"), ".")
+		if field != "" && !csvDateFields[field] {
+			return fmt.Errorf("%s is not a date field (use due_date, remind_me_date, completion_date, creation_date or modification_date)", field)
+		}
+		if value == "" {
+			return fmt.Errorf("missing layout for %s", key)
+		}
+		if m.Layouts == nil {
+			m.Layouts = map[string]string{}
+		}
+		m.Layouts[field] = value
+	case csvFields[key]:
+		if value == "" {
+			return fmt.Errorf("missing column for %s", key)
+		}
+		if m.Columns == nil {
+			m.Columns = map[string]string{}
+		}
+		m.Columns[key] = value
+	default:
+		return fmt.Errorf("unknown field %q (use id, name, body, list_name, due_date, remind_me_date, priority, flagged, completed, url, recurrence, completion_date, creation_date or modification_date)", key)
+	}
+	return nil
+}
+
+// ParseMap applies comma-separated "field=Column" pairs, as given to
+// rem import --map.
+func (m *CSVMapping) ParseMap(s string) error {
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid mapping %q (want field=Column)", pair)
+		}
+		if err := m.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCSVMapping reads a mapping file: one "key = value" entry per line,
+// with the keys Set accepts. Blank lines and lines starting with '#' are
+// skipped.
+//
+//	name = Task
+//	due_date = Deadline
+//	due_date.layout = 02/01/2006
+//	delimiter = ;
+func LoadCSVMapping(path string) (*CSVMapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV mapping: %w", err)
+	}
+	defer f.Close()
+
+	m := &CSVMapping{}
+	scanner := bufio.NewScanner(f)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, n)
+		}
+		if err := m.Set(key, value); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CSV mapping: %w", err)
+	}
+	return m, nil
+}
+
+// column returns the header a field is read from.
+func (m *CSVMapping) column(field string) string {
+	if m != nil {
+		if col, ok := m.Columns[field]; ok {
+			return col
+		}
+	}
+	return field
+}
+
+// parseDate reads a date field with its layout, in loc.
+func (m *CSVMapping) parseDate(field, value string, loc *time.Location) (time.Time, error) {
+	var layout string
+	if m != nil {
+		layout = m.Layouts[field]
+		if layout == "" {
+			layout = m.Layouts[""]
+		}
+	}
+	switch layout {
+	case "":
+		return parseTime(value, loc)
+	case NaturalLayout:
+		return parser.New(parser.WithLocation(loc)).ParseDate(value)
+	default:
+		return time.ParseInLocation(layout, value, loc)
+	}
+}
+
+func parseDelimiter(s string) (rune, error) {
+	switch strings.ToLower(s) {
+	case "tab", `\t`:
+		return '\t', nil
+	case "comma":
+		return ',', nil
+	case "semicolon":
+		return ';', nil
+	case "pipe":
+		return '|', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("invalid delimiter %q (use a single character or tab)", s)
+	}
+	return r, nil
+}
+
+// windows1252 holds the characters Windows-1252 puts in 0x80-0x9F, where
+// Latin-1 has control codes.
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// decodeReader converts r from encoding to UTF-8, dropping a byte order
+// mark. UTF-16 is little-endian unless a byte order mark says otherwise.
+func decodeReader(r io.Reader, encoding string) (io.Reader, error) {
+	switch strings.ToLower(strings.ReplaceAll(encoding, "_", "-")) {
+	case "", "utf-8", "utf8":
+		br := bufio.NewReader(r)
+		if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+			br.Discard(3)
+		}
+		return br, nil
+	case "utf-16", "utf16", "utf-16le", "utf-16be":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		bigEndian := strings.HasSuffix(strings.ToLower(encoding), "be")
+		switch {
+		case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+			data, bigEndian = data[2:], false
+		case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+			data, bigEndian = data[2:], true
+		}
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			if bigEndian {
+				units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+			} else {
+				units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+			}
+		}
+		return strings.NewReader(string(utf16.Decode(units))), nil
+	case "latin1", "latin-1", "iso-8859-1", "iso8859-1", "windows-1252", "cp1252":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		cp1252 := strings.Contains(encoding, "1252")
+		var b strings.Builder
+		b.Grow(len(data))
+		for _, c := range data {
+			if cp1252 && c >= 0x80 && c <= 0x9F {
+				b.WriteRune(windows1252[c-0x80])
+			} else {
+				b.WriteRune(rune(c))
+			}
+		}
+		return strings.NewReader(b.String()), nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %q (use utf-8, utf-16, latin1 or windows-1252)", encoding)
+}
@@ -0,0 +1,116 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// DetectSize is how much of the start of a file DetectFormat needs.
+const DetectSize = 4096
+
+var (
+	thingsType      = regexp.MustCompile(`"type"\s*:\s*"(?:to-do|project|heading)"`)
+	googleTasksKind = regexp.MustCompile(`"kind"\s*:\s*"tasks#`)
+	msTodoKey       = regexp.MustCompile(`"(?:displayName|importance|@odata\.context)"\s*:`)
+)
+
+// DetectFormat guesses the import format of a file from its first bytes,
+// returning one of the format names accepted by rem import, or "" when
+// the content isn't recognized. JSON is told apart by its keys: Things,
+// Microsoft To Do and Google Tasks exports, rem's own JSON array or NDJSON
+// lines. CSV is a Todoist template when it has TYPE and CONTENT columns and
+// rem's CSV when it has rem's columns. Text formats need a line only they
+// would have: a Markdown checkbox, an Org TODO headline or "#+" keyword, or
+// a TaskPaper task under a project. todo.txt has no such marker and is
+// never detected.
+func DetectFormat(head []byte) string {
+	head = bytes.TrimPrefix(head, []byte("\ufeff"))
+	trimmed := bytes.TrimSpace(head)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	switch trimmed[0] {
+	case '[':
+		switch {
+		case thingsType.Match(trimmed):
+			return "things"
+		case msTodoKey.Match(trimmed):
+			return "mstodo"
+		}
+		return "json"
+	case '{':
+		switch {
+		case googleTasksKind.Match(trimmed):
+			return "googletasks"
+		case msTodoKey.Match(trimmed):
+			return "mstodo"
+		}
+		first, _, _ := bytes.Cut(trimmed, []byte("\n"))
+		if json.Valid(bytes.TrimSpace(first)) {
+			return "ndjson"
+		}
+		return ""
+	}
+
+	if bytes.HasPrefix(bytes.ToUpper(trimmed), []byte("BEGIN:VCALENDAR")) {
+		return "ics"
+	}
+
+	lines := strings.Split(string(trimmed), "\n")
+	if format := detectCSV(lines[0]); format != "" {
+		return format
+	}
+	return detectText(lines)
+}
+
+// detectCSV recognizes a CSV header line.
+func detectCSV(line string) string {
+	if !strings.Contains(line, ",") {
+		return ""
+	}
+	header, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return ""
+	}
+	cols := make(map[string]bool, len(header))
+	for _, h := range header {
+		cols[strings.TrimSpace(h)] = true
+	}
+	switch {
+	case cols["TYPE"] && cols["CONTENT"]:
+		return "todoist"
+	case cols["name"] && (cols["list_name"] || cols["due_date"]):
+		return "csv"
+	}
+	return ""
+}
+
+// detectText looks for the first line that only Markdown, Org or
+// TaskPaper would have.
+func detectText(lines []string) string {
+	project := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case markdownTask.MatchString(line):
+			return "markdown"
+		case strings.HasPrefix(line, "#+"):
+			return "org"
+		}
+		if m := orgHeadline.FindStringSubmatch(line); m != nil && m[2] != "" {
+			return "org"
+		}
+		if project && indentWidth(line) > 0 && strings.HasPrefix(trimmed, "- ") {
+			return "taskpaper"
+		}
+		project = indentWidth(line) == 0 && strings.HasSuffix(trimmed, ":") && !strings.HasPrefix(trimmed, "- ")
+	}
+	return ""
+}
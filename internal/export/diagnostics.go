@@ -0,0 +1,146 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// Diagnostic severities.
+const (
+	// SeverityError means the record can't be imported.
+	SeverityError = "error"
+	// SeverityWarning means a field was dropped and the rest of the
+	// record can be imported.
+	SeverityWarning = "warning"
+)
+
+// Diagnostic describes a problem with one record of an imported file.
+type Diagnostic struct {
+	// Row is the record's position in the file, counting from 1: a data
+	// row of a CSV file, an element of a JSON array or a non-blank line of
+	// NDJSON. It matches the position of the reminder the importer returns.
+	Row int `json:"row"`
+	// Line is the line the record starts on, for line-based formats.
+	Line     int    `json:"line,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Problem  string `json:"problem"`
+	Severity string `json:"severity"`
+}
+
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "row %d", d.Row)
+	if d.Line > 0 {
+		fmt.Fprintf(&b, " (line %d)", d.Line)
+	}
+	if d.Field != "" {
+		fmt.Fprintf(&b, ": %s", d.Field)
+	}
+	fmt.Fprintf(&b, ": %s", d.Problem)
+	if d.Value != "" {
+		fmt.Fprintf(&b, ": %q", d.Value)
+	}
+	return b.String()
+}
+
+// WithDiagnostics has the JSON, NDJSON and CSV importers report values they
+// can't read to fn instead of silently dropping them. Records with errors,
+// such as a missing name, are still returned, so callers must skip them.
+func WithDiagnostics(fn func(Diagnostic)) Option {
+	return func(o *options) { o.report = fn }
+}
+
+// validator reports the problems found in one record at a time.
+type validator struct {
+	report    func(Diagnostic)
+	row, line int
+}
+
+func newValidator(o options) *validator {
+	return &validator{report: o.report}
+}
+
+// next moves on to the record starting on line.
+func (v *validator) next(line int) {
+	v.row++
+	v.line = line
+}
+
+func (v *validator) add(severity, field, value, problem string) {
+	if v.report != nil {
+		v.report(Diagnostic{Row: v.row, Line: v.line, Field: field, Value: value, Problem: problem, Severity: severity})
+	}
+}
+
+func (v *validator) name(name string) {
+	if strings.TrimSpace(name) == "" {
+		v.add(SeverityError, "name", "", "missing name")
+	}
+}
+
+// date reads a timestamp with parse, reporting values it rejects.
+func (v *validator) date(field, value string, parse func(string) (time.Time, error)) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := parse(value)
+	if err != nil {
+		v.add(SeverityWarning, field, value, "invalid date")
+		return nil
+	}
+	return &t
+}
+
+// priority reads a priority number (0-9) or label.
+func (v *validator) priority(value string) reminder.Priority {
+	if value == "" {
+		return reminder.PriorityNone
+	}
+	if p, err := strconv.Atoi(value); err == nil {
+		return v.priorityNumber(p)
+	}
+	lower := strings.ToLower(value)
+	if p := reminder.ParsePriority(lower); p != reminder.PriorityNone || lower == "none" {
+		return p
+	}
+	v.add(SeverityWarning, "priority", value, "invalid priority (use 0-9, high, medium, low or none)")
+	return reminder.PriorityNone
+}
+
+func (v *validator) priorityNumber(p int) reminder.Priority {
+	if p < 0 || p > 9 {
+		v.add(SeverityWarning, "priority", strconv.Itoa(p), "priority out of range (use 0-9)")
+		return reminder.PriorityNone
+	}
+	return reminder.Priority(p)
+}
+
+// boolean reads the ways spreadsheets write true and false: "true", "yes",
+// "1" or "x", and "false", "no", "0" or "".
+func (v *validator) boolean(field, value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "x":
+		return true
+	case "false", "no", "n", "0", "":
+		return false
+	}
+	v.add(SeverityWarning, field, value, "invalid boolean (use true or false)")
+	return false
+}
+
+func (v *validator) recurrence(value string, loc *time.Location) *reminder.Recurrence {
+	if value == "" {
+		return nil
+	}
+	rec, err := reminder.ParseRRule(value, loc)
+	if err != nil {
+		v.add(SeverityWarning, "recurrence", value, "invalid recurrence rule")
+		return nil
+	}
+	return rec
+}
@@ -2,6 +2,9 @@ package export
 
 import (
 	"bytes"
+	"encoding/json"
+	"io"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -189,6 +192,60 @@ func TestExportImportRoundTrip(t *testing.T) {
 	}
 }
 
+func TestRoundTripKeepsCompletionAndTimestamps(t *testing.T) {
+	created := time.Date(2025, 11, 3, 9, 15, 0, 0, time.Local)
+	modified := time.Date(2025, 11, 5, 18, 0, 0, 0, time.Local)
+	completed := time.Date(2025, 11, 6, 8, 30, 0, 0, time.Local)
+	original := &reminder.Reminder{
+		ID:               "done-1",
+		Name:             "File taxes",
+		ListName:         "Personal",
+		Flagged:          true,
+		Completed:        true,
+		CompletionDate:   &completed,
+		CreationDate:     &created,
+		ModificationDate: &modified,
+	}
+
+	for _, format := range []string{"json", "csv"} {
+		var buf bytes.Buffer
+		var imported []*reminder.Reminder
+		var err error
+		if format == "json" {
+			err = ExportJSON(&buf, []*reminder.Reminder{original})
+		} else {
+			err = ExportCSV(&buf, []*reminder.Reminder{original})
+		}
+		if err != nil {
+			t.Fatalf("%s: export failed: %v", format, err)
+		}
+		if format == "json" {
+			imported, err = ImportJSON(&buf)
+		} else {
+			imported, err = ImportCSV(&buf)
+		}
+		if err != nil || len(imported) != 1 {
+			t.Fatalf("%s: import failed: %v, %d reminders", format, err, len(imported))
+		}
+		r := imported[0]
+		if r.ID != original.ID || !r.Completed || !r.Flagged {
+			t.Errorf("%s: got %+v", format, r)
+		}
+		for _, d := range []struct {
+			field     string
+			got, want *time.Time
+		}{
+			{"completion date", r.CompletionDate, &completed},
+			{"creation date", r.CreationDate, &created},
+			{"modification date", r.ModificationDate, &modified},
+		} {
+			if d.got == nil || !d.got.Equal(*d.want) {
+				t.Errorf("%s: %s = %v, want %v", format, d.field, d.got, d.want)
+			}
+		}
+	}
+}
+
 func TestSpecialCharacters(t *testing.T) {
 	dueDate := time.Date(2026, 2, 15, 14, 30, 0, 0, time.Local)
 	reminders := []*reminder.Reminder{
@@ -239,3 +296,1149 @@ func TestSpecialCharacters(t *testing.T) {
 		t.Errorf("CSV name with special chars: expected %q, got %q", reminders[0].Name, csvImported[0].Name)
 	}
 }
+
+func TestRecurrenceRoundTrip(t *testing.T) {
+	dueDate := time.Date(2026, 2, 16, 9, 0, 0, 0, time.Local)
+	rec, err := reminder.ParseRRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", time.Local)
+	if err != nil {
+		t.Fatalf("ParseRRule failed: %v", err)
+	}
+	reminders := []*reminder.Reminder{
+		{ID: "test-repeat", Name: "Standup", ListName: "Work", DueDate: &dueDate, Recurrence: rec},
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := ExportJSON(&jsonBuf, reminders); err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+	if !strings.Contains(jsonBuf.String(), `"recurrence": "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"`) {
+		t.Errorf("JSON output should contain the RRULE, got %s", jsonBuf.String())
+	}
+	imported, err := ImportJSON(&jsonBuf)
+	if err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+	if imported[0].Recurrence == nil || imported[0].Recurrence.RRule() != rec.RRule() {
+		t.Errorf("JSON recurrence not preserved: %v", imported[0].Recurrence)
+	}
+
+	var csvBuf bytes.Buffer
+	if err := ExportCSV(&csvBuf, reminders); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	csvImported, err := ImportCSV(&csvBuf)
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if csvImported[0].Recurrence == nil || csvImported[0].Recurrence.RRule() != rec.RRule() {
+		t.Errorf("CSV recurrence not preserved: %v", csvImported[0].Recurrence)
+	}
+}
+
+func TestRFC3339RoundTrip(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	dueDate := time.Date(2026, 3, 1, 10, 0, 0, 0, berlin)
+	reminders := []*reminder.Reminder{{ID: "test-tz", Name: "Call Berlin", DueDate: &dueDate}}
+
+	var jsonBuf bytes.Buffer
+	if err := ExportJSON(&jsonBuf, reminders, WithRFC3339(), WithLocation(berlin)); err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+	if !strings.Contains(jsonBuf.String(), `"due_date": "2026-03-01T10:00:00+01:00"`) {
+		t.Errorf("JSON output should contain an RFC 3339 due date, got %s", jsonBuf.String())
+	}
+	imported, err := ImportJSON(&jsonBuf)
+	if err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+	if imported[0].DueDate == nil || !imported[0].DueDate.Equal(dueDate) {
+		t.Errorf("JSON due date = %v, want %v", imported[0].DueDate, dueDate)
+	}
+
+	var csvBuf bytes.Buffer
+	if err := ExportCSV(&csvBuf, reminders, WithRFC3339(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if !strings.Contains(csvBuf.String(), "2026-03-01T09:00:00Z") {
+		t.Errorf("CSV output should contain the due date in UTC, got %s", csvBuf.String())
+	}
+	csvImported, err := ImportCSV(&csvBuf)
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if csvImported[0].DueDate == nil || !csvImported[0].DueDate.Equal(dueDate) {
+		t.Errorf("CSV due date = %v, want %v", csvImported[0].DueDate, dueDate)
+	}
+}
+
+func TestImportMixedTimeFormats(t *testing.T) {
+	jsonData := `[
+		{"name": "Legacy", "list_name": "Work", "due_date": "2026-03-01T10:00:00"},
+		{"name": "Zoned", "list_name": "Work", "due_date": "2026-03-01T10:00:00-05:00"}
+	]`
+	imported, err := ImportJSON(strings.NewReader(jsonData))
+	if err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+
+	legacy := time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)
+	if imported[0].DueDate == nil || !imported[0].DueDate.Equal(legacy) {
+		t.Errorf("legacy due date = %v, want %v", imported[0].DueDate, legacy)
+	}
+	zoned := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	if imported[1].DueDate == nil || !imported[1].DueDate.Equal(zoned) {
+		t.Errorf("zoned due date = %v, want %v", imported[1].DueDate, zoned)
+	}
+}
+
+func TestICSRoundTrip(t *testing.T) {
+	due := time.Date(2026, 2, 15, 14, 30, 0, 0, time.UTC)
+	alarm := due.Add(-15 * time.Minute)
+	completed := time.Date(2026, 2, 14, 8, 0, 0, 0, time.UTC)
+	rec, _ := reminder.ParseRRule("FREQ=WEEKLY;BYDAY=MO", time.UTC)
+	reminders := []*reminder.Reminder{
+		{
+			ID:           "test-id-1",
+			Name:         "Buy groceries; milk, eggs",
+			Body:         "Line one\nLine two with a very long tail that needs folding because it is well over seventy-five octets — ünïcödé",
+			ListName:     "Personal, Home",
+			DueDate:      &due,
+			RemindMeDate: &alarm,
+			Priority:     reminder.PriorityHigh,
+			Flagged:      true,
+			URL:          "https://example.com/a?b=c;d",
+			Recurrence:   rec,
+		},
+		{
+			ID:             "test-id-2",
+			Name:           "Done thing",
+			ListName:       "Work",
+			Priority:       reminder.PriorityLow,
+			Completed:      true,
+			CompletionDate: &completed,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportICS(&buf, reminders, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("ExportICS failed: %v", err)
+	}
+	output := buf.String()
+	for _, line := range strings.Split(output, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	for _, want := range []string{"BEGIN:VTODO", `SUMMARY:Buy groceries\; milk\, eggs`, "DUE:20260215T143000\r\n", "X-APPLE-FLAGGED:TRUE", "TRIGGER;VALUE=DATE-TIME:20260215T141500Z", "STATUS:COMPLETED", "PRIORITY:9"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("ICS output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	imported, err := ImportICS(&buf, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("ImportICS failed: %v", err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(imported))
+	}
+	got := imported[0]
+	want := reminders[0]
+	if got.Name != want.Name || got.Body != want.Body || got.ListName != want.ListName || got.URL != want.URL {
+		t.Errorf("text fields not preserved: %+v", got)
+	}
+	if got.DueDate == nil || !got.DueDate.Equal(due) || got.RemindMeDate == nil || !got.RemindMeDate.Equal(alarm) {
+		t.Errorf("dates not preserved: due %v, alarm %v", got.DueDate, got.RemindMeDate)
+	}
+	if got.Priority != reminder.PriorityHigh || !got.Flagged || got.Completed {
+		t.Errorf("flags not preserved: %+v", got)
+	}
+	if got.Recurrence == nil || got.Recurrence.RRule() != "FREQ=WEEKLY;BYDAY=MO" {
+		t.Errorf("recurrence not preserved: %v", got.Recurrence)
+	}
+	done := imported[1]
+	if !done.Completed || done.CompletionDate == nil || !done.CompletionDate.Equal(completed) || done.Priority != reminder.PriorityLow {
+		t.Errorf("completion not preserved: %+v", done)
+	}
+}
+
+func TestICSRecurringKeepsLocalTime(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	due := time.Date(2026, 3, 2, 9, 30, 0, 0, ny)
+	rec, _ := reminder.ParseRRule("FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", ny)
+	var buf bytes.Buffer
+	if err := ExportICS(&buf, []*reminder.Reminder{{ID: "standup", Name: "Standup", DueDate: &due, Recurrence: rec}}, WithLocation(ny)); err != nil {
+		t.Fatalf("ExportICS failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "DUE:20260302T093000\r\n") {
+		t.Errorf("expected a floating local due date, got:\n%s", buf.String())
+	}
+
+	imported, err := ImportICS(&buf, WithLocation(ny))
+	if err != nil || len(imported) != 1 {
+		t.Fatalf("ImportICS failed: %v, %d reminders", err, len(imported))
+	}
+	r := imported[0]
+	if r.DueDate == nil || !r.DueDate.Equal(due) || r.Recurrence == nil {
+		t.Fatalf("unexpected reminder: %+v", r)
+	}
+	// After the change to daylight saving time the standup is still at 9:30.
+	from := time.Date(2026, 10, 5, 0, 0, 0, 0, ny)
+	for _, o := range r.Recurrence.Occurrences(*r.DueDate, from, from.AddDate(0, 0, 1)) {
+		if o.In(ny).Hour() != 9 || o.In(ny).Minute() != 30 {
+			t.Errorf("occurrence at %v, want 9:30 New York time", o.In(ny))
+		}
+	}
+
+	// A UTC due date from another tool is read in the local zone too.
+	utc := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Standup\r\nDUE:20260302T143000Z\r\nRRULE:FREQ=DAILY\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+	imported, err = ImportICS(strings.NewReader(utc), WithLocation(ny))
+	if err != nil || len(imported) != 1 || imported[0].DueDate == nil {
+		t.Fatalf("ImportICS failed: %v, %+v", err, imported)
+	}
+	if loc := imported[0].DueDate.Location(); loc != ny {
+		t.Errorf("due date zone = %v, want America/New_York", loc)
+	}
+}
+
+func TestExportICSWithoutIDs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportICS(&buf, []*reminder.Reminder{{Name: "First"}, {Name: "Second"}}); err != nil {
+		t.Fatalf("ExportICS failed: %v", err)
+	}
+	imported, err := ImportICS(&buf)
+	if err != nil {
+		t.Fatalf("ImportICS failed: %v", err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(imported))
+	}
+	if imported[0].ID == "" || imported[0].ID == imported[1].ID {
+		t.Errorf("expected distinct UIDs, got %q and %q", imported[0].ID, imported[1].ID)
+	}
+}
+
+func TestImportICSFromOtherTools(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"PRODID:-//Other//Tasks//EN\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Not a task\r\nEND:VEVENT\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:abc\r\n" +
+		"SUMMARY:Submit\r\n  report\r\n" +
+		"DUE;TZID=\"America/New_York\":20260301T090000\r\n" +
+		"PRIORITY:3\r\n" +
+		"CATEGORIES:Work,Urgent\r\n" +
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-PT30M\r\nEND:VALARM\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:All day\r\nDUE;VALUE=DATE:20260302\r\nPRIORITY:5\r\nEND:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	imported, err := ImportICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("ImportICS failed: %v", err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(imported))
+	}
+
+	r := imported[0]
+	if r.Name != "Submit report" || r.ListName != "Work" || r.Priority != reminder.PriorityHigh {
+		t.Errorf("unexpected reminder: %+v", r)
+	}
+	wantDue := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	if r.DueDate == nil || !r.DueDate.Equal(wantDue) {
+		t.Errorf("due = %v, want %v", r.DueDate, wantDue)
+	}
+	if r.RemindMeDate == nil || !r.RemindMeDate.Equal(wantDue.Add(-30*time.Minute)) {
+		t.Errorf("alarm = %v, want 30 minutes before due", r.RemindMeDate)
+	}
+
+	allDay := imported[1]
+	if allDay.DueDate == nil || allDay.DueDate.Day() != 2 || allDay.Priority != reminder.PriorityMedium {
+		t.Errorf("unexpected all-day reminder: %+v", allDay)
+	}
+
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	imported, err = ImportICS(strings.NewReader(ics), WithLocation(tokyo))
+	if err != nil {
+		t.Fatalf("ImportICS with a location failed: %v", err)
+	}
+	if due := imported[1].DueDate; due == nil || !due.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, tokyo)) {
+		t.Errorf("floating due = %v, want midnight in UTC+9", due)
+	}
+	if due := imported[0].DueDate; due == nil || !due.Equal(wantDue) {
+		t.Errorf("due with TZID = %v, want %v", due, wantDue)
+	}
+}
+
+func TestImportICSErrors(t *testing.T) {
+	for desc, ics := range map[string]string{
+		"unterminated": "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:x\r\n",
+		"no colon":     "BEGIN:VCALENDAR\r\nGARBAGE\r\nEND:VCALENDAR\r\n",
+	} {
+		if _, err := ImportICS(strings.NewReader(ics)); err == nil {
+			t.Errorf("%s: expected error", desc)
+		}
+	}
+}
+
+func TestExportTodoTxt(t *testing.T) {
+	created := time.Date(2026, 1, 20, 9, 0, 0, 0, time.Local)
+	done := time.Date(2026, 2, 1, 18, 0, 0, 0, time.Local)
+	due := time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local)
+	dueAt := time.Date(2026, 2, 15, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		r    *reminder.Reminder
+		want string
+	}{
+		{
+			"open",
+			&reminder.Reminder{ID: "A1", Name: "Call mom", ListName: "Home Stuff", Body: "#phone", Priority: reminder.PriorityHigh, CreationDate: &created, DueDate: &due},
+			"(A) 2026-01-20 Call mom +Home_Stuff @phone due:2026-02-15 rem:A1",
+		},
+		{
+			"due time and url",
+			&reminder.Reminder{Name: "Review PR", Priority: reminder.PriorityMedium, URL: "https://example.com/pr/1", DueDate: &dueAt},
+			"(B) Review PR https://example.com/pr/1 due:2026-02-15T14:30",
+		},
+		{
+			"completed",
+			&reminder.Reminder{ID: "B2", Name: "File taxes", Completed: true, CompletionDate: &done, CreationDate: &created, Priority: reminder.PriorityLow},
+			"x 2026-02-01 2026-01-20 File taxes pri:C rem:B2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTodoTxt(tt.r); got != tt.want {
+				t.Errorf("FormatTodoTxt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportTodoTxt(t *testing.T) {
+	data := `(A) 2026-01-20 Call mom +Home_Stuff @phone due:2026-02-15 rem:A1
+
+x 2026-02-01 2026-01-20 File taxes pri:B
+(D) Ratio a+b @not.a.tag +Second see https://example.com key:value due:2026-02-15T14:30
+`
+	reminders, err := ImportTodoTxt(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ImportTodoTxt failed: %v", err)
+	}
+	if len(reminders) != 3 {
+		t.Fatalf("expected 3 reminders, got %d", len(reminders))
+	}
+
+	r := reminders[0]
+	if r.ID != "A1" || r.Name != "Call mom" || r.ListName != "Home Stuff" || r.Priority != reminder.PriorityHigh || r.Body != "#phone" {
+		t.Errorf("unexpected first reminder: %+v", r)
+	}
+	if r.CreationDate == nil || r.CreationDate.Format("2006-01-02") != "2026-01-20" {
+		t.Errorf("CreationDate = %v, want 2026-01-20", r.CreationDate)
+	}
+	if r.DueDate == nil || !r.DueDate.Equal(time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("DueDate = %v, want 2026-02-15", r.DueDate)
+	}
+
+	r = reminders[1]
+	if !r.Completed || r.CompletionDate == nil || r.CompletionDate.Format("2006-01-02") != "2026-02-01" || r.Priority != reminder.PriorityMedium {
+		t.Errorf("unexpected completed reminder: %+v", r)
+	}
+
+	r = reminders[2]
+	if r.Name != "Ratio a+b @not.a.tag see key:value" || r.ListName != "Second" || r.URL != "https://example.com" || r.Priority != reminder.PriorityLow {
+		t.Errorf("unexpected third reminder: %+v", r)
+	}
+	if r.DueDate == nil || r.DueDate.Hour() != 14 || r.DueDate.Minute() != 30 {
+		t.Errorf("DueDate = %v, want 14:30", r.DueDate)
+	}
+
+	for _, line := range reminders {
+		again, err := ParseTodoTxt(FormatTodoTxt(line))
+		if err != nil {
+			t.Fatalf("ParseTodoTxt failed: %v", err)
+		}
+		if FormatTodoTxt(again) != FormatTodoTxt(line) {
+			t.Errorf("round trip changed %q to %q", FormatTodoTxt(line), FormatTodoTxt(again))
+		}
+	}
+}
+
+func TestImportTodoTxtErrors(t *testing.T) {
+	for desc, data := range map[string]string{
+		"bad due":        "Pay rent due:tomorrow\n",
+		"no description": "x 2026-02-01 +Home\n",
+	} {
+		if _, err := ImportTodoTxt(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected error", desc)
+		}
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	created := time.Date(2026, 1, 20, 0, 0, 0, 0, time.Local)
+	done := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	remind := time.Date(2026, 2, 14, 9, 0, 0, 0, time.Local)
+	original := sampleReminders()
+	original[0].CreationDate = &created
+	original[0].RemindMeDate = &remind
+	original[0].Recurrence = &reminder.Recurrence{Frequency: reminder.FrequencyWeekly, ByDay: []reminder.WeekdayNum{{Day: time.Monday}}}
+	original[1].Completed = true
+	original[1].CompletionDate = &done
+	original = append(original, &reminder.Reminder{
+		Name:       "Pay rent",
+		ListName:   "Personal",
+		Priority:   reminder.PriorityLow,
+		Recurrence: &reminder.Recurrence{Frequency: reminder.FrequencyMonthly, ByDay: []reminder.WeekdayNum{{N: 1, Day: time.Monday}}},
+	})
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(&buf, original); err != nil {
+		t.Fatalf("ExportMarkdown failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Personal\n\n- [ ] Buy groceries ⏫ 🔁 every week on Mon ➕ 2026-01-20 ⏳ 2026-02-14 09:00 📅 2026-02-15 14:30\n    Milk, eggs, bread\n",
+		"- [ ] Pay rent 🔽 🔁 FREQ=MONTHLY;BYDAY=1MO\n",
+		"## Work\n\n- [x] [Review PR](https://github.com/example) ✅ 2026-02-01\n    Check auth changes\n\n    URL: https://github.com/example\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	imported, err := ImportMarkdown(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("ImportMarkdown failed: %v", err)
+	}
+	if len(imported) != len(original) {
+		t.Fatalf("expected %d reminders, got %d", len(original), len(imported))
+	}
+	byName := map[string]*reminder.Reminder{}
+	for _, r := range imported {
+		byName[r.Name] = r
+	}
+	for _, want := range original {
+		got := byName[want.Name]
+		if got == nil {
+			t.Errorf("missing %q", want.Name)
+			continue
+		}
+		if got.ListName != want.ListName || got.Body != want.Body || got.URL != want.URL || got.Priority != want.Priority || got.Completed != want.Completed {
+			t.Errorf("%s: got %+v, want %+v", want.Name, got, want)
+		}
+		for _, d := range []struct {
+			name      string
+			got, want *time.Time
+		}{
+			{"due", got.DueDate, want.DueDate},
+			{"remind", got.RemindMeDate, want.RemindMeDate},
+			{"created", got.CreationDate, want.CreationDate},
+			{"completed", got.CompletionDate, want.CompletionDate},
+		} {
+			if (d.got == nil) != (d.want == nil) || (d.got != nil && !d.got.Equal(*d.want)) {
+				t.Errorf("%s: %s date = %v, want %v", want.Name, d.name, d.got, d.want)
+			}
+		}
+		if (got.Recurrence == nil) != (want.Recurrence == nil) || (got.Recurrence != nil && got.Recurrence.RRule() != want.Recurrence.RRule()) {
+			t.Errorf("%s: recurrence = %v, want %v", want.Name, got.Recurrence, want.Recurrence)
+		}
+	}
+}
+
+func TestImportMarkdownNotes(t *testing.T) {
+	data := `# Meeting notes
+
+Some discussion that isn't a task.
+
+## Follow-ups
+
+* [X] Send slides 🔺 ✅️ 2026-02-01
+1. [ ] Book room 🗓️ 2026-02-20 🛫 2026-02-18 🔁 every week when done
+	- bring adapters
+- [/] Draft plan ⏬
+  - [ ] Outline #docs
+- [-] Cancelled idea
+`
+	reminders, err := ImportMarkdown(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ImportMarkdown failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		list      string
+		completed bool
+		priority  reminder.Priority
+		body      string
+	}{
+		{"Send slides", "Follow-ups", true, reminder.PriorityHigh, ""},
+		{"Book room", "Follow-ups", false, reminder.PriorityNone, "- bring adapters"},
+		{"Draft plan", "Follow-ups", false, reminder.PriorityLow, ""},
+		{"Outline #docs", "Follow-ups", false, reminder.PriorityNone, ""},
+		{"Cancelled idea", "Follow-ups", false, reminder.PriorityNone, ""},
+	}
+	if len(reminders) != len(tests) {
+		t.Fatalf("expected %d reminders, got %d", len(tests), len(reminders))
+	}
+	for i, tt := range tests {
+		r := reminders[i]
+		if r.Name != tt.name || r.ListName != tt.list || r.Completed != tt.completed || r.Priority != tt.priority || r.Body != tt.body {
+			t.Errorf("reminder %d = %+v, want %+v", i, r, tt)
+		}
+	}
+	if r := reminders[1]; r.DueDate == nil || r.DueDate.Day() != 20 || r.Recurrence == nil || r.Recurrence.Frequency != reminder.FrequencyWeekly {
+		t.Errorf("unexpected dates for Book room: due %v, recurrence %v", r.DueDate, r.Recurrence)
+	}
+}
+
+func TestImportMarkdownErrors(t *testing.T) {
+	for desc, data := range map[string]string{
+		"bad date":       "- [ ] Pay rent 📅 soon\n",
+		"bad recurrence": "- [ ] Pay rent 🔁 every blue moon\n",
+		"no title":       "- [ ] 📅 2026-02-15\n",
+	} {
+		if _, err := ImportMarkdown(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected error", desc)
+		}
+	}
+}
+
+// fullReminders returns reminders that set every exported field, with
+// times to the minute.
+func fullReminders() []*reminder.Reminder {
+	at := func(day, hour, min int) *time.Time {
+		t := time.Date(2026, 2, day, hour, min, 0, 0, time.Local)
+		return &t
+	}
+	return []*reminder.Reminder{
+		{
+			ID:               "FULL-1",
+			Name:             "Review PR",
+			Body:             "Check auth changes\n\nFollow-up: ask about tests",
+			ListName:         "Work Items",
+			DueDate:          at(15, 14, 30),
+			RemindMeDate:     at(15, 9, 0),
+			CreationDate:     at(1, 8, 15),
+			ModificationDate: at(2, 10, 45),
+			Priority:         reminder.Priority(3),
+			Flagged:          true,
+			URL:              "https://example.com/wiki/Foo_(bar)",
+			Recurrence:       &reminder.Recurrence{Frequency: reminder.FrequencyWeekly, Interval: 2, ByDay: []reminder.WeekdayNum{{Day: time.Tuesday}, {Day: time.Thursday}}},
+		},
+		{
+			ID:             "FULL-2",
+			Name:           "Pay rent",
+			ListName:       "Home",
+			DueDate:        at(28, 0, 0),
+			CompletionDate: at(27, 18, 5),
+			Completed:      true,
+			Priority:       reminder.PriorityLow,
+			Recurrence:     &reminder.Recurrence{Frequency: reminder.FrequencyMonthly},
+		},
+		{Name: "Loose end"},
+	}
+}
+
+func TestOrgAndTaskPaperRoundTrip(t *testing.T) {
+	codecs := []struct {
+		name string
+		exp  func(*bytes.Buffer, []*reminder.Reminder) error
+		imp  func(*bytes.Buffer) ([]*reminder.Reminder, error)
+	}{
+		{"org", func(b *bytes.Buffer, r []*reminder.Reminder) error { return ExportOrg(b, r) }, func(b *bytes.Buffer) ([]*reminder.Reminder, error) { return ImportOrg(b) }},
+		{"taskpaper", func(b *bytes.Buffer, r []*reminder.Reminder) error { return ExportTaskPaper(b, r) }, func(b *bytes.Buffer) ([]*reminder.Reminder, error) { return ImportTaskPaper(b) }},
+	}
+	for _, c := range codecs {
+		t.Run(c.name, func(t *testing.T) {
+			original := fullReminders()
+			var buf bytes.Buffer
+			if err := c.exp(&buf, original); err != nil {
+				t.Fatalf("export failed: %v", err)
+			}
+			text := buf.String()
+			imported, err := c.imp(&buf)
+			if err != nil {
+				t.Fatalf("import failed: %v\n%s", err, text)
+			}
+			if len(imported) != len(original) {
+				t.Fatalf("expected %d reminders, got %d:\n%s", len(original), len(imported), text)
+			}
+			for i := range original {
+				want, _ := json.Marshal(ToJSON(original[i]))
+				got, _ := json.Marshal(ToJSON(imported[i]))
+				if string(got) != string(want) {
+					t.Errorf("reminder %d:\n got %s\nwant %s\n%s", i, got, want, text)
+				}
+			}
+		})
+	}
+}
+
+func TestExportOrg(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportOrg(&buf, fullReminders()[1:]); err != nil {
+		t.Fatalf("ExportOrg failed: %v", err)
+	}
+	want := `* DONE [#C] Pay rent
+  CLOSED: [2026-02-27 Fri 18:05] DEADLINE: <2026-02-28 Sat +1m>
+  :PROPERTIES:
+  :REM_ID:   FULL-2
+  :LIST:     Home
+  :RRULE:    FREQ=MONTHLY
+  :END:
+* TODO Loose end
+`
+	if got := buf.String(); got != want {
+		t.Errorf("ExportOrg() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestImportOrgFromEmacs(t *testing.T) {
+	data := `#+TITLE: Tasks
+* Errands
+** NEXT [#B] Buy milk                                          :home:flagged:
+   SCHEDULED: <2026-02-14 Sat> DEADLINE: <2026-02-15 Sun 10:00-11:00 .+1w -2d>
+   Whole milk only.
+** CANCELLED Return parcel
+* Work
+** TODO Plan sprint
+   :PROPERTIES:
+   :LIST: Planning
+   :END:
+`
+	reminders, err := ImportOrg(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ImportOrg failed: %v", err)
+	}
+	if len(reminders) != 3 {
+		t.Fatalf("expected 3 reminders, got %d", len(reminders))
+	}
+	r := reminders[0]
+	if r.Name != "Buy milk" || r.ListName != "Errands" || r.Priority != reminder.PriorityMedium || !r.Flagged || r.Body != "Whole milk only." {
+		t.Errorf("unexpected first reminder: %+v", r)
+	}
+	if r.DueDate == nil || r.DueDate.Hour() != 10 || r.RemindMeDate == nil || r.Recurrence == nil || r.Recurrence.RRule() != "FREQ=WEEKLY" {
+		t.Errorf("unexpected planning: due %v, scheduled %v, recurrence %v", r.DueDate, r.RemindMeDate, r.Recurrence)
+	}
+	if r := reminders[1]; !r.Completed || r.ListName != "Errands" {
+		t.Errorf("unexpected second reminder: %+v", r)
+	}
+	if r := reminders[2]; r.ListName != "Planning" {
+		t.Errorf("expected LIST property to win, got %q", r.ListName)
+	}
+
+	for desc, data := range map[string]string{
+		"bad timestamp":   "* TODO x\n  DEADLINE: <tomorrow>\n",
+		"unclosed drawer": "* TODO x\n  :PROPERTIES:\n  :LIST: a\n",
+		"bad property":    "* TODO x\n  :PROPERTIES:\n  garbage\n  :END:\n",
+	} {
+		if _, err := ImportOrg(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected error", desc)
+		}
+	}
+}
+
+func TestImportTaskPaper(t *testing.T) {
+	data := `Inbox:
+	- Call the bank @today @priority(high) @due(2026-02-15)
+		Ask about the fee.
+	Errands:
+		- Buy stamps @done
+	- Plan trip @flag
+- Loose task @waiting
+Notes that aren't tasks.
+`
+	reminders, err := ImportTaskPaper(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ImportTaskPaper failed: %v", err)
+	}
+	tests := []struct {
+		name, list, body string
+		completed        bool
+	}{
+		{"Call the bank @today", "Inbox", "Ask about the fee.", false},
+		{"Buy stamps", "Errands", "", true},
+		{"Plan trip", "Inbox", "", false},
+		{"Loose task @waiting", "", "", false},
+	}
+	if len(reminders) != len(tests) {
+		t.Fatalf("expected %d reminders, got %d", len(tests), len(reminders))
+	}
+	for i, tt := range tests {
+		r := reminders[i]
+		if r.Name != tt.name || r.ListName != tt.list || r.Body != tt.body || r.Completed != tt.completed {
+			t.Errorf("reminder %d = %+v, want %+v", i, r, tt)
+		}
+	}
+	if r := reminders[0]; r.Priority != reminder.PriorityHigh || r.DueDate == nil {
+		t.Errorf("unexpected first reminder: %+v", r)
+	}
+	if !reminders[2].Flagged {
+		t.Errorf("expected Plan trip to be flagged")
+	}
+
+	if _, err := ImportTaskPaper(strings.NewReader("- Pay rent @due(soon)\n")); err == nil {
+		t.Errorf("expected error for an invalid date")
+	}
+}
+
+func TestNDJSONRoundTrip(t *testing.T) {
+	original := sampleReminders()
+
+	var buf bytes.Buffer
+	if err := ExportNDJSON(&buf, original); err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(original) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(original), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var jr JSONReminder
+		if err := json.Unmarshal([]byte(line), &jr); err != nil || jr.ID != original[i].ID {
+			t.Errorf("line %d = %q, want the JSON for %s", i+1, line, original[i].ID)
+		}
+	}
+
+	// Blank lines are skipped.
+	input := "\n" + strings.Join(lines, "\n\n") + "\n"
+	imported, err := ImportNDJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ImportNDJSON failed: %v", err)
+	}
+	if len(imported) != len(original) {
+		t.Fatalf("expected %d reminders, got %d", len(original), len(imported))
+	}
+	for i := range original {
+		if imported[i].Name != original[i].Name || imported[i].Priority != original[i].Priority {
+			t.Errorf("reminder %d = %+v, want %+v", i, imported[i], original[i])
+		}
+	}
+}
+
+func TestNDJSONDecoder(t *testing.T) {
+	d := NewNDJSONDecoder(strings.NewReader(`{"name": "First"}` + "\n" + `{"name": "Second"}` + "\n" + `{"name": ` + "\n"))
+	for _, want := range []string{"First", "Second"} {
+		r, err := d.Decode()
+		if err != nil || r.Name != want {
+			t.Fatalf("Decode() = %v, %v; want %s", r, err, want)
+		}
+	}
+	if _, err := d.Decode(); err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("expected an error on line 3, got %v", err)
+	}
+
+	d = NewNDJSONDecoder(strings.NewReader(`{"name": "No newline"}`))
+	if r, err := d.Decode(); err != nil || r.Name != "No newline" {
+		t.Fatalf("Decode() = %v, %v", r, err)
+	}
+	if _, err := d.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestImportTodoist(t *testing.T) {
+	input := "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"task,Inbox task @errand,,4,1,,,,en,\n" +
+		"section,Work,,,,,,,,\n" +
+		"task,Write report,Quarterly numbers,1,1,,,2026-02-15,en,\n" +
+		"task,Collect figures,,2,2,,,,en,\n" +
+		"note,Ask finance first,,,,,,,,\n" +
+		"task,Stand-up,,3,1,,,every weekday,en,\n" +
+		"task,Odd date,,4,1,,,sometime soonish,en,\n" +
+		"meta,view_style,list,,,,,,,\n"
+
+	reminders, err := ImportTodoist(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ImportTodoist failed: %v", err)
+	}
+	if len(reminders) != 5 {
+		t.Fatalf("expected 5 reminders, got %d", len(reminders))
+	}
+
+	inbox := reminders[0]
+	if inbox.Name != "Inbox task" || inbox.ListName != "" || inbox.Priority != reminder.PriorityNone {
+		t.Errorf("inbox task = %+v", inbox)
+	}
+	if tags := inbox.Tags(); len(tags) != 1 || tags[0] != "errand" {
+		t.Errorf("inbox tags = %v, want [errand]", tags)
+	}
+
+	report := reminders[1]
+	if report.ListName != "Work" || report.Priority != reminder.PriorityHigh || report.Body != "Quarterly numbers" {
+		t.Errorf("report = %+v", report)
+	}
+	if want := time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local); report.DueDate == nil || !report.DueDate.Equal(want) {
+		t.Errorf("report due = %v, want %v", report.DueDate, want)
+	}
+
+	sub := reminders[2]
+	if sub.Priority != reminder.PriorityMedium || sub.Body != "Subtask of: Write report\nAsk finance first" {
+		t.Errorf("subtask = %+v", sub)
+	}
+
+	standup := reminders[3]
+	if standup.Priority != reminder.PriorityLow || standup.Recurrence == nil || standup.DueDate == nil {
+		t.Errorf("stand-up = %+v", standup)
+	}
+
+	odd := reminders[4]
+	if odd.DueDate != nil || odd.Body != "Todoist date: sometime soonish" {
+		t.Errorf("odd date = %+v", odd)
+	}
+
+	if _, err := ImportTodoist(strings.NewReader("name,body\nx,y\n")); err == nil {
+		t.Errorf("expected error for a CSV without TYPE and CONTENT")
+	}
+}
+
+func TestImportThings(t *testing.T) {
+	input := `[
+  {"type": "to-do", "attributes": {"title": "Buy milk", "when": "2026-02-14@18:00", "deadline": "2026-02-15",
+    "tags": ["Errand", "Home Stuff"], "notes": "Oat milk",
+    "checklist-items": [
+      {"type": "checklist-item", "attributes": {"title": "Check fridge", "completed": true}},
+      {"type": "checklist-item", "attributes": {"title": "Go to shop"}}
+    ]}},
+  {"type": "project", "attributes": {"title": "Launch", "items": [
+    {"type": "to-do", "attributes": {"title": "Write announcement", "when": "someday"}},
+    {"type": "heading", "attributes": {"title": "Later", "items": [
+      {"type": "to-do", "attributes": {"title": "Retro", "canceled": true}}
+    ]}}
+  ]}},
+  {"type": "to-do", "attributes": {"title": "Renew passport", "list": "Personal", "when": "2026-03-01", "completed": true}}
+]`
+
+	reminders, err := ImportThings(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ImportThings failed: %v", err)
+	}
+	if len(reminders) != 4 {
+		t.Fatalf("expected 4 reminders, got %d", len(reminders))
+	}
+
+	milk := reminders[0]
+	if want := time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local); milk.DueDate == nil || !milk.DueDate.Equal(want) {
+		t.Errorf("milk due = %v, want %v", milk.DueDate, want)
+	}
+	if want := time.Date(2026, 2, 14, 18, 0, 0, 0, time.Local); milk.RemindMeDate == nil || !milk.RemindMeDate.Equal(want) {
+		t.Errorf("milk remind = %v, want %v", milk.RemindMeDate, want)
+	}
+	if want := "Oat milk\n- [x] Check fridge\n- [ ] Go to shop\n#Errand #Home-Stuff"; milk.Body != want {
+		t.Errorf("milk body = %q, want %q", milk.Body, want)
+	}
+
+	if r := reminders[1]; r.ListName != "Launch" || r.DueDate != nil || r.Completed {
+		t.Errorf("announcement = %+v", r)
+	}
+	if r := reminders[2]; r.ListName != "Launch" || !r.Completed {
+		t.Errorf("retro = %+v", r)
+	}
+	passport := reminders[3]
+	if passport.ListName != "Personal" || !passport.Completed || passport.DueDate == nil || passport.RemindMeDate != nil {
+		t.Errorf("passport = %+v", passport)
+	}
+
+	if _, err := ImportThings(strings.NewReader(`[{"type": "to-do", "attributes": {"title": "x", "deadline": "soonish"}}]`)); err == nil {
+		t.Errorf("expected error for an invalid deadline")
+	}
+}
+
+func TestImportGoogleTasks(t *testing.T) {
+	input := `{
+  "kind": "tasks#taskLists",
+  "items": [{
+    "kind": "tasks#taskList", "id": "L1", "title": "My Tasks",
+    "items": [
+      {"kind": "tasks#task", "id": "a", "title": "Plan trip", "notes": "Book flights", "status": "needsAction",
+       "due": "2026-02-15T00:00:00.000Z", "updated": "2026-02-01T09:30:00.000Z",
+       "links": [{"type": "email", "description": "Itinerary", "link": "https://mail.google.com/x"}]},
+      {"kind": "tasks#task", "id": "b", "title": "Pack", "parent": "a", "status": "completed",
+       "completed": "2026-02-10T08:00:00.000Z"},
+      {"kind": "tasks#task", "id": "c", "title": "Removed", "deleted": true}
+    ]
+  }]
+}`
+
+	reminders, err := ImportGoogleTasks(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ImportGoogleTasks failed: %v", err)
+	}
+	if len(reminders) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(reminders))
+	}
+
+	trip := reminders[0]
+	if trip.ListName != "My Tasks" || trip.Body != "Book flights" || trip.URL != "https://mail.google.com/x" || trip.Completed {
+		t.Errorf("trip = %+v", trip)
+	}
+	if want := time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local); trip.DueDate == nil || !trip.DueDate.Equal(want) {
+		t.Errorf("trip due = %v, want %v", trip.DueDate, want)
+	}
+	if want := time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC); trip.ModificationDate == nil || !trip.ModificationDate.Equal(want) {
+		t.Errorf("trip modified = %v, want %v", trip.ModificationDate, want)
+	}
+
+	pack := reminders[1]
+	if !pack.Completed || pack.Body != "Subtask of: Plan trip" || pack.CompletionDate == nil {
+		t.Errorf("pack = %+v", pack)
+	}
+}
+
+func TestImportMSToDo(t *testing.T) {
+	lists := `[{
+  "displayName": "Groceries",
+  "tasks": [
+    {"title": "Eggs", "importance": "high", "status": "notStarted",
+     "body": {"content": "<p>Free range</p><p>A dozen &amp; more</p>", "contentType": "html"},
+     "dueDateTime": {"dateTime": "2026-02-15T00:00:00.0000000", "timeZone": "UTC"},
+     "reminderDateTime": {"dateTime": "2026-02-14T17:00:00.0000000", "timeZone": "UTC"},
+     "categories": ["Shopping"],
+     "linkedResources": [{"webUrl": "https://example.com/eggs"}]},
+    {"title": "Bread", "importance": "normal", "status": "completed",
+     "body": {"content": "", "contentType": "text"},
+     "completedDateTime": {"dateTime": "2026-02-10T08:00:00.0000000", "timeZone": "Pacific Standard Time"}}
+  ]
+}]`
+
+	reminders, err := ImportMSToDo(strings.NewReader(lists))
+	if err != nil {
+		t.Fatalf("ImportMSToDo failed: %v", err)
+	}
+	if len(reminders) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(reminders))
+	}
+
+	eggs := reminders[0]
+	if eggs.ListName != "Groceries" || eggs.Priority != reminder.PriorityHigh || eggs.URL != "https://example.com/eggs" {
+		t.Errorf("eggs = %+v", eggs)
+	}
+	if want := "Free range\nA dozen & more\n#Shopping"; eggs.Body != want {
+		t.Errorf("eggs body = %q, want %q", eggs.Body, want)
+	}
+	if want := time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local); eggs.DueDate == nil || !eggs.DueDate.Equal(want) {
+		t.Errorf("eggs due = %v, want %v", eggs.DueDate, want)
+	}
+	if want := time.Date(2026, 2, 14, 17, 0, 0, 0, time.UTC); eggs.RemindMeDate == nil || !eggs.RemindMeDate.Equal(want) {
+		t.Errorf("eggs remind = %v, want %v", eggs.RemindMeDate, want)
+	}
+
+	bread := reminders[1]
+	if !bread.Completed || bread.Priority != reminder.PriorityNone || bread.CompletionDate == nil {
+		t.Errorf("bread = %+v", bread)
+	}
+
+	// A tasks endpoint response has no list.
+	page := `{"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#tasks", "value": [{"title": "Call mum", "importance": "low"}]}`
+	reminders, err = ImportMSToDo(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("ImportMSToDo failed: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].ListName != "" || reminders[0].Priority != reminder.PriorityLow {
+		t.Errorf("tasks page = %+v", reminders)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "  \n", ""},
+		{"rem json", `[{"id": "x", "name": "A", "list_name": "Work"}]`, "json"},
+		{"ndjson", `{"id": "x", "name": "A"}` + "\n" + `{"id": "y", "name": "B"}`, "ndjson"},
+		{"pretty json object", "{\n  \"foo\": 1\n}", ""},
+		{"things", `[{"type": "to-do", "attributes": {"title": "A"}}]`, "things"},
+		{"ms to do lists", `[{"displayName": "Tasks", "tasks": []}]`, "mstodo"},
+		{"ms to do page", `{"@odata.context": "x", "value": []}`, "mstodo"},
+		{"google tasks", "{\n \"kind\": \"tasks#taskLists\",\n \"items\": []\n}", "googletasks"},
+		{"ics", "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n", "ics"},
+		{"todoist", "\ufeffTYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT\ntask,A,,1,1\n", "todoist"},
+		{"rem csv", "id,name,body,list_name,due_date\n", "csv"},
+		{"other csv", "title,when\nA,today\n", ""},
+		{"markdown", "# Notes\n\nSome text\n- [ ] Task\n", "markdown"},
+		{"org", "* Work\n** TODO Task\n", "org"},
+		{"org keywords", "#+TITLE: Tasks\n", "org"},
+		{"taskpaper", "Work:\n\t- Task @due(2026-02-15)\n", "taskpaper"},
+		{"todo.txt", "(A) Call mum +Family @phone\nx 2026-02-01 Pay rent\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat([]byte(tt.input)); got != tt.want {
+				t.Errorf("DetectFormat(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportCSVMapping(t *testing.T) {
+	m := &CSVMapping{}
+	if err := m.ParseMap("name=Task,list_name=Project,due_date=Deadline,remind_me_date=Alert,priority=Prio,completed=Done,due_date.layout=02/01/2006"); err != nil {
+		t.Fatalf("ParseMap failed: %v", err)
+	}
+	if err := m.Set("remind_me_date.layout", NaturalLayout); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("delimiter", "tab"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("encoding", "windows-1252"); err != nil {
+		t.Fatal(err)
+	}
+
+	// "Café – menu" in Windows-1252.
+	input := "Task\tProject\tDeadline\tAlert\tPrio\tDone\n" +
+		"Caf\xe9 \x96 menu\tHome\t15/02/2026\tFeb 14 2026 5pm\thigh\tyes\n" +
+		"Plain\t\t\t\t3\t\n"
+	reminders, err := ImportCSV(strings.NewReader(input), WithCSVMapping(m))
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if len(reminders) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(reminders))
+	}
+
+	r := reminders[0]
+	if r.Name != "Café – menu" || r.ListName != "Home" || r.Priority != reminder.PriorityHigh || !r.Completed {
+		t.Errorf("unexpected reminder: %+v", r)
+	}
+	if want := time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local); r.DueDate == nil || !r.DueDate.Equal(want) {
+		t.Errorf("due = %v, want %v", r.DueDate, want)
+	}
+	if want := time.Date(2026, 2, 14, 17, 0, 0, 0, time.Local); r.RemindMeDate == nil || !r.RemindMeDate.Equal(want) {
+		t.Errorf("remind = %v, want %v", r.RemindMeDate, want)
+	}
+	if r := reminders[1]; r.Priority != 3 || r.DueDate != nil || r.Completed {
+		t.Errorf("unexpected reminder: %+v", r)
+	}
+
+	m = &CSVMapping{Columns: map[string]string{"name": "Title"}}
+	if _, err := ImportCSV(strings.NewReader("Task\nx\n"), WithCSVMapping(m)); err == nil {
+		t.Errorf("expected error for a mapped column missing from the header")
+	}
+}
+
+func TestImportCSVUTF16(t *testing.T) {
+	// UTF-16LE with a byte order mark, as Excel's "Unicode text" writes.
+	text := "name;list_name\r\nÜbung;Sport\r\n"
+	data := []byte{0xFF, 0xFE}
+	for _, c := range text {
+		data = append(data, byte(c), byte(c>>8))
+	}
+	m := &CSVMapping{Comma: ';', Encoding: "utf-16"}
+	reminders, err := ImportCSV(bytes.NewReader(data), WithCSVMapping(m))
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].Name != "Übung" || reminders[0].ListName != "Sport" {
+		t.Errorf("unexpected reminders: %+v", reminders)
+	}
+}
+
+func TestCSVMappingErrors(t *testing.T) {
+	tests := []struct{ key, value string }{
+		{"title", "Task"},
+		{"name", ""},
+		{"priority.layout", "2006"},
+		{"layout", ""},
+		{"delimiter", ";;"},
+		{"delimiter", `"`},
+		{"encoding", "ebcdic"},
+	}
+	for _, tt := range tests {
+		m := &CSVMapping{}
+		if err := m.Set(tt.key, tt.value); err == nil {
+			t.Errorf("Set(%q, %q) succeeded, want an error", tt.key, tt.value)
+		}
+	}
+	if err := (&CSVMapping{}).ParseMap("name"); err == nil {
+		t.Errorf("expected error for a pair without '='")
+	}
+}
+
+func TestLoadCSVMapping(t *testing.T) {
+	path := t.TempDir() + "/team.map"
+	data := "# Exported from the team sheet\nname = Task\n\ndue_date = Due On\ndue_date.layout = Jan 2, 2006\ndelimiter = ;\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadCSVMapping(path)
+	if err != nil {
+		t.Fatalf("LoadCSVMapping failed: %v", err)
+	}
+	if m.Columns["name"] != "Task" || m.Columns["due_date"] != "Due On" || m.Layouts["due_date"] != "Jan 2, 2006" || m.Comma != ';' {
+		t.Errorf("unexpected mapping: %+v", m)
+	}
+
+	if err := os.WriteFile(path, []byte("name = Task\nbogus line\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCSVMapping(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("expected an error on line 2, got %v", err)
+	}
+}
+
+func TestImportDiagnostics(t *testing.T) {
+	collect := func() (*[]Diagnostic, Option) {
+		var diags []Diagnostic
+		return &diags, WithDiagnostics(func(d Diagnostic) { diags = append(diags, d) })
+	}
+
+	csvInput := "name,due_date,priority,flagged,recurrence\n" +
+		"Good,2026-02-15T10:00:00,high,true,FREQ=DAILY\n" +
+		",,,,\n" +
+		"\"Multi\nline\",soon,12,maybe,EVERY=DAY\n"
+	diags, opt := collect()
+	reminders, err := ImportCSV(strings.NewReader(csvInput), opt)
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if len(reminders) != 3 {
+		t.Fatalf("expected every row back, got %d", len(reminders))
+	}
+	want := []Diagnostic{
+		{Row: 2, Line: 3, Field: "name", Problem: "missing name", Severity: SeverityError},
+		{Row: 3, Line: 4, Field: "due_date", Value: "soon", Problem: "invalid date", Severity: SeverityWarning},
+		{Row: 3, Line: 4, Field: "priority", Value: "12", Problem: "priority out of range (use 0-9)", Severity: SeverityWarning},
+		{Row: 3, Line: 4, Field: "flagged", Value: "maybe", Problem: "invalid boolean (use true or false)", Severity: SeverityWarning},
+		{Row: 3, Line: 4, Field: "recurrence", Value: "EVERY=DAY", Problem: "invalid recurrence rule", Severity: SeverityWarning},
+	}
+	if len(*diags) != len(want) {
+		t.Fatalf("got diagnostics %+v, want %+v", *diags, want)
+	}
+	for i := range want {
+		if (*diags)[i] != want[i] {
+			t.Errorf("diagnostic %d = %+v, want %+v", i, (*diags)[i], want[i])
+		}
+	}
+	if r := reminders[2]; r.Name != "Multi\nline" || r.DueDate != nil || r.Priority != reminder.PriorityNone || r.Recurrence != nil {
+		t.Errorf("expected bad fields to be dropped, got %+v", r)
+	}
+
+	diags, opt = collect()
+	if _, err := ImportJSON(strings.NewReader(`[{"name": "A"}, {"name": "B", "due_date": "nope", "priority": -1}]`), opt); err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+	if len(*diags) != 2 || (*diags)[0].Row != 2 || (*diags)[0].Field != "due_date" || (*diags)[1].Field != "priority" {
+		t.Errorf("unexpected JSON diagnostics: %+v", *diags)
+	}
+
+	diags, opt = collect()
+	if _, err := ImportNDJSON(strings.NewReader("{\"name\": \"A\"}\n\n{\"name\": \"\"}\n"), opt); err != nil {
+		t.Fatalf("ImportNDJSON failed: %v", err)
+	}
+	if len(*diags) != 1 || (*diags)[0].Row != 2 || (*diags)[0].Line != 3 || (*diags)[0].Severity != SeverityError {
+		t.Errorf("unexpected NDJSON diagnostics: %+v", *diags)
+	}
+	if got := (*diags)[0].String(); got != "row 2 (line 3): name: missing name" {
+		t.Errorf("String() = %q", got)
+	}
+}
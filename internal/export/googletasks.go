@@ -0,0 +1,116 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// googleTaskList is a task list in a Google Takeout Tasks.json file.
+type googleTaskList struct {
+	Title string       `json:"title"`
+	Items []googleTask `json:"items"`
+}
+
+type googleTask struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Notes     string `json:"notes"`
+	Status    string `json:"status"`
+	Due       string `json:"due"`
+	Completed string `json:"completed"`
+	Updated   string `json:"updated"`
+	Created   string `json:"created"`
+	Parent    string `json:"parent"`
+	Deleted   bool   `json:"deleted"`
+	Links     []struct {
+		Link string `json:"link"`
+	} `json:"links"`
+}
+
+// ImportGoogleTasks reads the Tasks.json file from a Google Takeout
+// archive. Each task list becomes a list. Google Tasks has no priorities
+// and its due dates carry no time, so reminders are due at midnight on
+// the due day in the zone set with WithLocation, or local time. Subtasks
+// name their parent in their notes, the first link is the URL, and
+// deleted tasks are skipped.
+func ImportGoogleTasks(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	var takeout struct {
+		Items []googleTaskList `json:"items"`
+	}
+	if err := json.NewDecoder(r).Decode(&takeout); err != nil {
+		return nil, fmt.Errorf("failed to parse Google Tasks JSON: %w", err)
+	}
+
+	var reminders []*reminder.Reminder
+	for _, list := range takeout.Items {
+		titles := make(map[string]string, len(list.Items))
+		for _, t := range list.Items {
+			titles[t.ID] = t.Title
+		}
+		for _, t := range list.Items {
+			if t.Deleted {
+				continue
+			}
+			task, err := fromGoogleTask(t, loc)
+			if err != nil {
+				return nil, fmt.Errorf("task %q: %w", t.Title, err)
+			}
+			task.ListName = list.Title
+			if parent, ok := titles[t.Parent]; ok {
+				task.Body = strings.TrimSpace("Subtask of: " + parent + "\n" + task.Body)
+			}
+			reminders = append(reminders, task)
+		}
+	}
+	return reminders, nil
+}
+
+func fromGoogleTask(t googleTask, loc *time.Location) (*reminder.Reminder, error) {
+	r := &reminder.Reminder{
+		Name:      strings.TrimSpace(t.Title),
+		Body:      t.Notes,
+		Completed: t.Status == "completed",
+	}
+	if r.Name == "" {
+		return nil, fmt.Errorf("missing title")
+	}
+	if len(t.Links) > 0 {
+		r.URL = t.Links[0].Link
+	}
+
+	if t.Due != "" {
+		due, err := time.Parse(time.RFC3339, t.Due)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date: %q", t.Due)
+		}
+		// The date is stored as midnight UTC whatever the user's zone.
+		due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, loc)
+		r.DueDate = &due
+	}
+	for _, ts := range []struct {
+		value string
+		dst   **time.Time
+	}{
+		{t.Completed, &r.CompletionDate},
+		{t.Created, &r.CreationDate},
+		{t.Updated, &r.ModificationDate},
+	} {
+		if ts.value == "" {
+			continue
+		}
+		v, err := time.Parse(time.RFC3339, ts.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp: %q", ts.value)
+		}
+		*ts.dst = &v
+	}
+	return r, nil
+}
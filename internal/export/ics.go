@@ -0,0 +1,484 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+const (
+	icsDateTime    = "20060102T150405"
+	icsDateTimeUTC = "20060102T150405Z"
+	icsDate        = "20060102"
+	icsLineLimit   = 75
+)
+
+// ExportICS writes reminders as an RFC 5545 calendar of VTODO components.
+// The list goes in CATEGORIES, the flag in X-APPLE-FLAGGED and the alert
+// time in a VALARM. Times are written in UTC, except the due date of a
+// repeating reminder: that is a floating time in the zone set with
+// WithLocation (or the local zone), so the series keeps its time of day
+// across daylight saving changes.
+func ExportICS(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
+	o := newOptions(opts)
+	iw := &icsWriter{w: bufio.NewWriter(w)}
+	now := time.Now()
+
+	iw.line("BEGIN", "VCALENDAR")
+	iw.line("VERSION", "2.0")
+	iw.line("PRODID", "-//rem//rem CLI//EN")
+	for i, r := range reminders {
+		iw.line("BEGIN", "VTODO")
+		uid := r.ID
+		if uid == "" {
+			// The index keeps reminders without an ID apart on import.
+			uid = fmt.Sprintf("rem-%d-%d@rem", now.UnixNano(), i)
+		}
+		iw.line("UID", escapeICSText(uid))
+		iw.line("DTSTAMP", formatICSTime(now))
+		if r.CreationDate != nil {
+			iw.line("CREATED", formatICSTime(*r.CreationDate))
+		}
+		if r.ModificationDate != nil {
+			iw.line("LAST-MODIFIED", formatICSTime(*r.ModificationDate))
+		}
+		iw.line("SUMMARY", escapeICSText(r.Name))
+		if r.Body != "" {
+			iw.line("DESCRIPTION", escapeICSText(r.Body))
+		}
+		switch {
+		case r.DueDate != nil && r.Recurrence != nil:
+			iw.line("DUE", r.DueDate.In(o.location()).Format(icsDateTime))
+		case r.DueDate != nil:
+			iw.line("DUE", formatICSTime(*r.DueDate))
+		}
+		if r.Recurrence != nil {
+			iw.line("RRULE", r.Recurrence.RRule())
+		}
+		if r.Completed {
+			iw.line("STATUS", "COMPLETED")
+			if r.CompletionDate != nil {
+				iw.line("COMPLETED", formatICSTime(*r.CompletionDate))
+			}
+		} else {
+			iw.line("STATUS", "NEEDS-ACTION")
+		}
+		if r.Priority != reminder.PriorityNone {
+			iw.line("PRIORITY", strconv.Itoa(int(r.Priority)))
+		}
+		if r.URL != "" {
+			iw.line("URL", r.URL)
+		}
+		if r.Flagged {
+			iw.line("X-APPLE-FLAGGED", "TRUE")
+		}
+		if r.ListName != "" {
+			iw.line("CATEGORIES", escapeICSText(r.ListName))
+		}
+		if r.RemindMeDate != nil {
+			iw.line("BEGIN", "VALARM")
+			iw.line("ACTION", "DISPLAY")
+			iw.line("DESCRIPTION", escapeICSText(r.Name))
+			iw.line("TRIGGER;VALUE=DATE-TIME", formatICSTime(*r.RemindMeDate))
+			iw.line("END", "VALARM")
+		}
+		iw.line("END", "VTODO")
+	}
+	iw.line("END", "VCALENDAR")
+
+	if iw.err != nil {
+		return fmt.Errorf("failed to write ICS: %w", iw.err)
+	}
+	if err := iw.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write ICS: %w", err)
+	}
+	return nil
+}
+
+// icsWriter writes content lines folded at 75 octets, remembering the
+// first error.
+type icsWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (iw *icsWriter) line(name, value string) {
+	if iw.err != nil {
+		return
+	}
+	line := name + ":" + value
+	// Continuation lines start with a space, which counts toward the limit.
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		// Don't split a UTF-8 sequence.
+		for cut > 1 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		if _, iw.err = iw.w.WriteString(line[:cut] + "\r\n "); iw.err != nil {
+			return
+		}
+		line = line[cut:]
+		limit = icsLineLimit - 1
+	}
+	_, iw.err = iw.w.WriteString(line + "\r\n")
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format(icsDateTimeUTC)
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// unescapeICSText reverses escapeICSText.
+func unescapeICSText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// icsProperty is one unfolded content line.
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ImportICS reads the VTODO components of an RFC 5545 calendar. Other
+// components are skipped. Times may be UTC, floating (read in the zone set
+// with WithLocation, or the local zone) or carry a TZID; an alarm is read
+// from its absolute or DUE-relative TRIGGER.
+func ImportICS(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	props, err := readICSProperties(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		reminders []*reminder.Reminder
+		current   *reminder.Reminder
+		depth     []string
+		trigger   *icsProperty
+	)
+	for i := range props {
+		p := &props[i]
+		switch p.name {
+		case "BEGIN":
+			depth = append(depth, strings.ToUpper(p.value))
+			if strings.EqualFold(p.value, "VTODO") {
+				current = &reminder.Reminder{}
+				trigger = nil
+			}
+			continue
+		case "END":
+			if len(depth) == 0 {
+				return nil, fmt.Errorf("failed to parse ICS: unexpected END:%s", p.value)
+			}
+			depth = depth[:len(depth)-1]
+			if strings.EqualFold(p.value, "VTODO") && current != nil {
+				if trigger != nil {
+					current.RemindMeDate = alarmTime(trigger, current.DueDate, loc)
+				}
+				reminders = append(reminders, current)
+				current = nil
+			}
+			continue
+		}
+		if current == nil || len(depth) == 0 {
+			continue
+		}
+
+		if depth[len(depth)-1] == "VALARM" {
+			if p.name == "TRIGGER" {
+				trigger = p
+			}
+			continue
+		}
+		if depth[len(depth)-1] != "VTODO" {
+			continue
+		}
+
+		switch p.name {
+		case "UID":
+			current.ID = unescapeICSText(p.value)
+		case "SUMMARY":
+			current.Name = unescapeICSText(p.value)
+		case "DESCRIPTION":
+			current.Body = unescapeICSText(p.value)
+		case "URL":
+			current.URL = p.value
+		case "CATEGORIES":
+			if current.ListName == "" {
+				current.ListName = unescapeICSText(splitICSList(p.value)[0])
+			}
+		case "PRIORITY":
+			n, err := strconv.Atoi(strings.TrimSpace(p.value))
+			if err == nil {
+				current.Priority = icsPriority(n)
+			}
+		case "STATUS":
+			current.Completed = strings.EqualFold(p.value, "COMPLETED")
+		case "X-APPLE-FLAGGED":
+			current.Flagged = strings.EqualFold(p.value, "TRUE")
+		case "DUE":
+			if t, err := parseICSTime(p, loc); err == nil {
+				current.DueDate = &t
+			}
+		case "COMPLETED":
+			if t, err := parseICSTime(p, loc); err == nil {
+				current.CompletionDate = &t
+				current.Completed = true
+			}
+		case "CREATED":
+			if t, err := parseICSTime(p, loc); err == nil {
+				current.CreationDate = &t
+			}
+		case "LAST-MODIFIED":
+			if t, err := parseICSTime(p, loc); err == nil {
+				current.ModificationDate = &t
+			}
+		case "RRULE":
+			recLoc := loc
+			if current.DueDate != nil {
+				recLoc = current.DueDate.Location()
+			}
+			if rec, err := reminder.ParseRRule(p.value, recLoc); err == nil {
+				current.Recurrence = rec
+			}
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("failed to parse ICS: VTODO %q is not terminated", current.Name)
+	}
+	return reminders, nil
+}
+
+// readICSProperties unfolds and splits the content lines of r.
+func readICSProperties(r io.Reader) ([]icsProperty, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ICS: %w", err)
+	}
+
+	props := make([]icsProperty, 0, len(lines))
+	for n, line := range lines {
+		p, err := parseICSLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ICS line %d: %w", n+1, err)
+		}
+		props = append(props, p)
+	}
+	return props, nil
+}
+
+// parseICSLine splits "NAME;PARAM=value:VALUE", allowing quoted parameter
+// values to contain ':' and ';'.
+func parseICSLine(line string) (icsProperty, error) {
+	inQuotes := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ':':
+			if !inQuotes {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return icsProperty{}, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	head := line[:colon]
+	p := icsProperty{value: line[colon+1:], params: make(map[string]string)}
+	parts := splitOutsideQuotes(head, ';')
+	p.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, nil
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case sep:
+			if !inQuotes {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// splitICSList splits a comma-separated value, honoring "\," escapes.
+func splitICSList(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseICSTime reads a DATE or DATE-TIME value: UTC ("...Z"), with a TZID
+// parameter, or floating in loc. UTC values are moved to loc, so that a
+// series read from them repeats at the same local time.
+func parseICSTime(p *icsProperty, loc *time.Location) (time.Time, error) {
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	value := strings.TrimSpace(p.value)
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse(icsDateTimeUTC, value)
+		return t.In(loc), err
+	case len(value) == len(icsDate):
+		return time.ParseInLocation(icsDate, value, loc)
+	default:
+		return time.ParseInLocation(icsDateTime, value, loc)
+	}
+}
+
+// alarmTime resolves a VALARM TRIGGER: an absolute DATE-TIME, or a
+// duration relative to the due date such as "-PT15M".
+func alarmTime(p *icsProperty, due *time.Time, loc *time.Location) *time.Time {
+	if strings.EqualFold(p.params["VALUE"], "DATE-TIME") {
+		t, err := parseICSTime(p, loc)
+		if err != nil {
+			return nil
+		}
+		return &t
+	}
+	if due == nil || strings.EqualFold(p.params["RELATED"], "END") {
+		return nil
+	}
+	d, err := parseICSDuration(p.value)
+	if err != nil {
+		return nil
+	}
+	t := due.Add(d)
+	return &t
+}
+
+// parseICSDuration reads an RFC 5545 duration like "-PT15M" or "P1DT2H".
+func parseICSDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	rest, ok := strings.CutPrefix(strings.ToUpper(s), "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var total time.Duration
+	inTime := false
+	num := ""
+	for _, c := range rest {
+		switch {
+		case c == 'T':
+			inTime = true
+		case c >= '0' && c <= '9':
+			num += string(c)
+		default:
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			num = ""
+			switch {
+			case c == 'W' && !inTime:
+				total += time.Duration(n) * 7 * 24 * time.Hour
+			case c == 'D' && !inTime:
+				total += time.Duration(n) * 24 * time.Hour
+			case c == 'H' && inTime:
+				total += time.Duration(n) * time.Hour
+			case c == 'M' && inTime:
+				total += time.Duration(n) * time.Minute
+			case c == 'S' && inTime:
+				total += time.Duration(n) * time.Second
+			default:
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return sign * total, nil
+}
+
+// icsPriority maps an RFC 5545 priority (1 highest to 9 lowest, 0 none)
+// onto the three reminder levels.
+func icsPriority(n int) reminder.Priority {
+	switch {
+	case n >= 1 && n <= 4:
+		return reminder.PriorityHigh
+	case n == 5:
+		return reminder.PriorityMedium
+	case n >= 6 && n <= 9:
+		return reminder.PriorityLow
+	default:
+		return reminder.PriorityNone
+	}
+}
@@ -25,43 +25,116 @@ type JSONReminder struct {
 	Flagged          bool    `json:"flagged"`
 	Completed        bool    `json:"completed"`
 	URL              string  `json:"url,omitempty"`
+	Recurrence       string  `json:"recurrence,omitempty"`
 }
 
+// timeFormat is the default timestamp layout: local time without an offset.
 const timeFormat = "2006-01-02T15:04:05"
 
-func formatTimePtr(t *time.Time) *string {
+// Option configures how reminders are exported.
+type Option func(*options)
+
+type options struct {
+	rfc3339 bool
+	loc     *time.Location
+	csv     *CSVMapping
+	report  func(Diagnostic)
+}
+
+// WithRFC3339 writes timestamps in RFC 3339 with their UTC offset
+// ("2026-03-01T10:00:00+01:00") instead of the offset-less default.
+func WithRFC3339() Option {
+	return func(o *options) { o.rfc3339 = true }
+}
+
+// WithLocation converts timestamps to loc before writing them.
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) { o.loc = loc }
+}
+
+// WithCSVMapping reads CSV imports with the given columns, date layouts,
+// delimiter and encoding.
+func WithCSVMapping(m *CSVMapping) Option {
+	return func(o *options) { o.csv = m }
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// location returns the zone set with WithLocation, or the local zone.
+func (o options) location() *time.Location {
+	if o.loc != nil {
+		return o.loc
+	}
+	return time.Local
+}
+
+func (o options) formatTime(t time.Time) string {
+	t = t.In(o.location())
+	if o.rfc3339 {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format(timeFormat)
+}
+
+func (o options) formatTimePtr(t *time.Time) *string {
 	if t == nil {
 		return nil
 	}
-	s := t.Format(timeFormat)
+	s := o.formatTime(*t)
 	return &s
 }
 
+// parseTime reads an exported timestamp: RFC 3339, or the offset-less
+// default layout in loc. Timestamps with an offset are moved to loc, so
+// they are shown and exported like any other.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc), nil
+	}
+	return time.ParseInLocation(timeFormat, s, loc)
+}
+
 // ToJSON converts a reminder to its JSON representation.
-func ToJSON(r *reminder.Reminder) JSONReminder {
+func ToJSON(r *reminder.Reminder, opts ...Option) JSONReminder {
+	o := newOptions(opts)
 	return JSONReminder{
 		ID:               r.ID,
 		Name:             r.Name,
 		Body:             r.Body,
 		ListName:         r.ListName,
-		DueDate:          formatTimePtr(r.DueDate),
-		RemindMeDate:     formatTimePtr(r.RemindMeDate),
-		CompletionDate:   formatTimePtr(r.CompletionDate),
-		CreationDate:     formatTimePtr(r.CreationDate),
-		ModificationDate: formatTimePtr(r.ModificationDate),
+		DueDate:          o.formatTimePtr(r.DueDate),
+		RemindMeDate:     o.formatTimePtr(r.RemindMeDate),
+		CompletionDate:   o.formatTimePtr(r.CompletionDate),
+		CreationDate:     o.formatTimePtr(r.CreationDate),
+		ModificationDate: o.formatTimePtr(r.ModificationDate),
 		Priority:         int(r.Priority),
 		PriorityLabel:    r.Priority.String(),
 		Flagged:          r.Flagged,
 		Completed:        r.Completed,
 		URL:              r.URL,
+		Recurrence:       formatRecurrence(r.Recurrence),
 	}
 }
 
+// formatRecurrence returns the RRULE for a recurrence, or "" if nil.
+func formatRecurrence(rec *reminder.Recurrence) string {
+	if rec == nil {
+		return ""
+	}
+	return rec.RRule()
+}
+
 // ExportJSON writes reminders as JSON to the writer.
-func ExportJSON(w io.Writer, reminders []*reminder.Reminder) error {
+func ExportJSON(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
 	jsonReminders := make([]JSONReminder, 0, len(reminders))
 	for _, r := range reminders {
-		jsonReminders = append(jsonReminders, ToJSON(r))
+		jsonReminders = append(jsonReminders, ToJSON(r, opts...))
 	}
 
 	encoder := json.NewEncoder(w)
@@ -69,8 +142,11 @@ func ExportJSON(w io.Writer, reminders []*reminder.Reminder) error {
 	return encoder.Encode(jsonReminders)
 }
 
-// ImportJSON reads reminders from a JSON reader.
-func ImportJSON(r io.Reader) ([]*reminder.Reminder, error) {
+// ImportJSON reads reminders from a JSON reader. Timestamps may be RFC 3339
+// or the offset-less default layout.
+func ImportJSON(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	v := newValidator(o)
 	var jsonReminders []JSONReminder
 	decoder := json.NewDecoder(r)
 	if err := decoder.Decode(&jsonReminders); err != nil {
@@ -79,31 +155,45 @@ func ImportJSON(r io.Reader) ([]*reminder.Reminder, error) {
 
 	reminders := make([]*reminder.Reminder, 0, len(jsonReminders))
 	for _, jr := range jsonReminders {
-		rem := &reminder.Reminder{
-			Name:      jr.Name,
-			Body:      jr.Body,
-			ListName:  jr.ListName,
-			Priority:  reminder.Priority(jr.Priority),
-			Flagged:   jr.Flagged,
-			Completed: jr.Completed,
-			URL:       jr.URL,
-		}
-
-		if jr.DueDate != nil {
-			t, err := time.ParseInLocation(timeFormat, *jr.DueDate, time.Now().Location())
-			if err == nil {
-				rem.DueDate = &t
-			}
-		}
-		if jr.RemindMeDate != nil {
-			t, err := time.ParseInLocation(timeFormat, *jr.RemindMeDate, time.Now().Location())
-			if err == nil {
-				rem.RemindMeDate = &t
-			}
-		}
-
-		reminders = append(reminders, rem)
+		v.next(0)
+		reminders = append(reminders, fromJSON(jr, v, o.location()))
 	}
 
 	return reminders, nil
 }
+
+// fromJSON converts a JSON reminder back to a reminder, reading offset-less
+// timestamps in loc. Timestamps, priorities and recurrences that don't
+// parse are reported to v and left unset.
+func fromJSON(jr JSONReminder, v *validator, loc *time.Location) *reminder.Reminder {
+	parse := func(s string) (time.Time, error) { return parseTime(s, loc) }
+	v.name(jr.Name)
+	rem := &reminder.Reminder{
+		ID:        jr.ID,
+		Name:      jr.Name,
+		Body:      jr.Body,
+		ListName:  jr.ListName,
+		Flagged:   jr.Flagged,
+		Completed: jr.Completed,
+		URL:       jr.URL,
+	}
+
+	if jr.DueDate != nil {
+		rem.DueDate = v.date("due_date", *jr.DueDate, parse)
+	}
+	if jr.RemindMeDate != nil {
+		rem.RemindMeDate = v.date("remind_me_date", *jr.RemindMeDate, parse)
+	}
+	if jr.CompletionDate != nil && jr.Completed {
+		rem.CompletionDate = v.date("completion_date", *jr.CompletionDate, parse)
+	}
+	if jr.CreationDate != nil {
+		rem.CreationDate = v.date("creation_date", *jr.CreationDate, parse)
+	}
+	if jr.ModificationDate != nil {
+		rem.ModificationDate = v.date("modification_date", *jr.ModificationDate, parse)
+	}
+	rem.Priority = v.priorityNumber(jr.Priority)
+	rem.Recurrence = v.recurrence(jr.Recurrence, loc)
+	return rem
+}
@@ -0,0 +1,364 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+const (
+	markdownDate     = "2006-01-02"
+	markdownDateTime = "2006-01-02 15:04"
+	markdownIndent   = "    "
+)
+
+// Obsidian Tasks signifiers. Priorities take no value; the others are
+// followed by a date or, for recurrence, a rule.
+const (
+	mdDue        = "📅"
+	mdScheduled  = "⏳"
+	mdCreated    = "➕"
+	mdDone       = "✅"
+	mdRecurrence = "🔁"
+	mdHigh       = "⏫"
+	mdMedium     = "🔼"
+	mdLow        = "🔽"
+)
+
+var markdownSignifiers = map[rune]string{
+	'📅': mdDue, '📆': mdDue, '🗓': mdDue,
+	'⏳': mdScheduled,
+	'➕': mdCreated,
+	'✅': mdDone,
+	'🔁': mdRecurrence,
+	'🔺': mdHigh, '⏫': mdHigh,
+	'🔼': mdMedium,
+	'🔽': mdLow, '⏬': mdLow,
+	// Understood by Obsidian Tasks but not by rem.
+	'🛫': "", '❌': "", '🆔': "", '⛔': "",
+}
+
+var (
+	markdownHeading = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+	markdownTask    = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+\[(.)\]\s+(.*)$`)
+	markdownLink    = regexp.MustCompile(`^\[(.+)\]\((\S+)\)$`)
+)
+
+// ExportMarkdown writes reminders as Markdown task lists with a "## List"
+// section per list, in the order the lists first appear:
+//
+//	## Work
+//
+//	- [ ] [Review PR](https://github.com/org/repo/pull/1) ⏫ 📅 2026-02-15 14:30
+//	    Check the auth changes
+//	- [x] Ship release ✅ 2026-02-01
+//
+// Dates and priorities use the Obsidian Tasks emoji, the URL links the
+// title and the notes are indented under the task.
+func ExportMarkdown(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
+	o := newOptions(opts)
+	bw := bufio.NewWriter(w)
+
+	lists, byList := groupByList(reminders)
+	for i, list := range lists {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		if list != "" {
+			fmt.Fprintf(bw, "## %s\n\n", list)
+		}
+		for _, r := range byList[list] {
+			writeMarkdownTask(bw, r, o)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeMarkdownTask(w io.Writer, r *reminder.Reminder, o options) {
+	date := func(t time.Time) string {
+		t = t.In(o.location())
+		if t.Hour() == 0 && t.Minute() == 0 {
+			return t.Format(markdownDate)
+		}
+		return t.Format(markdownDateTime)
+	}
+
+	box := "[ ]"
+	if r.Completed {
+		box = "[x]"
+	}
+	title := strings.Join(strings.Fields(r.Name), " ")
+	if r.URL != "" {
+		title = "[" + title + "](" + r.URL + ")"
+	}
+	parts := []string{"-", box, title}
+
+	switch {
+	case r.Priority >= 1 && r.Priority <= 4:
+		parts = append(parts, mdHigh)
+	case r.Priority == 5:
+		parts = append(parts, mdMedium)
+	case r.Priority >= 6 && r.Priority <= 9:
+		parts = append(parts, mdLow)
+	}
+	if r.Recurrence != nil {
+		parts = append(parts, mdRecurrence, recurrenceText(r.Recurrence))
+	}
+	if r.CreationDate != nil {
+		parts = append(parts, mdCreated, date(*r.CreationDate))
+	}
+	if r.RemindMeDate != nil {
+		parts = append(parts, mdScheduled, date(*r.RemindMeDate))
+	}
+	if r.DueDate != nil {
+		parts = append(parts, mdDue, date(*r.DueDate))
+	}
+	if r.Completed && r.CompletionDate != nil {
+		parts = append(parts, mdDone, date(*r.CompletionDate))
+	}
+	fmt.Fprintln(w, strings.Join(parts, " "))
+
+	if r.Body == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(r.Body, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			fmt.Fprintln(w)
+		} else {
+			fmt.Fprintln(w, markdownIndent+line)
+		}
+	}
+}
+
+// groupByList groups reminders by list, returning the list names in the
+// order they first appear.
+func groupByList(reminders []*reminder.Reminder) ([]string, map[string][]*reminder.Reminder) {
+	var lists []string
+	byList := map[string][]*reminder.Reminder{}
+	for _, r := range reminders {
+		if _, ok := byList[r.ListName]; !ok {
+			lists = append(lists, r.ListName)
+		}
+		byList[r.ListName] = append(byList[r.ListName], r)
+	}
+	return lists, byList
+}
+
+// recurrenceText writes a recurrence in words when they read back as the
+// same rule, and as an RRULE otherwise.
+func recurrenceText(rule *reminder.Recurrence) string {
+	text := rule.String()
+	if parsed, err := parser.ParseRepeat(strings.ToLower(text)); err == nil && parsed.RRule() == rule.RRule() {
+		return text
+	}
+	return rule.RRule()
+}
+
+// ImportMarkdown reads Markdown task lists: GitHub "- [ ]" and "- [x]"
+// checkboxes with Obsidian Tasks emoji for dates, priority and recurrence.
+// Headings name the list of the tasks under them, and text indented under
+// a task becomes its notes. Other lines are ignored.
+func ImportMarkdown(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	var (
+		reminders []*reminder.Reminder
+		list      string
+		current   *reminder.Reminder
+		indent    int
+		notes     []string
+		blanks    int
+	)
+	finish := func() {
+		if current != nil {
+			current.Body = strings.Join(notes, "\n")
+		}
+		current, notes, blanks = nil, nil, 0
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if m := markdownTask.FindStringSubmatch(line); m != nil {
+			finish()
+			task, err := parseMarkdownTask(m[3], loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			task.Completed = m[2] == "x" || m[2] == "X"
+			task.ListName = list
+			reminders = append(reminders, task)
+			current, indent = task, indentWidth(m[1])
+			continue
+		}
+
+		if current != nil {
+			if line == "" {
+				blanks++
+				continue
+			}
+			if indentWidth(line) > indent {
+				if len(notes) > 0 {
+					for ; blanks > 0; blanks-- {
+						notes = append(notes, "")
+					}
+				}
+				blanks = 0
+				notes = append(notes, trimIndent(line, indent+len(markdownIndent)))
+				continue
+			}
+			finish()
+		}
+
+		if m := markdownHeading.FindStringSubmatch(line); m != nil {
+			list = m[1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Markdown: %w", err)
+	}
+	finish()
+	return reminders, nil
+}
+
+// parseMarkdownTask reads the text after a task's checkbox.
+func parseMarkdownTask(text string, loc *time.Location) (*reminder.Reminder, error) {
+	r := &reminder.Reminder{}
+	title, fields := splitMarkdownSignifiers(text)
+	if m := markdownLink.FindStringSubmatch(title); m != nil {
+		title, r.URL = m[1], m[2]
+	}
+	if title == "" {
+		return nil, fmt.Errorf("missing task title")
+	}
+	r.Name = title
+
+	for _, f := range fields {
+		switch f.kind {
+		case mdHigh:
+			r.Priority = reminder.PriorityHigh
+		case mdMedium:
+			r.Priority = reminder.PriorityMedium
+		case mdLow:
+			r.Priority = reminder.PriorityLow
+		case mdRecurrence:
+			rule, err := parseRecurrenceText(f.value, loc)
+			if err != nil {
+				return nil, err
+			}
+			r.Recurrence = rule
+		case mdDue, mdScheduled, mdCreated, mdDone:
+			t, err := time.ParseInLocation(markdownDateTime, f.value, loc)
+			if err != nil {
+				t, err = time.ParseInLocation(markdownDate, f.value, loc)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid date after %s: %q", f.kind, f.value)
+			}
+			switch f.kind {
+			case mdDue:
+				r.DueDate = &t
+			case mdScheduled:
+				r.RemindMeDate = &t
+			case mdCreated:
+				r.CreationDate = &t
+			case mdDone:
+				r.CompletionDate = &t
+			}
+		}
+	}
+	return r, nil
+}
+
+type markdownField struct {
+	kind  string
+	value string
+}
+
+// splitMarkdownSignifiers splits task text at each signifier emoji into
+// the title and the fields that follow it.
+func splitMarkdownSignifiers(text string) (string, []markdownField) {
+	var (
+		title  string
+		fields []markdownField
+		b      strings.Builder
+		kind   *string
+	)
+	flush := func() {
+		value := strings.TrimSpace(b.String())
+		b.Reset()
+		if kind == nil {
+			title = value
+		} else if *kind != "" {
+			fields = append(fields, markdownField{*kind, value})
+		}
+	}
+	for _, c := range text {
+		if c == '\uFE0F' { // variation selector
+			continue
+		}
+		if k, ok := markdownSignifiers[c]; ok {
+			flush()
+			kind = &k
+			continue
+		}
+		b.WriteRune(c)
+	}
+	flush()
+	return title, fields
+}
+
+// parseRecurrenceText reads a recurrence written by recurrenceText, or by
+// hand in the Obsidian Tasks style ("every week on Monday").
+func parseRecurrenceText(s string, loc *time.Location) (*reminder.Recurrence, error) {
+	if strings.Contains(strings.ToUpper(s), "FREQ=") {
+		return reminder.ParseRRule(s, loc)
+	}
+	s = strings.TrimSuffix(strings.ToLower(s), " when done")
+	return parser.ParseRepeat(s)
+}
+
+// indentWidth measures leading whitespace, counting a tab as four spaces.
+func indentWidth(s string) int {
+	n := 0
+	for _, c := range s {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// trimIndent removes up to width columns of leading whitespace.
+func trimIndent(s string, width int) string {
+	n := 0
+	for i, c := range s {
+		switch {
+		case n >= width:
+			return s[i:]
+		case c == ' ':
+			n++
+		case c == '\t':
+			n += 4
+		default:
+			return s[i:]
+		}
+	}
+	return ""
+}
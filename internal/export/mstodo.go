@@ -0,0 +1,193 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+var (
+	htmlBreak = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|li)>`)
+	htmlTag   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// msTodoList is a Microsoft To Do list as returned by the Graph API, with
+// its tasks inlined.
+type msTodoList struct {
+	DisplayName string       `json:"displayName"`
+	Tasks       []msTodoTask `json:"tasks"`
+}
+
+type msTodoTask struct {
+	Title      string `json:"title"`
+	Importance string `json:"importance"`
+	Status     string `json:"status"`
+	Body       struct {
+		Content     string `json:"content"`
+		ContentType string `json:"contentType"`
+	} `json:"body"`
+	DueDateTime          *msDateTime `json:"dueDateTime"`
+	ReminderDateTime     *msDateTime `json:"reminderDateTime"`
+	CompletedDateTime    *msDateTime `json:"completedDateTime"`
+	CreatedDateTime      string      `json:"createdDateTime"`
+	LastModifiedDateTime string      `json:"lastModifiedDateTime"`
+	Categories           []string    `json:"categories"`
+	LinkedResources      []struct {
+		WebURL string `json:"webUrl"`
+	} `json:"linkedResources"`
+}
+
+// msDateTime is a Graph dateTimeTimeZone: a wall time and the zone it is in.
+type msDateTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+// ImportMSToDo reads Microsoft To Do lists exported through the Graph API.
+// The file holds either an array of lists, each with a displayName and its
+// tasks, or the {"value": [...]} response of the list or tasks endpoint;
+// tasks read without a list go to the default list. Importance high and
+// low map to the same priorities and normal to none. To Do due dates have
+// no time, so reminders are due at midnight on the due day. HTML notes are
+// reduced to text, categories become #tags and the first linked resource
+// is the URL.
+func ImportMSToDo(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Microsoft To Do JSON: %w", err)
+	}
+	var lists []msTodoList
+	var page struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &page); err == nil && page.Value != nil {
+		data = page.Value
+	}
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse Microsoft To Do JSON: %w", err)
+	}
+	if len(raw) > 0 && raw[0]["title"] != nil {
+		var tasks []msTodoTask
+		if err := json.Unmarshal(data, &tasks); err != nil {
+			return nil, fmt.Errorf("failed to parse Microsoft To Do JSON: %w", err)
+		}
+		lists = []msTodoList{{Tasks: tasks}}
+	} else if err := json.Unmarshal(data, &lists); err != nil {
+		return nil, fmt.Errorf("failed to parse Microsoft To Do JSON: %w", err)
+	}
+
+	var reminders []*reminder.Reminder
+	for _, list := range lists {
+		for _, t := range list.Tasks {
+			task, err := fromMSToDo(t, loc)
+			if err != nil {
+				return nil, fmt.Errorf("task %q: %w", t.Title, err)
+			}
+			task.ListName = list.DisplayName
+			reminders = append(reminders, task)
+		}
+	}
+	return reminders, nil
+}
+
+func fromMSToDo(t msTodoTask, loc *time.Location) (*reminder.Reminder, error) {
+	r := &reminder.Reminder{
+		Name:      strings.TrimSpace(t.Title),
+		Body:      strings.TrimSpace(t.Body.Content),
+		Completed: t.Status == "completed",
+	}
+	if r.Name == "" {
+		return nil, fmt.Errorf("missing title")
+	}
+	if strings.EqualFold(t.Body.ContentType, "html") {
+		text := htmlTag.ReplaceAllString(htmlBreak.ReplaceAllString(r.Body, "\n"), "")
+		r.Body = strings.TrimSpace(html.UnescapeString(text))
+	}
+	switch strings.ToLower(t.Importance) {
+	case "high":
+		r.Priority = reminder.PriorityHigh
+	case "low":
+		r.Priority = reminder.PriorityLow
+	}
+	if len(t.LinkedResources) > 0 {
+		r.URL = t.LinkedResources[0].WebURL
+	}
+
+	if t.DueDateTime != nil {
+		due, err := t.DueDateTime.parse()
+		if err != nil {
+			return nil, err
+		}
+		due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, loc)
+		r.DueDate = &due
+	}
+	var err error
+	if r.RemindMeDate, err = t.ReminderDateTime.parseOptional(); err != nil {
+		return nil, err
+	}
+	if r.CompletionDate, err = t.CompletedDateTime.parseOptional(); err != nil {
+		return nil, err
+	}
+	for _, ts := range []struct {
+		value string
+		dst   **time.Time
+	}{
+		{t.CreatedDateTime, &r.CreationDate},
+		{t.LastModifiedDateTime, &r.ModificationDate},
+	} {
+		if ts.value == "" {
+			continue
+		}
+		v, err := time.Parse(time.RFC3339Nano, ts.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp: %q", ts.value)
+		}
+		*ts.dst = &v
+	}
+
+	var tags []string
+	for _, c := range t.Categories {
+		c = strings.Join(strings.Fields(c), "-")
+		if reminder.IsTag("#" + c) {
+			tags = append(tags, c)
+		}
+	}
+	r.SetTags(tags)
+	return r, nil
+}
+
+// parse reads the wall time in its zone. Zones Go doesn't know, such as
+// Windows names, are read as UTC.
+func (d *msDateTime) parse() (time.Time, error) {
+	loc, err := parser.LoadLocation(d.TimeZone)
+	if err != nil || d.TimeZone == "" {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04:05.9999999", d.DateTime, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %q", d.DateTime)
+	}
+	return t, nil
+}
+
+func (d *msDateTime) parseOptional() (*time.Time, error) {
+	if d == nil {
+		return nil, nil
+	}
+	t, err := d.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// ExportNDJSON writes reminders as newline-delimited JSON: one compact
+// JSONReminder per line.
+func ExportNDJSON(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+	for _, r := range reminders {
+		if err := encoder.Encode(ToJSON(r, opts...)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// NDJSONDecoder reads reminders from newline-delimited JSON one line at a
+// time, so the input never has to fit in memory.
+type NDJSONDecoder struct {
+	r    *bufio.Reader
+	line int
+	v    *validator
+	loc  *time.Location
+}
+
+// NewNDJSONDecoder creates an NDJSONDecoder reading from r.
+func NewNDJSONDecoder(r io.Reader, opts ...Option) *NDJSONDecoder {
+	o := newOptions(opts)
+	return &NDJSONDecoder{r: bufio.NewReader(r), v: newValidator(o), loc: o.location()}
+}
+
+// Decode returns the next reminder, or io.EOF after the last one. Blank
+// lines are skipped.
+func (d *NDJSONDecoder) Decode() (*reminder.Reminder, error) {
+	for {
+		data, err := d.r.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("failed to read NDJSON: %w", err)
+		}
+		d.line++
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		var jr JSONReminder
+		if err := json.Unmarshal(data, &jr); err != nil {
+			return nil, fmt.Errorf("line %d: failed to parse JSON: %w", d.line, err)
+		}
+		d.v.next(d.line)
+		return fromJSON(jr, d.v, d.loc), nil
+	}
+}
+
+// ImportNDJSON reads all reminders from newline-delimited JSON.
+func ImportNDJSON(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	var reminders []*reminder.Reminder
+	d := NewNDJSONDecoder(r, opts...)
+	for {
+		rem, err := d.Decode()
+		if err == io.EOF {
+			return reminders, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, rem)
+	}
+}
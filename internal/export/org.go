@@ -0,0 +1,324 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+const (
+	orgDate     = "2006-01-02 Mon"
+	orgDateTime = "2006-01-02 Mon 15:04"
+	orgIndent   = "  "
+)
+
+var (
+	orgHeadline  = regexp.MustCompile(`^(\*+)\s+(?:(TODO|NEXT|WAITING|DONE|CANCELED|CANCELLED)\s+)?(?:\[#([A-Z])\]\s*)?(.*?)(?:\s+(:[\w@#%:]+:))?\s*$`)
+	orgPlanning  = regexp.MustCompile(`(CLOSED|DEADLINE|SCHEDULED):\s*([<\[][^>\]]*[>\]])`)
+	orgProperty  = regexp.MustCompile(`^:([^:\s]+):\s*(.*)$`)
+	orgTimestamp = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})(?:\s+[^\s\d.+-]+)?(?:\s+(\d{1,2}:\d{2})(?:-\d{1,2}:\d{2})?)?(?:\s+(?:\.\+|\+\+|\+)(\d+)([hdwmy]))?(?:\s+--?\d+[hdwmy])?$`)
+)
+
+var orgRepeaterUnits = map[string]reminder.Frequency{
+	"d": reminder.FrequencyDaily,
+	"w": reminder.FrequencyWeekly,
+	"m": reminder.FrequencyMonthly,
+	"y": reminder.FrequencyYearly,
+}
+
+// ExportOrg writes reminders as Org-mode entries, one top-level
+// "* TODO [#A] Title :flagged:" headline each. Completed reminders are DONE
+// with a CLOSED timestamp, the due date is the DEADLINE and the alert time
+// is SCHEDULED. A property drawer carries the rem ID (REM_ID), the list,
+// the exact priority when A, B and C can't express it, the URL, the full
+// RRULE and the created and modified times; simple rules also get a
+// repeater on the deadline. Notes follow the drawer. Times are written to
+// the minute.
+func ExportOrg(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
+	o := newOptions(opts)
+	bw := bufio.NewWriter(w)
+	stamp := func(open, close string, t time.Time, repeater string) string {
+		t = t.In(o.location())
+		layout := orgDateTime
+		if t.Hour() == 0 && t.Minute() == 0 {
+			layout = orgDate
+		}
+		s := t.Format(layout)
+		if repeater != "" {
+			s += " " + repeater
+		}
+		return open + s + close
+	}
+
+	for _, r := range reminders {
+		headline := []string{"*", "TODO"}
+		if r.Completed {
+			headline[1] = "DONE"
+		}
+		if letter := priorityLetter(r.Priority); letter != "" {
+			headline = append(headline, "[#"+letter+"]")
+		}
+		headline = append(headline, strings.Join(strings.Fields(r.Name), " "))
+		if r.Flagged {
+			headline = append(headline, ":flagged:")
+		}
+		fmt.Fprintln(bw, strings.Join(headline, " "))
+
+		var planning []string
+		if r.Completed && r.CompletionDate != nil {
+			planning = append(planning, "CLOSED: "+stamp("[", "]", *r.CompletionDate, ""))
+		}
+		if r.DueDate != nil {
+			planning = append(planning, "DEADLINE: "+stamp("<", ">", *r.DueDate, orgRepeater(r.Recurrence)))
+		}
+		if r.RemindMeDate != nil {
+			planning = append(planning, "SCHEDULED: "+stamp("<", ">", *r.RemindMeDate, ""))
+		}
+		if len(planning) > 0 {
+			fmt.Fprintln(bw, orgIndent+strings.Join(planning, " "))
+		}
+
+		var props [][2]string
+		if r.ID != "" {
+			props = append(props, [2]string{"REM_ID", r.ID})
+		}
+		if r.ListName != "" {
+			props = append(props, [2]string{"LIST", r.ListName})
+		}
+		if p := r.Priority; p != reminder.PriorityNone && p != reminder.PriorityHigh && p != reminder.PriorityMedium && p != reminder.PriorityLow {
+			props = append(props, [2]string{"REM_PRIORITY", strconv.Itoa(int(p))})
+		}
+		if r.URL != "" {
+			props = append(props, [2]string{"URL", r.URL})
+		}
+		if r.Recurrence != nil {
+			props = append(props, [2]string{"RRULE", r.Recurrence.RRule()})
+		}
+		if r.CreationDate != nil {
+			props = append(props, [2]string{"CREATED", stamp("[", "]", *r.CreationDate, "")})
+		}
+		if r.ModificationDate != nil {
+			props = append(props, [2]string{"MODIFIED", stamp("[", "]", *r.ModificationDate, "")})
+		}
+		if len(props) > 0 {
+			fmt.Fprintln(bw, orgIndent+":PROPERTIES:")
+			for _, p := range props {
+				fmt.Fprintf(bw, "%s%-10s %s\n", orgIndent, ":"+p[0]+":", p[1])
+			}
+			fmt.Fprintln(bw, orgIndent+":END:")
+		}
+
+		if r.Body != "" {
+			for _, line := range strings.Split(strings.TrimRight(r.Body, "\n"), "\n") {
+				if strings.TrimSpace(line) == "" {
+					fmt.Fprintln(bw)
+				} else {
+					fmt.Fprintln(bw, orgIndent+line)
+				}
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// orgRepeater returns the repeater cookie ("+2w") for rules Org can
+// express, or "" for rules that need the RRULE property.
+func orgRepeater(rule *reminder.Recurrence) string {
+	if rule == nil || len(rule.ByDay) > 0 || len(rule.ByMonthDay) > 0 || len(rule.ByMonth) > 0 || rule.Count > 0 || rule.Until != nil {
+		return ""
+	}
+	interval := rule.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	for unit, f := range orgRepeaterUnits {
+		if f == rule.Frequency {
+			return "+" + strconv.Itoa(interval) + unit
+		}
+	}
+	return ""
+}
+
+// ImportOrg reads Org-mode TODO entries. Headlines with a TODO, NEXT or
+// WAITING keyword are open reminders and DONE or CANCELED ones completed;
+// other headlines name the list of the entries below them unless an entry
+// has a LIST property. Text under an entry becomes its notes.
+func ImportOrg(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	var (
+		reminders []*reminder.Reminder
+		list      string
+		current   *reminder.Reminder
+		repeater  *reminder.Recurrence
+		inDrawer  bool
+		inBody    bool
+		notes     []string
+	)
+	finish := func() {
+		if current == nil {
+			return
+		}
+		if current.Recurrence == nil {
+			current.Recurrence = repeater
+		}
+		current.Body = strings.TrimRight(strings.Join(dedent(notes), "\n"), "\n")
+		current, repeater, inBody, notes = nil, nil, false, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	n := 0
+	drawerLine := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if m := orgHeadline.FindStringSubmatch(line); m != nil && !inDrawer {
+			finish()
+			if m[2] == "" {
+				list = m[4]
+				continue
+			}
+			current = &reminder.Reminder{
+				Name:      m[4],
+				ListName:  list,
+				Completed: m[2] == "DONE" || m[2] == "CANCELED" || m[2] == "CANCELLED",
+			}
+			if m[3] != "" {
+				current.Priority = priorityFromLetter(m[3][0])
+			}
+			current.Flagged = strings.Contains(m[5], ":flagged:")
+			reminders = append(reminders, current)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inDrawer && trimmed == ":END:":
+			inDrawer = false
+		case inDrawer:
+			m := orgProperty.FindStringSubmatch(trimmed)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: invalid property line %q", n, trimmed)
+			}
+			if err := setOrgProperty(current, strings.ToUpper(m[1]), m[2], loc); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+		case !inBody && trimmed == ":PROPERTIES:":
+			inDrawer, drawerLine = true, n
+		case !inBody && orgPlanning.MatchString(trimmed):
+			for _, m := range orgPlanning.FindAllStringSubmatch(trimmed, -1) {
+				t, rule, err := parseOrgTimestamp(m[2], loc)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", n, err)
+				}
+				switch m[1] {
+				case "CLOSED":
+					current.CompletionDate = &t
+				case "DEADLINE":
+					current.DueDate = &t
+					repeater = rule
+				case "SCHEDULED":
+					current.RemindMeDate = &t
+				}
+			}
+		case !inBody && trimmed == "":
+		default:
+			inBody = true
+			notes = append(notes, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Org file: %w", err)
+	}
+	if inDrawer {
+		return nil, fmt.Errorf("line %d: property drawer is never closed with :END:", drawerLine)
+	}
+	finish()
+	return reminders, nil
+}
+
+func setOrgProperty(r *reminder.Reminder, key, value string, loc *time.Location) error {
+	switch key {
+	case "REM_ID":
+		r.ID = value
+	case "LIST":
+		r.ListName = value
+	case "REM_PRIORITY":
+		p, err := strconv.Atoi(value)
+		if err != nil || p < 0 || p > 9 {
+			return fmt.Errorf("invalid priority: %q", value)
+		}
+		r.Priority = reminder.Priority(p)
+	case "URL":
+		r.URL = value
+	case "RRULE":
+		rule, err := reminder.ParseRRule(value, loc)
+		if err != nil {
+			return err
+		}
+		r.Recurrence = rule
+	case "CREATED", "MODIFIED":
+		t, _, err := parseOrgTimestamp(value, loc)
+		if err != nil {
+			return err
+		}
+		if key == "CREATED" {
+			r.CreationDate = &t
+		} else {
+			r.ModificationDate = &t
+		}
+	}
+	return nil
+}
+
+// parseOrgTimestamp reads an active or inactive timestamp such as
+// "<2026-02-15 Sun 14:30 +1w>", returning the repeater as a recurrence.
+func parseOrgTimestamp(s string, loc *time.Location) (time.Time, *reminder.Recurrence, error) {
+	inner := strings.TrimSpace(strings.Trim(s, "<>[]"))
+	m := orgTimestamp.FindStringSubmatch(inner)
+	if m == nil {
+		return time.Time{}, nil, fmt.Errorf("invalid timestamp: %q", s)
+	}
+	value, layout := m[1], "2006-01-02"
+	if m[2] != "" {
+		value, layout = value+" "+m[2], "2006-01-02 15:04"
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("invalid timestamp: %q", s)
+	}
+
+	var rule *reminder.Recurrence
+	if f, ok := orgRepeaterUnits[m[4]]; ok {
+		interval, _ := strconv.Atoi(m[3])
+		rule = &reminder.Recurrence{Frequency: f, Interval: interval}
+	}
+	return t, rule, nil
+}
+
+// dedent removes the indentation all non-blank lines share.
+func dedent(lines []string) []string {
+	width := -1
+	for _, line := range lines {
+		if line != "" && (width < 0 || indentWidth(line) < width) {
+			width = indentWidth(line)
+		}
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = trimIndent(line, width)
+	}
+	return out
+}
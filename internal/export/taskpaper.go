@@ -0,0 +1,265 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+var taskPaperTag = regexp.MustCompile(`(^|\s)@([\w-]+)(?:\(([^)]*)\))?`)
+
+// ExportTaskPaper writes reminders as a TaskPaper document with a project
+// per list:
+//
+//	Work:
+//		- Review PR @priority(1) @flag @due(2026-02-15 14:30) @id(<id>)
+//			Notes...
+//
+// Every field is a tag: @priority holds the exact priority (1-9), @due,
+// @remind, @created, @modified and @done hold dates to the minute, and
+// @repeat the recurrence. Reminders without a list come first, outside
+// any project.
+func ExportTaskPaper(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
+	o := newOptions(opts)
+	bw := bufio.NewWriter(w)
+
+	lists, byList := groupByList(reminders)
+	for i, list := range lists {
+		indent := ""
+		if list != "" {
+			if i > 0 {
+				fmt.Fprintln(bw)
+			}
+			fmt.Fprintf(bw, "%s:\n", list)
+			indent = "\t"
+		}
+		for _, r := range byList[list] {
+			writeTaskPaperTask(bw, r, indent, o)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeTaskPaperTask(w io.Writer, r *reminder.Reminder, indent string, o options) {
+	date := func(t time.Time) string {
+		t = t.In(o.location())
+		if t.Hour() == 0 && t.Minute() == 0 {
+			return t.Format(markdownDate)
+		}
+		return t.Format(markdownDateTime)
+	}
+	tag := func(name, value string) string {
+		return "@" + name + "(" + strings.ReplaceAll(value, ")", "%29") + ")"
+	}
+
+	parts := []string{"-", strings.Join(strings.Fields(r.Name), " ")}
+	if r.Priority != reminder.PriorityNone {
+		parts = append(parts, tag("priority", strconv.Itoa(int(r.Priority))))
+	}
+	if r.Flagged {
+		parts = append(parts, "@flag")
+	}
+	if r.DueDate != nil {
+		parts = append(parts, tag("due", date(*r.DueDate)))
+	}
+	if r.RemindMeDate != nil {
+		parts = append(parts, tag("remind", date(*r.RemindMeDate)))
+	}
+	if r.Recurrence != nil {
+		parts = append(parts, tag("repeat", recurrenceText(r.Recurrence)))
+	}
+	if r.URL != "" {
+		parts = append(parts, tag("url", r.URL))
+	}
+	if r.CreationDate != nil {
+		parts = append(parts, tag("created", date(*r.CreationDate)))
+	}
+	if r.ModificationDate != nil {
+		parts = append(parts, tag("modified", date(*r.ModificationDate)))
+	}
+	if r.Completed {
+		if r.CompletionDate != nil {
+			parts = append(parts, tag("done", date(*r.CompletionDate)))
+		} else {
+			parts = append(parts, "@done")
+		}
+	}
+	if r.ID != "" {
+		parts = append(parts, tag("id", r.ID))
+	}
+	fmt.Fprintln(w, indent+strings.Join(parts, " "))
+
+	if r.Body == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(r.Body, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			fmt.Fprintln(w)
+		} else {
+			fmt.Fprintln(w, indent+"\t"+line)
+		}
+	}
+}
+
+// ImportTaskPaper reads a TaskPaper document. Lines starting with "- " are
+// tasks and lines ending in ':' are projects, which name the list of the
+// tasks inside them. Lines indented under a task, other than subtasks,
+// become its notes. Tags rem doesn't know stay in the title.
+func ImportTaskPaper(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	type project struct {
+		name   string
+		indent int
+	}
+	var (
+		reminders []*reminder.Reminder
+		projects  []project
+		current   *reminder.Reminder
+		indent    int
+		notes     []string
+		blanks    int
+	)
+	finish := func() {
+		if current != nil {
+			current.Body = strings.Join(notes, "\n")
+		}
+		current, notes, blanks = nil, nil, 0
+	}
+	// enclosing drops the projects a line at width is no longer inside.
+	enclosing := func(width int) {
+		for len(projects) > 0 && projects[len(projects)-1].indent >= width {
+			projects = projects[:len(projects)-1]
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		width := indentWidth(line)
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			finish()
+			task, err := parseTaskPaperTask(strings.TrimPrefix(trimmed, "-"), loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			enclosing(width)
+			if len(projects) > 0 {
+				task.ListName = projects[len(projects)-1].name
+			}
+			reminders = append(reminders, task)
+			current, indent = task, width
+			continue
+		}
+
+		if current != nil {
+			if trimmed == "" {
+				blanks++
+				continue
+			}
+			if width > indent {
+				if len(notes) > 0 {
+					for ; blanks > 0; blanks-- {
+						notes = append(notes, "")
+					}
+				}
+				blanks = 0
+				notes = append(notes, trimIndent(line, indent+4))
+				continue
+			}
+			finish()
+		}
+
+		if name, ok := strings.CutSuffix(trimmed, ":"); ok && name != "" {
+			enclosing(width)
+			projects = append(projects, project{name, width})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read TaskPaper file: %w", err)
+	}
+	finish()
+	return reminders, nil
+}
+
+// parseTaskPaperTask reads the text of a task after its "- ".
+func parseTaskPaperTask(text string, loc *time.Location) (*reminder.Reminder, error) {
+	r := &reminder.Reminder{}
+	var err error
+	date := func(name, value string) *time.Time {
+		t, perr := time.ParseInLocation(markdownDateTime, value, loc)
+		if perr != nil {
+			t, perr = time.ParseInLocation(markdownDate, value, loc)
+		}
+		if perr != nil {
+			err = fmt.Errorf("invalid @%s date: %q", name, value)
+			return nil
+		}
+		return &t
+	}
+
+	title := taskPaperTag.ReplaceAllStringFunc(text, func(match string) string {
+		m := taskPaperTag.FindStringSubmatch(match)
+		name, value := strings.ToLower(m[2]), strings.ReplaceAll(m[3], "%29", ")")
+		switch name {
+		case "priority":
+			p, perr := strconv.Atoi(value)
+			if perr != nil {
+				p = int(reminder.ParsePriority(strings.ToLower(value)))
+			}
+			if p < 0 || p > 9 {
+				err = fmt.Errorf("invalid @priority: %q", value)
+			}
+			r.Priority = reminder.Priority(p)
+		case "flag", "flagged":
+			r.Flagged = true
+		case "due":
+			r.DueDate = date(name, value)
+		case "remind":
+			r.RemindMeDate = date(name, value)
+		case "created":
+			r.CreationDate = date(name, value)
+		case "modified":
+			r.ModificationDate = date(name, value)
+		case "done":
+			r.Completed = true
+			if value != "" {
+				r.CompletionDate = date(name, value)
+			}
+		case "repeat":
+			rule, rerr := parseRecurrenceText(value, loc)
+			if rerr != nil {
+				err = rerr
+			}
+			r.Recurrence = rule
+		case "url":
+			r.URL = value
+		case "id":
+			r.ID = value
+		default:
+			return match
+		}
+		return m[1]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Name = strings.Join(strings.Fields(title), " ")
+	if r.Name == "" {
+		return nil, fmt.Errorf("missing task title")
+	}
+	return r, nil
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// thingsItem is an object in the Things 3 JSON format: a to-do, a project,
+// a heading or a checklist item.
+type thingsItem struct {
+	Type       string           `json:"type"`
+	Attributes thingsAttributes `json:"attributes"`
+}
+
+type thingsAttributes struct {
+	Title          string       `json:"title"`
+	Notes          string       `json:"notes"`
+	When           string       `json:"when"`
+	Deadline       string       `json:"deadline"`
+	Tags           []string     `json:"tags"`
+	ChecklistItems []thingsItem `json:"checklist-items"`
+	Items          []thingsItem `json:"items"`
+	List           string       `json:"list"`
+	Area           string       `json:"area"`
+	Completed      bool         `json:"completed"`
+	Canceled       bool         `json:"canceled"`
+	CreationDate   string       `json:"creation-date"`
+	CompletionDate string       `json:"completion-date"`
+}
+
+// ImportThings reads a Things 3 JSON export, the array of to-dos and
+// projects used by the things:///json URL scheme. To-dos in a project
+// go to a list named after it, and other to-dos to the project or area
+// in their "list" attribute. The deadline is the due date; the "when"
+// date is the alert time, or the due date for to-dos without a deadline.
+// Checklist items are added to the notes as Markdown checkboxes and tags
+// become #tags. Completed and canceled to-dos are both completed.
+func ImportThings(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	var items []thingsItem
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to parse Things JSON: %w", err)
+	}
+
+	var reminders []*reminder.Reminder
+	var walk func(items []thingsItem, list string) error
+	walk = func(items []thingsItem, list string) error {
+		for _, item := range items {
+			switch item.Type {
+			case "project":
+				if err := walk(item.Attributes.Items, item.Attributes.Title); err != nil {
+					return err
+				}
+			case "heading":
+				if err := walk(item.Attributes.Items, list); err != nil {
+					return err
+				}
+			case "to-do":
+				task, err := fromThings(item.Attributes, loc)
+				if err != nil {
+					return fmt.Errorf("to-do %q: %w", item.Attributes.Title, err)
+				}
+				if task.ListName == "" {
+					task.ListName = list
+				}
+				reminders = append(reminders, task)
+			}
+		}
+		return nil
+	}
+	if err := walk(items, ""); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func fromThings(a thingsAttributes, loc *time.Location) (*reminder.Reminder, error) {
+	r := &reminder.Reminder{
+		Name:      strings.TrimSpace(a.Title),
+		ListName:  a.List,
+		Completed: a.Completed || a.Canceled,
+	}
+	if r.Name == "" {
+		return nil, fmt.Errorf("missing title")
+	}
+	if r.ListName == "" {
+		r.ListName = a.Area
+	}
+
+	var notes []string
+	if a.Notes != "" {
+		notes = append(notes, strings.TrimRight(a.Notes, "\n"))
+	}
+	for _, item := range a.ChecklistItems {
+		box := "[ ]"
+		if item.Attributes.Completed || item.Attributes.Canceled {
+			box = "[x]"
+		}
+		notes = append(notes, "- "+box+" "+item.Attributes.Title)
+	}
+	r.Body = strings.Join(notes, "\n")
+
+	var err error
+	if r.DueDate, err = parseThingsDate(a.Deadline, loc); err != nil {
+		return nil, fmt.Errorf("invalid deadline: %w", err)
+	}
+	when, err := parseThingsDate(a.When, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid when date: %w", err)
+	}
+	if r.DueDate == nil {
+		r.DueDate = when
+	} else {
+		r.RemindMeDate = when
+	}
+	if r.CreationDate, err = parseThingsDate(a.CreationDate, loc); err != nil {
+		return nil, fmt.Errorf("invalid creation date: %w", err)
+	}
+	if r.CompletionDate, err = parseThingsDate(a.CompletionDate, loc); err != nil {
+		return nil, fmt.Errorf("invalid completion date: %w", err)
+	}
+
+	var tags []string
+	for _, tag := range a.Tags {
+		tag = strings.Join(strings.Fields(tag), "-")
+		if reminder.IsTag("#" + tag) {
+			tags = append(tags, tag)
+		}
+	}
+	r.SetTags(tags)
+	return r, nil
+}
+
+// parseThingsDate reads a Things date: "2026-02-15", "2026-02-15@14:30",
+// an ISO 8601 timestamp or a relative date such as "today", "tomorrow" or
+// "evening". "anytime", "someday" and "" have no date.
+func parseThingsDate(s string, loc *time.Location) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "", "anytime", "someday":
+		return nil, nil
+	case "evening":
+		s = "today at 6pm"
+	}
+	if day, clock, ok := strings.Cut(s, "@"); ok {
+		s = day + " " + clock
+	}
+	t, err := parser.New(parser.WithLocation(loc), parser.WithDefaultTime(0, 0)).ParseDate(s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,164 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// ImportTodoist reads a project exported from Todoist as a CSV template.
+// Task rows become reminders, section rows name the list of the tasks
+// below them and note rows are added to the notes of the task above.
+// Todoist's P1 to P4 map to high, medium, low and none, and @labels in the
+// task become #tags. There are no subtasks in Reminders, so a task
+// indented under another keeps its place in the list and names its parent
+// in its notes. DATE is read as a natural language date or, when it
+// repeats ("every monday"), as a recurrence; dates rem can't read are kept
+// in the notes.
+func ImportTodoist(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	colMap := make(map[string]int)
+	for i, h := range header {
+		colMap[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := colMap["TYPE"]; !ok {
+		return nil, fmt.Errorf("not a Todoist CSV file: missing TYPE column")
+	}
+	if _, ok := colMap["CONTENT"]; !ok {
+		return nil, fmt.Errorf("not a Todoist CSV file: missing CONTENT column")
+	}
+	field := func(record []string, name string) string {
+		if idx, ok := colMap[name]; ok && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+
+	var (
+		reminders []*reminder.Reminder
+		list      string
+		notes     = map[*reminder.Reminder][]string{}
+		// parents holds the last task seen at each indent level.
+		parents []*reminder.Reminder
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV record: %w", err)
+		}
+
+		content := field(record, "CONTENT")
+		switch strings.ToLower(field(record, "TYPE")) {
+		case "section":
+			list = content
+			parents = nil
+		case "note":
+			if len(parents) > 0 && content != "" {
+				task := parents[len(parents)-1]
+				notes[task] = append(notes[task], content)
+			}
+		case "task":
+			task, err := parseTodoistTask(record, field, loc)
+			if err != nil {
+				line, _ := reader.FieldPos(0)
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			task.ListName = list
+
+			indent, _ := strconv.Atoi(field(record, "INDENT"))
+			if indent < 1 {
+				indent = 1
+			}
+			if indent > len(parents)+1 {
+				indent = len(parents) + 1
+			}
+			parents = append(parents[:indent-1], task)
+			var lines []string
+			if indent > 1 {
+				lines = append(lines, "Subtask of: "+parents[indent-2].Name)
+			}
+			if task.Body != "" {
+				lines = append(lines, task.Body)
+			}
+			notes[task] = lines
+			reminders = append(reminders, task)
+		}
+	}
+
+	for _, task := range reminders {
+		tags := task.Tags()
+		task.Body = strings.Join(notes[task], "\n")
+		task.SetTags(tags)
+	}
+	return reminders, nil
+}
+
+// parseTodoistTask reads a task row. Labels are returned as tags on the
+// reminder and the description as its notes.
+func parseTodoistTask(record []string, field func([]string, string) string, loc *time.Location) (*reminder.Reminder, error) {
+	r := &reminder.Reminder{Body: field(record, "DESCRIPTION")}
+
+	var title, tags []string
+	for _, w := range strings.Fields(field(record, "CONTENT")) {
+		if len(w) > 1 && w[0] == '@' && reminder.IsTag("#"+w[1:]) {
+			tags = append(tags, w[1:])
+			continue
+		}
+		title = append(title, w)
+	}
+	r.Name = strings.Join(title, " ")
+	if r.Name == "" {
+		return nil, fmt.Errorf("missing task content")
+	}
+
+	switch field(record, "PRIORITY") {
+	case "1":
+		r.Priority = reminder.PriorityHigh
+	case "2":
+		r.Priority = reminder.PriorityMedium
+	case "3":
+		r.Priority = reminder.PriorityLow
+	}
+
+	if date := field(record, "DATE"); date != "" {
+		dateLoc := loc
+		if tz := field(record, "TIMEZONE"); tz != "" {
+			if l, err := parser.LoadLocation(tz); err == nil {
+				dateLoc = l
+			}
+		}
+		p := parser.New(parser.WithLocation(dateLoc), parser.WithDefaultTime(0, 0))
+		if strings.HasPrefix(strings.ToLower(date), "every") {
+			if rule, first, err := p.ParseRecurrence(date); err == nil {
+				r.Recurrence, r.DueDate = rule, &first
+			}
+		} else if t, err := p.ParseDate(date); err == nil {
+			r.DueDate = &t
+		}
+		if r.DueDate == nil {
+			r.Body = strings.TrimSpace(r.Body + "\nTodoist date: " + date)
+		}
+	}
+
+	r.SetTags(tags)
+	return r, nil
+}
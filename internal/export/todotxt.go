@@ -0,0 +1,216 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+const (
+	todoTxtDate     = "2006-01-02"
+	todoTxtDateTime = "2006-01-02T15:04"
+)
+
+// ExportTodoTxt writes reminders in the todo.txt format, one task per line.
+// See FormatTodoTxt for how fields are mapped.
+func ExportTodoTxt(w io.Writer, reminders []*reminder.Reminder, opts ...Option) error {
+	bw := bufio.NewWriter(w)
+	for _, r := range reminders {
+		fmt.Fprintln(bw, FormatTodoTxt(r, opts...))
+	}
+	return bw.Flush()
+}
+
+// FormatTodoTxt formats a reminder as a todo.txt line:
+//
+//	x 2026-02-01 2026-01-20 Title +List @tag https://url due:2026-02-15 rem:<id>
+//	(A) 2026-01-20 Title +List due:2026-02-15T14:30 rem:<id>
+//
+// Priorities high, medium and low map to (A), (B) and (C); completed tasks
+// keep theirs as pri:A. Spaces in the list name become underscores, #tags in
+// the notes become @contexts and the reminder ID is kept in rem:<id>.
+func FormatTodoTxt(r *reminder.Reminder, opts ...Option) string {
+	o := newOptions(opts)
+	date := func(t time.Time) string {
+		t = t.In(o.location())
+		return t.Format(todoTxtDate)
+	}
+
+	var parts []string
+	pri := priorityLetter(r.Priority)
+	if r.Completed {
+		parts = append(parts, "x")
+		if r.CompletionDate != nil {
+			parts = append(parts, date(*r.CompletionDate))
+			if r.CreationDate != nil {
+				parts = append(parts, date(*r.CreationDate))
+			}
+		}
+	} else {
+		if pri != "" {
+			parts = append(parts, "("+pri+")")
+		}
+		if r.CreationDate != nil {
+			parts = append(parts, date(*r.CreationDate))
+		}
+	}
+
+	parts = append(parts, strings.Fields(r.Name)...)
+	if r.ListName != "" {
+		parts = append(parts, "+"+strings.Join(strings.Fields(r.ListName), "_"))
+	}
+	for _, tag := range r.Tags() {
+		parts = append(parts, "@"+tag)
+	}
+	if r.URL != "" {
+		parts = append(parts, r.URL)
+	}
+	if r.DueDate != nil {
+		due := *r.DueDate
+		due = due.In(o.location())
+		if due.Hour() == 0 && due.Minute() == 0 {
+			parts = append(parts, "due:"+due.Format(todoTxtDate))
+		} else {
+			parts = append(parts, "due:"+due.Format(todoTxtDateTime))
+		}
+	}
+	if r.Completed && pri != "" {
+		parts = append(parts, "pri:"+pri)
+	}
+	if r.ID != "" {
+		parts = append(parts, "rem:"+r.ID)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ImportTodoTxt reads reminders from a todo.txt file. Blank lines are
+// skipped. Dates are read in the zone set with WithLocation, or local time.
+func ImportTodoTxt(r io.Reader, opts ...Option) ([]*reminder.Reminder, error) {
+	var reminders []*reminder.Reminder
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		rem, err := ParseTodoTxt(scanner.Text(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		reminders = append(reminders, rem)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read todo.txt: %w", err)
+	}
+	return reminders, nil
+}
+
+// ParseTodoTxt reads a single todo.txt line, the reverse of FormatTodoTxt.
+// The first +project is the list; further projects, unknown key:value
+// pairs and contexts that aren't valid tags stay in the title.
+func ParseTodoTxt(line string, opts ...Option) (*reminder.Reminder, error) {
+	o := newOptions(opts)
+	loc := o.location()
+	date := func(s string) (*time.Time, bool) {
+		t, err := time.ParseInLocation(todoTxtDate, s, loc)
+		if err != nil {
+			return nil, false
+		}
+		return &t, true
+	}
+
+	r := &reminder.Reminder{}
+	words := strings.Fields(line)
+	if len(words) > 0 && words[0] == "x" {
+		r.Completed = true
+		words = words[1:]
+		if len(words) > 0 {
+			if t, ok := date(words[0]); ok {
+				r.CompletionDate = t
+				words = words[1:]
+			}
+		}
+	} else if len(words) > 0 && isTodoTxtPriority(words[0]) {
+		r.Priority = priorityFromLetter(words[0][1])
+		words = words[1:]
+	}
+	if len(words) > 0 {
+		if t, ok := date(words[0]); ok {
+			r.CreationDate = t
+			words = words[1:]
+		}
+	}
+
+	var title, tags []string
+	for _, w := range words {
+		key, value, isPair := strings.Cut(w, ":")
+		switch {
+		case len(w) > 1 && w[0] == '+' && r.ListName == "":
+			r.ListName = strings.ReplaceAll(w[1:], "_", " ")
+		case len(w) > 1 && w[0] == '@' && reminder.IsTag("#"+w[1:]):
+			tags = append(tags, w[1:])
+		case r.URL == "" && (strings.HasPrefix(w, "http://") || strings.HasPrefix(w, "https://")):
+			r.URL = w
+		case isPair && key == "due" && value != "":
+			due, err := time.ParseInLocation(todoTxtDate, value, loc)
+			if err != nil {
+				due, err = time.ParseInLocation(todoTxtDateTime, value, loc)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid due date: %q", value)
+			}
+			r.DueDate = &due
+		case isPair && key == "pri" && len(value) == 1 && value[0] >= 'A' && value[0] <= 'Z':
+			r.Priority = priorityFromLetter(value[0])
+		case isPair && key == "rem" && value != "":
+			r.ID = value
+		default:
+			title = append(title, w)
+		}
+	}
+
+	r.Name = strings.Join(title, " ")
+	if r.Name == "" {
+		return nil, fmt.Errorf("missing task description")
+	}
+	r.SetTags(tags)
+	return r, nil
+}
+
+// priorityLetter maps a priority to its todo.txt or Org letter, or "" for
+// none.
+func priorityLetter(p reminder.Priority) string {
+	switch {
+	case p >= 1 && p <= 4:
+		return "A"
+	case p == 5:
+		return "B"
+	case p >= 6 && p <= 9:
+		return "C"
+	default:
+		return ""
+	}
+}
+
+// priorityFromLetter maps a priority letter: A is high, B medium
+// and anything lower is low.
+func priorityFromLetter(c byte) reminder.Priority {
+	switch c {
+	case 'A':
+		return reminder.PriorityHigh
+	case 'B':
+		return reminder.PriorityMedium
+	default:
+		return reminder.PriorityLow
+	}
+}
+
+func isTodoTxtPriority(w string) bool {
+	return len(w) == 3 && w[0] == '(' && w[1] >= 'A' && w[1] <= 'Z' && w[2] == ')'
+}
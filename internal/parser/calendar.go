@@ -0,0 +1,321 @@
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Calendar is a working calendar: the weekdays that are worked and the
+// dates that are holidays. It backs business-day expressions such as
+// "in 3 business days" and "next workday".
+type Calendar struct {
+	workdays [7]bool
+	holidays map[string]string
+}
+
+// NewCalendar returns a calendar with Monday to Friday as working days and
+// no holidays.
+func NewCalendar() *Calendar {
+	c := &Calendar{holidays: make(map[string]string)}
+	c.SetWorkdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+	return c
+}
+
+// SetWorkdays replaces the set of working weekdays.
+func (c *Calendar) SetWorkdays(days ...time.Weekday) {
+	c.workdays = [7]bool{}
+	for _, d := range days {
+		c.workdays[d] = true
+	}
+}
+
+// Workdays returns the working weekdays, starting from Sunday.
+func (c *Calendar) Workdays() []time.Weekday {
+	var days []time.Weekday
+	for d, ok := range c.workdays {
+		if ok {
+			days = append(days, time.Weekday(d))
+		}
+	}
+	return days
+}
+
+// AddHoliday marks the date of t as a holiday.
+func (c *Calendar) AddHoliday(t time.Time, name string) {
+	c.holidays[t.Format(dateKey)] = name
+}
+
+// Holiday returns the name of the holiday on t's date, if it is one.
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[t.Format(dateKey)]
+	return name, ok
+}
+
+// IsWorkday reports whether t falls on a working weekday that is not a holiday.
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	if !c.workdays[t.Weekday()] {
+		return false
+	}
+	_, holiday := c.Holiday(t)
+	return !holiday
+}
+
+// AddWorkdays moves t by n working days, forward for positive n and
+// backward for negative n, keeping the time of day. A calendar without
+// working weekdays counts every day.
+func (c *Calendar) AddWorkdays(t time.Time, n int) time.Time {
+	if len(c.Workdays()) == 0 {
+		return t.AddDate(0, 0, n)
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsWorkday(t) {
+			n--
+		}
+	}
+	return t
+}
+
+const dateKey = "2006-01-02"
+
+// DefaultCalendarPath returns the working calendar file: $REM_CALENDAR if
+// set, otherwise the first of calendar.yaml, calendar.yml or calendar.ics
+// that exists in rem's config directory ($XDG_CONFIG_HOME/rem, falling back
+// to ~/.config/rem). It returns "" when there is none.
+func DefaultCalendarPath() (string, error) {
+	if p := os.Getenv("REM_CALENDAR"); p != "" {
+		return p, nil
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate home directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	for _, name := range []string{"calendar.yaml", "calendar.yml", "calendar.ics"} {
+		p := filepath.Join(configHome, "rem", name)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", nil
+}
+
+// LoadCalendar reads a working calendar from path. Files ending in .ics are
+// read as iCalendar, with every all-day VEVENT marking a holiday (recurring
+// events are not expanded). Anything else is read as a YAML list:
+//
+//	workdays: [mon, tue, wed, thu, fri]
+//	holidays:
+//	  - 2026-12-25: Christmas Day
+//	  - 2026-12-31
+//
+// A YAML file without workdays keeps Monday to Friday.
+func LoadCalendar(path string) (*Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open calendar: %w", err)
+	}
+	defer f.Close()
+
+	c := NewCalendar()
+	if strings.EqualFold(filepath.Ext(path), ".ics") {
+		err = c.readICS(f)
+	} else {
+		err = c.readYAML(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calendar %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// readYAML reads the small YAML subset documented on LoadCalendar.
+func (c *Calendar) readYAML(f *os.File) error {
+	section := "holidays"
+	var workdays []time.Weekday
+	sawWorkdays := false
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || line == "---" {
+			continue
+		}
+
+		if item, ok := strings.CutPrefix(line, "-"); ok {
+			item = strings.TrimSpace(item)
+			switch section {
+			case "workdays":
+				days, err := parseWorkdayList(item)
+				if err != nil {
+					return fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				workdays = append(workdays, days...)
+			case "holidays":
+				date, name, _ := strings.Cut(item, ":")
+				t, err := time.Parse(dateKey, unquote(strings.TrimSpace(date)))
+				if err != nil {
+					return fmt.Errorf("line %d: invalid holiday date %q", lineNo, strings.TrimSpace(date))
+				}
+				c.AddHoliday(t, unquote(strings.TrimSpace(name)))
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("line %d: expected \"key:\" or \"- item\"", lineNo)
+		}
+		section = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch section {
+		case "workdays":
+			sawWorkdays = true
+			if value != "" {
+				days, err := parseWorkdayList(value)
+				if err != nil {
+					return fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				workdays = append(workdays, days...)
+			}
+		case "holidays":
+			if value != "" && value != "[]" {
+				return fmt.Errorf("line %d: holidays must be a list, one date per line", lineNo)
+			}
+		default:
+			return fmt.Errorf("line %d: unknown key %q (use workdays or holidays)", lineNo, section)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if sawWorkdays {
+		if len(workdays) == 0 {
+			return errors.New("workdays is empty")
+		}
+		c.SetWorkdays(workdays...)
+	}
+	return nil
+}
+
+// parseWorkdayList parses "mon", "[mon, tue]" or "mon, tue".
+func parseWorkdayList(s string) ([]time.Weekday, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	var days []time.Weekday
+	for _, name := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		day, ok := weekdays[strings.ToLower(unquote(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// readICS marks the days covered by each VEVENT as holidays. DTEND is
+// exclusive, as in RFC 5545; an event without one covers a single day.
+func (c *Calendar) readICS(f *os.File) error {
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// Continuation lines start with a space or tab.
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	inEvent := false
+	var start, end, summary string
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, _, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end, summary = "", "", ""
+			}
+		case "DTSTART":
+			start = value
+		case "DTEND":
+			end = value
+		case "SUMMARY":
+			summary = strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\\`, `\`).Replace(value)
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if err := c.addEventDays(start, end, summary); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Calendar) addEventDays(start, end, summary string) error {
+	first, err := parseICSDate(start)
+	if err != nil {
+		return fmt.Errorf("event %q: %w", summary, err)
+	}
+	last := first
+	if end != "" {
+		t, err := parseICSDate(end)
+		if err != nil {
+			return fmt.Errorf("event %q: %w", summary, err)
+		}
+		if t.After(first) {
+			last = t.AddDate(0, 0, -1)
+		}
+	}
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		c.AddHoliday(d, summary)
+	}
+	return nil
+}
+
+// parseICSDate reads the date part of a DATE or DATE-TIME value.
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	t, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCalendar(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCalendarAddWorkdays(t *testing.T) {
+	c := NewCalendar()
+	c.AddHoliday(time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC), "Presidents' Day")
+
+	tests := []struct {
+		n    int
+		want time.Time
+	}{
+		{0, fixedNow},
+		{1, time.Date(2026, 2, 12, 10, 0, 0, 0, time.Local)},
+		{3, time.Date(2026, 2, 17, 10, 0, 0, 0, time.Local)},
+		{-3, time.Date(2026, 2, 6, 10, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		if got := c.AddWorkdays(fixedNow, tt.n); !got.Equal(tt.want) {
+			t.Errorf("AddWorkdays(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLoadCalendarYAML(t *testing.T) {
+	path := writeCalendar(t, "calendar.yaml", `# team calendar
+workdays: [mon, tue, wed, thu]
+holidays:
+  - 2026-12-25: Christmas Day
+  - "2026-12-31"   # office closed
+`)
+	c, err := LoadCalendar(path)
+	if err != nil {
+		t.Fatalf("LoadCalendar error: %v", err)
+	}
+
+	if c.IsWorkday(time.Date(2026, 2, 13, 0, 0, 0, 0, time.Local)) {
+		t.Error("Friday should not be a workday")
+	}
+	if name, ok := c.Holiday(time.Date(2026, 12, 25, 12, 0, 0, 0, time.Local)); !ok || name != "Christmas Day" {
+		t.Errorf("Holiday(Dec 25) = %q, %v", name, ok)
+	}
+	if c.IsWorkday(time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local)) {
+		t.Error("Dec 31 should be a holiday")
+	}
+}
+
+func TestLoadCalendarYAMLBlockWorkdays(t *testing.T) {
+	path := writeCalendar(t, "calendar.yml", "workdays:\n  - sun\n  - mon\n")
+	c, err := LoadCalendar(path)
+	if err != nil {
+		t.Fatalf("LoadCalendar error: %v", err)
+	}
+	got := c.Workdays()
+	if len(got) != 2 || got[0] != time.Sunday || got[1] != time.Monday {
+		t.Errorf("Workdays() = %v, want [Sunday Monday]", got)
+	}
+}
+
+func TestLoadCalendarICS(t *testing.T) {
+	path := writeCalendar(t, "holidays.ics", "BEGIN:VCALENDAR\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"DTSTART;VALUE=DATE:20261224\r\n"+
+		"DTEND;VALUE=DATE:20261227\r\n"+
+		"SUMMARY:Winter\r\n  break\r\n"+
+		"END:VEVENT\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"DTSTART:20260101T000000Z\r\n"+
+		"SUMMARY:New Year\\, again\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n")
+
+	c, err := LoadCalendar(path)
+	if err != nil {
+		t.Fatalf("LoadCalendar error: %v", err)
+	}
+	for _, day := range []int{24, 25, 26} {
+		if name, ok := c.Holiday(time.Date(2026, 12, day, 0, 0, 0, 0, time.Local)); !ok || name != "Winter break" {
+			t.Errorf("Holiday(Dec %d) = %q, %v", day, name, ok)
+		}
+	}
+	if _, ok := c.Holiday(time.Date(2026, 12, 27, 0, 0, 0, 0, time.Local)); ok {
+		t.Error("DTEND should be exclusive")
+	}
+	if name, _ := c.Holiday(time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)); name != "New Year, again" {
+		t.Errorf("Holiday(Jan 1) = %q", name)
+	}
+}
+
+func TestLoadCalendarErrors(t *testing.T) {
+	tests := map[string]string{
+		"bad date":       "holidays:\n  - 2026-13-01\n",
+		"unknown key":    "vacations:\n  - 2026-01-01\n",
+		"bad weekday":    "workdays: [mon, funday]\n",
+		"no workdays":    "workdays: []\n",
+		"inline holiday": "holidays: 2026-01-01\n",
+	}
+	for desc, content := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if _, err := LoadCalendar(writeCalendar(t, "calendar.yaml", content)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -1,230 +1,803 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// ParseDate parses a natural language or formatted date string into a time.Time.
+// Parser parses natural language and formatted dates relative to a
+// reference time. The zero value is not usable; create one with New.
+type Parser struct {
+	now           func() time.Time
+	loc           *time.Location
+	defaultHour   int
+	defaultMinute int
+	endOfDayHour  int
+	calendar      *Calendar
+}
+
+// Option configures a Parser.
+type Option func(*Parser)
+
+// WithNow fixes the reference time used for relative expressions.
+func WithNow(t time.Time) Option {
+	return func(p *Parser) { p.now = func() time.Time { return t } }
+}
+
+// WithClock sets the function that supplies the reference time.
+func WithClock(now func() time.Time) Option {
+	return func(p *Parser) { p.now = now }
+}
+
+// WithLocation sets the zone results are resolved in (default: time.Local).
+func WithLocation(loc *time.Location) Option {
+	return func(p *Parser) { p.loc = loc }
+}
+
+// WithDefaultTime sets the time of day used for inputs that name a day but
+// no time, like "tomorrow" or "next monday" (default: 9:00).
+func WithDefaultTime(hour, min int) Option {
+	return func(p *Parser) { p.defaultHour, p.defaultMinute = hour, min }
+}
+
+// WithEndOfDay sets the hour used by "eod" and "eow" (default: 17).
+func WithEndOfDay(hour int) Option {
+	return func(p *Parser) { p.endOfDayHour = hour }
+}
+
+// WithCalendar sets the working calendar used by business-day expressions
+// (default: Monday to Friday, no holidays).
+func WithCalendar(c *Calendar) Option {
+	return func(p *Parser) { p.calendar = c }
+}
+
+// New creates a Parser. Without options it uses the wall clock, the local
+// zone, 9:00 for date-only inputs and 17:00 as the end of day.
+func New(opts ...Option) *Parser {
+	p := &Parser{
+		now:           time.Now,
+		loc:           time.Local,
+		defaultHour:   9,
+		defaultMinute: 0,
+		endOfDayHour:  17,
+		calendar:      NewCalendar(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ParseDate parses a natural language or formatted date string into a time.Time
+// relative to the current time in the local zone.
 func ParseDate(input string) (time.Time, error) {
+	return New().ParseDate(input)
+}
+
+// ParseDateAt parses input relative to now, resolving results in loc.
+func ParseDateAt(input string, now time.Time, loc *time.Location) (time.Time, error) {
+	return New(WithNow(now), WithLocation(loc)).ParseDate(input)
+}
+
+// Calendar returns the parser's working calendar.
+func (p *Parser) Calendar() *Calendar {
+	return p.calendar
+}
+
+// Now returns the parser's reference time in its location.
+func (p *Parser) Now() time.Time {
+	return p.now().In(p.loc)
+}
+
+// ParseDate parses a natural language or formatted date string into a time.Time.
+//
+// Expressions are built from a date phrase ("friday", "march 3", "the 15th",
+// "end of month", "next quarter", "tonight"), a relative offset ("in 2h30m",
+// "3 days from now", "2 weeks after next monday") and a time of day ("at
+// 5pm", "noon", "17:00"), in any combination. A time of day alone means its
+// next occurrence; a date phrase alone uses the default time of day. A
+// trailing zone ("3pm PST", "9:00 Europe/Berlin") evaluates the expression
+// in that zone; RFC 3339 timestamps keep their offset.
+func (p *Parser) ParseDate(input string) (time.Time, error) {
+	e, err := p.Explain(input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return e.Time, nil
+}
+
+// Explanation describes how ParseDate read an expression.
+type Explanation struct {
+	Input string    `json:"input"`
+	Time  time.Time `json:"time"`
+	// Rules are the grammar rules that matched, outermost first.
+	Rules []string `json:"rules"`
+	// Warnings point out readings that may not be what was meant.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (e *Explanation) rule(name string) {
+	e.Rules = append(e.Rules, name)
+}
+
+func (e *Explanation) warn(format string, args ...any) {
+	e.Warnings = append(e.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Explain parses input like ParseDate and reports which rules matched and
+// anything surprising about the result.
+func (p *Parser) Explain(input string) (*Explanation, error) {
 	input = strings.TrimSpace(input)
 	if input == "" {
-		return time.Time{}, fmt.Errorf("empty date string")
+		return nil, fmt.Errorf("empty date string")
+	}
+	e := &Explanation{Input: input}
+	t, err := p.explain(input, e)
+	if err != nil {
+		return nil, err
+	}
+	e.Time = t
+
+	if t.Before(p.Now()) && !meansPast(input) {
+		e.warn("%s is in the past", t.In(p.loc).Format("Mon Jan 2 15:04"))
 	}
+	return e, nil
+}
 
-	now := time.Now()
+// meansPast reports whether input asks for a past date on purpose.
+func meansPast(input string) bool {
+	for _, w := range tokenize(input) {
+		if w == "ago" || w == "before" || w == "yesterday" {
+			return true
+		}
+	}
+	return false
+}
 
+func (p *Parser) explain(input string, e *Explanation) (time.Time, error) {
 	// Try standard formats first
-	if t, err := tryStandardFormats(input); err == nil {
-		return t, nil
+	if t, layout, err := tryStandardFormats(input, p.loc); err == nil {
+		e.rule(fmt.Sprintf("standard format (%s)", layout))
+		if !strings.Contains(layout, "15") && !strings.Contains(layout, "3:04") {
+			e.warn("no time given, using midnight")
+		}
+		return t.In(p.loc), nil
 	}
 
-	// Try natural language parsing
-	lower := strings.ToLower(input)
+	// An explicit zone ("3pm PST") resolves the rest of the input there.
+	if rest, loc, ok := splitZone(input); ok {
+		e.rule(fmt.Sprintf("time zone (%s)", loc))
+		zoned := *p
+		zoned.loc = loc
+		t, err := zoned.explain(rest, e)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.In(p.loc), nil
+	}
 
-	// Handle "today", "tomorrow", "yesterday"
-	switch {
-	case lower == "today":
-		return todayAt(now, 9, 0), nil
-	case lower == "tomorrow":
-		return todayAt(now.AddDate(0, 0, 1), 9, 0), nil
-	case lower == "yesterday":
-		return todayAt(now.AddDate(0, 0, -1), 9, 0), nil
+	t, err := p.parseExpr(tokenize(input), p.Now(), e)
+	if errors.Is(err, errNoMatch) {
+		return time.Time{}, fmt.Errorf("unable to parse date: %q", input)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse date %q: %w", input, err)
 	}
+	return t, nil
+}
+
+// dayAt returns base's date at the parser's default time of day.
+func (p *Parser) dayAt(base time.Time) time.Time {
+	return todayAt(base, p.defaultHour, p.defaultMinute)
+}
 
-	// Handle "in X hours/minutes/days/weeks"
-	if t, err := parseRelative(lower, now); err == nil {
-		return t, nil
+// tonightHour is the time of day "tonight" resolves to.
+const tonightHour = 20
+
+var errNoMatch = errors.New("no rule matched")
+
+// tokenize lowercases input and splits it into words, dropping commas.
+func tokenize(input string) []string {
+	return strings.Fields(strings.ReplaceAll(strings.ToLower(input), ",", " "))
+}
+
+// parseExpr parses a tokenized date expression:
+//
+//	expr   = offset ("after" | "before" | "from") expr
+//	       | phrase [time] | time [phrase] | phrase "at" time
+//	phrase = "in" offset | offset "ago" | <date rule>
+func (p *Parser) parseExpr(words []string, now time.Time, e *Explanation) (time.Time, error) {
+	for i, w := range words {
+		if i == 0 || (w != "after" && w != "before" && w != "from") {
+			continue
+		}
+		d, ok := parseOffset(words[:i])
+		if !ok {
+			continue
+		}
+		e.rule("offset " + w + " date")
+		base, err := p.parseExpr(words[i+1:], now, e)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if w == "before" {
+			d = d.negate()
+		}
+		return p.shift(base, d), nil
 	}
 
-	// Handle "next monday", "next tuesday", etc.
-	if t, err := parseNextWeekday(lower, now); err == nil {
-		return t, nil
+	words, clock, err := splitTimeOfDay(words)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(words) > 0 && (words[0] == "on" || words[0] == "by") {
+		words = words[1:]
 	}
 
-	// Handle "today at 5pm", "tomorrow at 3:30pm"
-	if t, err := parseDateWithTime(lower, now); err == nil {
-		return t, nil
+	if len(words) == 0 {
+		if clock == nil {
+			return time.Time{}, errNoMatch
+		}
+		// A bare time means its next occurrence.
+		e.rule("time only")
+		result := todayAt(now, clock.hour, clock.min)
+		if result.Before(now) {
+			result = result.AddDate(0, 0, 1)
+			e.warn("%s has already passed today, scheduled for tomorrow", result.Format("15:04"))
+		}
+		return result, nil
 	}
 
-	// Handle standalone time like "5pm", "17:00", "3:30pm"
-	if t, err := parseTimeOnly(lower, now); err == nil {
-		return t, nil
+	result, ok := p.parsePhrase(words, now, e)
+	if !ok {
+		return time.Time{}, errNoMatch
 	}
+	if clock != nil {
+		e.rule("time of day")
+		result = todayAt(result, clock.hour, clock.min)
+	}
+	return result, nil
+}
 
-	// Handle "next week", "next month"
+// parsePhrase resolves a date phrase without a time of day.
+func (p *Parser) parsePhrase(words []string, now time.Time, e *Explanation) (time.Time, bool) {
+	n := len(words)
 	switch {
-	case lower == "next week":
-		return todayAt(now.AddDate(0, 0, 7), 9, 0), nil
-	case lower == "next month":
-		return todayAt(now.AddDate(0, 1, 0), 9, 0), nil
-	case lower == "end of day", lower == "eod":
-		return todayAt(now, 17, 0), nil
-	case lower == "end of week", lower == "eow":
-		daysUntilFriday := (5 - int(now.Weekday()) + 7) % 7
-		if daysUntilFriday == 0 {
-			daysUntilFriday = 7
+	case words[0] == "in":
+		if d, ok := parseOffset(words[1:]); ok {
+			e.rule("relative offset")
+			return p.shift(now, d), true
+		}
+		return time.Time{}, false
+	case words[n-1] == "ago":
+		if d, ok := parseOffset(words[:n-1]); ok {
+			e.rule("ago")
+			return p.shift(now, d.negate()), true
 		}
-		return todayAt(now.AddDate(0, 0, daysUntilFriday), 17, 0), nil
+		return time.Time{}, false
 	}
 
-	return time.Time{}, fmt.Errorf("unable to parse date: %q", input)
+	for _, rule := range dateRules {
+		if t, ok := rule.parse(p, words, now); ok {
+			e.rule(rule.name)
+			if rule.name == "month and day" && t.Year() != now.Year() && !yearPattern.MatchString(words[n-1]) {
+				e.warn("%s has passed this year, using %d", t.Format("January 2"), t.Year())
+			}
+			if rule.name == "weekday" && t.YearDay() == now.YearDay() && t.Year() == now.Year() {
+				e.warn("%s is today; use \"next %s\" for next week", strings.ToLower(t.Weekday().String()), strings.ToLower(t.Weekday().String()))
+			}
+			return t, true
+		}
+	}
+	return time.Time{}, false
 }
 
-func tryStandardFormats(input string) (time.Time, error) {
-	formats := []string{
-		"2006-01-02",
-		"2006-01-02 15:04",
-		"2006-01-02 3:04PM",
-		"2006-01-02 3:04pm",
-		"2006-01-02T15:04:05",
-		"2006-01-02T15:04",
-		"01/02/2006",
-		"01/02/2006 15:04",
-		"01/02/2006 3:04PM",
-		"Jan 2, 2006",
-		"Jan 2, 2006 3:04PM",
-		"Jan 2, 2006 15:04",
-		"January 2, 2006",
-		"January 2, 2006 3:04PM",
-		"January 2, 2006 15:04",
-		"2 Jan 2006",
-		"02 Jan 2006",
+// dateRule is one production of the date phrase grammar.
+type dateRule struct {
+	name  string
+	parse func(p *Parser, words []string, now time.Time) (time.Time, bool)
+}
+
+var dateRules = []dateRule{
+	{"relative day", ruleRelativeDay},
+	{"weekday", ruleWeekday},
+	{"next weekday", ruleNextWeekday},
+	{"weekend", ruleWeekend},
+	{"next workday", ruleNextWorkday},
+	{"next period", ruleNextPeriod},
+	{"end of period", ruleEndOfPeriod},
+	{"day of month", ruleDayOfMonth},
+	{"month and day", ruleMonthDay},
+}
+
+// ruleRelativeDay handles "today", "tomorrow", "yesterday", "tonight",
+// "now" and "day after tomorrow".
+func ruleRelativeDay(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	switch strings.Join(words, " ") {
+	case "now":
+		return now, true
+	case "today":
+		return p.dayAt(now), true
+	case "tonight":
+		return todayAt(now, tonightHour, 0), true
+	case "tomorrow", "tmrw", "tmr":
+		return p.dayAt(now.AddDate(0, 0, 1)), true
+	case "yesterday":
+		return p.dayAt(now.AddDate(0, 0, -1)), true
+	case "day after tomorrow":
+		return p.dayAt(now.AddDate(0, 0, 2)), true
 	}
+	return time.Time{}, false
+}
 
-	for _, f := range formats {
-		t, err := time.ParseInLocation(f, input, time.Now().Location())
-		if err == nil {
-			return t, nil
-		}
+// ruleWeekday handles "friday" and "this friday": the next one, today
+// included.
+func ruleWeekday(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	if len(words) == 2 && words[0] == "this" {
+		words = words[1:]
+	}
+	if len(words) != 1 {
+		return time.Time{}, false
+	}
+	day, ok := weekdays[words[0]]
+	if !ok {
+		return time.Time{}, false
+	}
+	return p.dayAt(now.AddDate(0, 0, daysUntil(now, day, false))), true
+}
+
+// ruleNextWeekday handles "next friday": the next one after today.
+func ruleNextWeekday(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	if len(words) != 2 || words[0] != "next" {
+		return time.Time{}, false
 	}
+	day, ok := weekdays[words[1]]
+	if !ok {
+		return time.Time{}, false
+	}
+	return p.dayAt(now.AddDate(0, 0, daysUntil(now, day, true))), true
+}
 
-	return time.Time{}, fmt.Errorf("no standard format matched")
+// ruleWeekend handles "weekend", "this weekend" (Saturday, or today if it
+// is already the weekend) and "next weekend" (the next Saturday after today).
+func ruleWeekend(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	switch strings.Join(words, " ") {
+	case "weekend", "this weekend":
+		if now.Weekday() == time.Sunday {
+			return p.dayAt(now), true
+		}
+		return p.dayAt(now.AddDate(0, 0, daysUntil(now, time.Saturday, false))), true
+	case "next weekend":
+		return p.dayAt(now.AddDate(0, 0, daysUntil(now, time.Saturday, true))), true
+	}
+	return time.Time{}, false
 }
 
-var relativePattern = regexp.MustCompile(`^in\s+(\d+)\s+(minute|minutes|min|mins|hour|hours|hr|hrs|day|days|week|weeks|month|months)$`)
+// ruleNextWorkday handles "next workday", "next business day" and "next
+// working day": the first working day after today.
+func ruleNextWorkday(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	if len(words) < 2 || words[0] != "next" {
+		return time.Time{}, false
+	}
+	unit, span, ok := lookupUnit(words[1:])
+	if !ok || span != len(words)-1 || unit.workdays != 1 {
+		return time.Time{}, false
+	}
+	return p.dayAt(p.calendar.AddWorkdays(now, 1)), true
+}
 
-func parseRelative(input string, now time.Time) (time.Time, error) {
-	matches := relativePattern.FindStringSubmatch(input)
-	if matches == nil {
-		return time.Time{}, fmt.Errorf("not a relative date")
+// ruleNextPeriod handles "next week", "next month" and "next year", which
+// move the current date forward, and "next quarter", which is the first day
+// of the following quarter.
+func ruleNextPeriod(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	if len(words) != 2 || words[0] != "next" {
+		return time.Time{}, false
 	}
+	switch words[1] {
+	case "week":
+		return p.dayAt(now.AddDate(0, 0, 7)), true
+	case "month":
+		return p.dayAt(now.AddDate(0, 1, 0)), true
+	case "year":
+		return p.dayAt(now.AddDate(1, 0, 0)), true
+	case "quarter":
+		start := quarterStart(now).AddDate(0, 3, 0)
+		return p.dayAt(start), true
+	}
+	return time.Time{}, false
+}
 
-	amount, _ := strconv.Atoi(matches[1])
-	unit := matches[2]
+var endOfAbbreviations = map[string]string{
+	"eod": "day",
+	"eow": "week",
+	"eom": "month",
+	"eoq": "quarter",
+	"eoy": "year",
+}
 
+// ruleEndOfPeriod handles "end of [this|next] day|week|business
+// week|month|quarter|year" and the abbreviations eod, eow, eom, eoq and eoy.
+// The end of a week is Friday and the end of a business week its last
+// working day; all of them fall at the end-of-day hour.
+func ruleEndOfPeriod(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	words = dropWords(words, "the")
+	var unit string
+	ahead := 0
 	switch {
-	case strings.HasPrefix(unit, "min"):
-		return now.Add(time.Duration(amount) * time.Minute), nil
-	case strings.HasPrefix(unit, "hour"), strings.HasPrefix(unit, "hr"):
-		return now.Add(time.Duration(amount) * time.Hour), nil
-	case strings.HasPrefix(unit, "day"):
-		return now.AddDate(0, 0, amount), nil
-	case strings.HasPrefix(unit, "week"):
-		return now.AddDate(0, 0, amount*7), nil
-	case strings.HasPrefix(unit, "month"):
-		return now.AddDate(0, amount, 0), nil
+	case len(words) == 1:
+		unit = endOfAbbreviations[words[0]]
+	case len(words) >= 3 && words[0] == "end" && words[1] == "of":
+		rest := words[2:]
+		if len(rest) >= 2 && (rest[0] == "this" || rest[0] == "next") {
+			if rest[0] == "next" {
+				ahead = 1
+			}
+			rest = rest[1:]
+		}
+		unit = strings.Join(rest, " ")
+	}
+	if unit == "business week" || unit == "work week" || unit == "working week" || unit == "workweek" {
+		return p.endOfWorkWeek(now, ahead)
 	}
 
-	return time.Time{}, fmt.Errorf("unknown unit: %s", unit)
+	var day time.Time
+	switch unit {
+	case "day":
+		day = now.AddDate(0, 0, ahead)
+	case "week":
+		day = now.AddDate(0, 0, daysUntil(now, time.Friday, true)+7*ahead)
+	case "month":
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		day = first.AddDate(0, ahead+1, -1)
+	case "quarter":
+		day = quarterStart(now).AddDate(0, 3*(ahead+1), -1)
+	case "year":
+		day = time.Date(now.Year()+ahead, time.December, 31, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}, false
+	}
+	return todayAt(day, p.endOfDayHour, 0), true
 }
 
-var weekdays = map[string]time.Weekday{
-	"sunday":    time.Sunday,
-	"monday":    time.Monday,
-	"tuesday":   time.Tuesday,
-	"wednesday": time.Wednesday,
-	"thursday":  time.Thursday,
-	"friday":    time.Friday,
-	"saturday":  time.Saturday,
-	"sun":       time.Sunday,
-	"mon":       time.Monday,
-	"tue":       time.Tuesday,
-	"wed":       time.Wednesday,
-	"thu":       time.Thursday,
-	"fri":       time.Friday,
-	"sat":       time.Saturday,
+// endOfWorkWeek returns the last working day of the Monday-to-Sunday week
+// that is ahead weeks away, at the end-of-day hour. When that day has
+// already passed, it moves to the next week that has one.
+func (p *Parser) endOfWorkWeek(now time.Time, ahead int) (time.Time, bool) {
+	today := todayAt(now, 0, 0)
+	monday := today.AddDate(0, 0, -((int(now.Weekday())+6)%7)+7*ahead)
+	for week := 0; week < 52; week++ {
+		for d := 6; d >= 0; d-- {
+			day := monday.AddDate(0, 0, 7*week+d)
+			if p.calendar.IsWorkday(day) {
+				if day.Before(today) {
+					break
+				}
+				return todayAt(day, p.endOfDayHour, 0), true
+			}
+		}
+	}
+	return time.Time{}, false
 }
 
-func parseNextWeekday(input string, now time.Time) (time.Time, error) {
-	// Match "next monday", "next tuesday at 2pm", etc.
-	parts := strings.Fields(input)
-	if len(parts) < 2 || parts[0] != "next" {
-		return time.Time{}, fmt.Errorf("not a next weekday expression")
+// ruleDayOfMonth handles "the 15th" and "15th": the next such day, today
+// included, skipping months that are too short.
+func ruleDayOfMonth(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	hasThe := words[0] == "the"
+	words = dropWords(words, "the")
+	if len(words) != 1 || (!hasThe && !ordinalDayPattern.MatchString(words[0])) {
+		return time.Time{}, false
+	}
+	day, err := parseDayOfMonth(words[0])
+	if err != nil {
+		return time.Time{}, false
+	}
+	for i := 0; i < 12; i++ {
+		first := time.Date(now.Year(), now.Month()+time.Month(i), 1, 0, 0, 0, 0, now.Location())
+		t := time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, now.Location())
+		if t.Month() != first.Month() || (i == 0 && day < now.Day()) {
+			continue
+		}
+		return p.dayAt(t), true
 	}
+	return time.Time{}, false
+}
 
-	dayName := parts[1]
-	targetDay, ok := weekdays[dayName]
+// ruleMonthDay handles "march 3", "3 march", "the 3rd of march" and the
+// same with a year. Without a year it is the next such date, today included.
+func ruleMonthDay(p *Parser, words []string, now time.Time) (time.Time, bool) {
+	words = dropWords(words, "the", "of")
+	if len(words) != 2 && len(words) != 3 {
+		return time.Time{}, false
+	}
+	month, ok := months[strings.TrimSuffix(words[0], ".")]
+	dayStr := words[1]
+	if !ok {
+		month, ok = months[strings.TrimSuffix(words[1], ".")]
+		dayStr = words[0]
+	}
 	if !ok {
-		return time.Time{}, fmt.Errorf("unknown weekday: %s", dayName)
+		return time.Time{}, false
+	}
+	day, err := parseDayOfMonth(dayStr)
+	if err != nil {
+		return time.Time{}, false
 	}
 
-	daysAhead := int(targetDay) - int(now.Weekday())
-	if daysAhead <= 0 {
-		daysAhead += 7
+	year, explicitYear := now.Year(), len(words) == 3
+	if explicitYear {
+		if !yearPattern.MatchString(words[2]) {
+			return time.Time{}, false
+		}
+		year, _ = strconv.Atoi(words[2])
 	}
 
-	targetDate := now.AddDate(0, 0, daysAhead)
-	result := todayAt(targetDate, 9, 0) // default 9 AM
+	t := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	if t.Day() != day {
+		return time.Time{}, false
+	}
+	if !explicitYear && t.Before(todayAt(now, 0, 0)) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return p.dayAt(t), true
+}
 
-	// Check for "at" time specification
-	if len(parts) >= 4 && parts[2] == "at" {
-		timeStr := strings.Join(parts[3:], " ")
-		hour, min, err := parseTimeStr(timeStr)
-		if err == nil {
-			result = todayAt(targetDate, hour, min)
+var (
+	ordinalDayPattern = regexp.MustCompile(`^\d{1,2}(st|nd|rd|th)$`)
+	yearPattern       = regexp.MustCompile(`^\d{4}$`)
+	compactOffset     = regexp.MustCompile(`^(?:\d+[a-z]+)+$`)
+	compactOffsetPart = regexp.MustCompile(`(\d+)([a-z]+)`)
+	bareHourPattern   = regexp.MustCompile(`^\d{1,2}$`)
+)
+
+// offset is a calendar-aware amount of time, like "1 month and 2 days"
+// or "3 business days".
+type offset struct {
+	months, days, workdays int
+	clock                  time.Duration
+}
+
+// shift moves t by o, counting working days on the parser's calendar.
+func (p *Parser) shift(t time.Time, o offset) time.Time {
+	t = t.AddDate(0, o.months, o.days).Add(o.clock)
+	return p.calendar.AddWorkdays(t, o.workdays)
+}
+
+func (o offset) negate() offset {
+	return offset{months: -o.months, days: -o.days, workdays: -o.workdays, clock: -o.clock}
+}
+
+// offsetUnits maps unit names to the offset of one unit.
+var offsetUnits = map[string]offset{
+	"m": {clock: time.Minute}, "min": {clock: time.Minute}, "mins": {clock: time.Minute},
+	"minute": {clock: time.Minute}, "minutes": {clock: time.Minute},
+	"h": {clock: time.Hour}, "hr": {clock: time.Hour}, "hrs": {clock: time.Hour},
+	"hour": {clock: time.Hour}, "hours": {clock: time.Hour},
+	"d": {days: 1}, "day": {days: 1}, "days": {days: 1},
+	"w": {days: 7}, "wk": {days: 7}, "wks": {days: 7}, "week": {days: 7}, "weeks": {days: 7},
+	"fortnight": {days: 14}, "fortnights": {days: 14},
+	"mo": {months: 1}, "month": {months: 1}, "months": {months: 1},
+	"quarter": {months: 3}, "quarters": {months: 3},
+	"y": {months: 12}, "yr": {months: 12}, "yrs": {months: 12},
+	"year": {months: 12}, "years": {months: 12},
+	"workday": {workdays: 1}, "workdays": {workdays: 1},
+}
+
+// workdayQualifiers turn a following "day" or "days" into a working day.
+var workdayQualifiers = map[string]bool{"business": true, "working": true, "work": true}
+
+// lookupUnit reads the unit at the start of words, returning it and the
+// number of words it spans.
+func lookupUnit(words []string) (offset, int, bool) {
+	if len(words) == 0 {
+		return offset{}, 0, false
+	}
+	if len(words) >= 2 && workdayQualifiers[words[0]] && (words[1] == "day" || words[1] == "days") {
+		return offset{workdays: 1}, 2, true
+	}
+	unit, ok := offsetUnits[words[0]]
+	return unit, 1, ok
+}
+
+var numberWords = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+// parseOffset parses an amount of time such as "3 days", "a fortnight",
+// "1 week and 2 days", "2h30m" or "3 business days". All words must be consumed.
+func parseOffset(words []string) (offset, bool) {
+	var total offset
+	found := false
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if w == "and" && found {
+			continue
+		}
+
+		if compactOffset.MatchString(w) {
+			for _, m := range compactOffsetPart.FindAllStringSubmatch(w, -1) {
+				n, _ := strconv.Atoi(m[1])
+				unit, ok := offsetUnits[m[2]]
+				if !ok {
+					return offset{}, false
+				}
+				total = total.plus(unit, n)
+			}
+			found = true
+			continue
+		}
+
+		n, ok := numberWords[w]
+		if !ok {
+			var err error
+			if n, err = strconv.Atoi(w); err != nil {
+				return offset{}, false
+			}
+		}
+		unit, span, ok := lookupUnit(words[i+1:])
+		if !ok {
+			return offset{}, false
 		}
+		total = total.plus(unit, n)
+		found = true
+		i += span
 	}
+	return total, found
+}
 
-	return result, nil
+func (o offset) plus(unit offset, n int) offset {
+	return offset{
+		months:   o.months + unit.months*n,
+		days:     o.days + unit.days*n,
+		workdays: o.workdays + unit.workdays*n,
+		clock:    o.clock + unit.clock*time.Duration(n),
+	}
+}
+
+type clockTime struct {
+	hour, min int
 }
 
-func parseDateWithTime(input string, now time.Time) (time.Time, error) {
-	// Handle "today at 5pm", "tomorrow at 3:30pm"
-	parts := strings.SplitN(input, " at ", 2)
-	if len(parts) != 2 {
-		return time.Time{}, fmt.Errorf("not a date with time")
+// splitTimeOfDay separates a time of day from the rest of the expression.
+// The time is introduced by "at" or stands at the start or end
+// ("friday 3pm", "noon tomorrow").
+func splitTimeOfDay(words []string) ([]string, *clockTime, error) {
+	for i := len(words) - 1; i >= 0; i-- {
+		if words[i] != "at" {
+			continue
+		}
+		timeStr := strings.Join(words[i+1:], " ")
+		hour, min, err := parseAtTime(timeStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid time %q: %w", timeStr, err)
+		}
+		return words[:i], &clockTime{hour, min}, nil
 	}
 
-	datePart := strings.TrimSpace(parts[0])
-	timePart := strings.TrimSpace(parts[1])
+	for _, n := range []int{2, 1} {
+		if len(words) < n {
+			continue
+		}
+		if hour, min, err := parseTimeStr(strings.Join(words[len(words)-n:], " ")); err == nil {
+			return words[:len(words)-n], &clockTime{hour, min}, nil
+		}
+		if hour, min, err := parseTimeStr(strings.Join(words[:n], " ")); err == nil {
+			return words[n:], &clockTime{hour, min}, nil
+		}
+	}
+	return words, nil, nil
+}
 
-	var baseDate time.Time
-	switch datePart {
-	case "today":
-		baseDate = now
-	case "tomorrow":
-		baseDate = now.AddDate(0, 0, 1)
-	case "yesterday":
-		baseDate = now.AddDate(0, 0, -1)
-	default:
-		return time.Time{}, fmt.Errorf("unknown date base: %s", datePart)
+// parseAtTime parses the time after "at", which may also be a bare hour
+// on the 24-hour clock ("at 9", "at 17").
+func parseAtTime(s string) (int, int, error) {
+	s = strings.TrimSpace(s)
+	if bareHourPattern.MatchString(s) {
+		hour, _ := strconv.Atoi(s)
+		if hour > 23 {
+			return 0, 0, fmt.Errorf("invalid hour: %d", hour)
+		}
+		return hour, 0, nil
 	}
+	return parseTimeStr(s)
+}
 
-	hour, min, err := parseTimeStr(timePart)
-	if err != nil {
-		return time.Time{}, err
+// daysUntil returns the number of days from now to the next given weekday.
+// When strict is set, today does not count.
+func daysUntil(now time.Time, day time.Weekday, strict bool) int {
+	n := (int(day) - int(now.Weekday()) + 7) % 7
+	if n == 0 && strict {
+		n = 7
 	}
+	return n
+}
 
-	return todayAt(baseDate, hour, min), nil
+// quarterStart returns midnight on the first day of now's quarter.
+func quarterStart(now time.Time) time.Time {
+	month := time.Month((int(now.Month())-1)/3*3 + 1)
+	return time.Date(now.Year(), month, 1, 0, 0, 0, 0, now.Location())
 }
 
-func parseTimeOnly(input string, now time.Time) (time.Time, error) {
-	hour, min, err := parseTimeStr(input)
-	if err != nil {
-		return time.Time{}, err
+// dropWords returns words without any of the given filler words.
+func dropWords(words []string, filler ...string) []string {
+	result := make([]string, 0, len(words))
+	for _, w := range words {
+		if !slices.Contains(filler, w) {
+			result = append(result, w)
+		}
 	}
+	return result
+}
+
+// zonedFormats carry their own UTC offset.
+var zonedFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04Z07:00",
+}
 
-	result := todayAt(now, hour, min)
-	// If the time has already passed today, schedule for tomorrow
-	if result.Before(now) {
-		result = result.AddDate(0, 0, 1)
+// tryStandardFormats returns the time and the layout that matched.
+func tryStandardFormats(input string, loc *time.Location) (time.Time, string, error) {
+	for _, f := range zonedFormats {
+		if t, err := time.Parse(f, input); err == nil {
+			return t, f, nil
+		}
 	}
 
-	return result, nil
+	formats := []string{
+		"2006-01-02",
+		"2006-01-02 15:04",
+		"2006-01-02 3:04PM",
+		"2006-01-02 3:04pm",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"01/02/2006",
+		"01/02/2006 15:04",
+		"01/02/2006 3:04PM",
+		"Jan 2, 2006",
+		"Jan 2, 2006 3:04PM",
+		"Jan 2, 2006 15:04",
+		"January 2, 2006",
+		"January 2, 2006 3:04PM",
+		"January 2, 2006 15:04",
+		"2 Jan 2006",
+		"02 Jan 2006",
+	}
+
+	for _, f := range formats {
+		t, err := time.ParseInLocation(f, input, loc)
+		if err == nil {
+			return t, f, nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("no standard format matched")
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+	"sun":       time.Sunday,
+	"mon":       time.Monday,
+	"tue":       time.Tuesday,
+	"wed":       time.Wednesday,
+	"thu":       time.Thursday,
+	"fri":       time.Friday,
+	"sat":       time.Saturday,
+	"tues":      time.Tuesday,
+	"thur":      time.Thursday,
+	"thurs":     time.Thursday,
 }
 
 var timePatterns = []struct {
@@ -264,6 +837,12 @@ var timePatterns = []struct {
 
 func parseTimeStr(s string) (int, int, error) {
 	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "noon", "midday":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
 	for _, p := range timePatterns {
 		matches := p.re.FindStringSubmatch(s)
 		if matches != nil {
@@ -183,3 +183,235 @@ func TestParseDateTimeOnly(t *testing.T) {
 		})
 	}
 }
+
+func TestParserFixedClock(t *testing.T) {
+	day := func(month time.Month, d, hour, min int) time.Time {
+		return time.Date(2026, month, d, hour, min, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		input string
+		opts  []Option
+		want  time.Time
+	}{
+		{"today", nil, day(2, 11, 9, 0)},
+		{"tomorrow", nil, day(2, 12, 9, 0)},
+		{"yesterday", nil, day(2, 10, 9, 0)},
+		{"5pm", nil, day(2, 11, 17, 0)},
+		{"9am", nil, day(2, 12, 9, 0)},
+		{"in 3 hours", nil, day(2, 11, 13, 0)},
+		{"next monday", nil, day(2, 16, 9, 0)},
+		{"next wednesday", nil, day(2, 18, 9, 0)},
+		{"next friday at 3:30pm", nil, day(2, 13, 15, 30)},
+		{"next month", nil, day(3, 11, 9, 0)},
+		{"eod", nil, day(2, 11, 17, 0)},
+		{"eow", nil, day(2, 13, 17, 0)},
+		{"tomorrow", []Option{WithDefaultTime(8, 30)}, day(2, 12, 8, 30)},
+		{"next week", []Option{WithDefaultTime(7, 0)}, day(2, 18, 7, 0)},
+		{"eod", []Option{WithEndOfDay(18)}, day(2, 11, 18, 0)},
+		{"end of week", []Option{WithEndOfDay(16)}, day(2, 13, 16, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := New(append([]Option{WithNow(fixedNow)}, tt.opts...)...)
+			got, err := p.ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateAtLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+9", 9*3600)
+	// 20:00 UTC on Feb 11 is already Feb 12 in UTC+9.
+	now := time.Date(2026, 2, 11, 20, 0, 0, 0, time.UTC)
+
+	got, err := ParseDateAt("tomorrow", now, loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, 2, 13, 9, 0, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("tomorrow = %v, want %v", got, want)
+	}
+
+	got, err = ParseDateAt("2026-03-01 10:00", now, loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = time.Date(2026, 3, 1, 10, 0, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("standard format = %v, want %v", got, want)
+	}
+}
+
+func TestParserClock(t *testing.T) {
+	now := fixedNow
+	p := New(WithClock(func() time.Time { return now }))
+
+	first, _ := p.ParseDate("in 1 hour")
+	now = now.Add(24 * time.Hour)
+	second, _ := p.ParseDate("in 1 hour")
+
+	if got := second.Sub(first); got != 24*time.Hour {
+		t.Errorf("clock not consulted per call: results differ by %v", got)
+	}
+}
+
+func TestParseDateGrammar(t *testing.T) {
+	at := func(year int, month time.Month, d, hour, min int) time.Time {
+		return time.Date(year, month, d, hour, min, 0, 0, time.Local)
+	}
+	p := New(WithNow(fixedNow)) // Wednesday, Feb 11 2026 10:00
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		// weekdays
+		{"friday", at(2026, 2, 13, 9, 0)},
+		{"friday 3pm", at(2026, 2, 13, 15, 0)},
+		{"3pm friday", at(2026, 2, 13, 15, 0)},
+		{"this friday at 3:30 pm", at(2026, 2, 13, 15, 30)},
+		{"by friday", at(2026, 2, 13, 9, 0)},
+		{"wednesday", at(2026, 2, 11, 9, 0)},
+		{"next wednesday", at(2026, 2, 18, 9, 0)},
+		{"this weekend", at(2026, 2, 14, 9, 0)},
+
+		// periods
+		{"end of month", at(2026, 2, 28, 17, 0)},
+		{"eom", at(2026, 2, 28, 17, 0)},
+		{"end of the next month", at(2026, 3, 31, 17, 0)},
+		{"end of quarter", at(2026, 3, 31, 17, 0)},
+		{"end of year", at(2026, 12, 31, 17, 0)},
+		{"next quarter", at(2026, 4, 1, 9, 0)},
+		{"next year", at(2027, 2, 11, 9, 0)},
+
+		// days of the month and calendar dates
+		{"the 15th", at(2026, 2, 15, 9, 0)},
+		{"15th at 2pm", at(2026, 2, 15, 14, 0)},
+		{"the 10th", at(2026, 3, 10, 9, 0)},
+		{"the 31st", at(2026, 3, 31, 9, 0)},
+		{"march 3", at(2026, 3, 3, 9, 0)},
+		{"3 march", at(2026, 3, 3, 9, 0)},
+		{"the 3rd of march at 5pm", at(2026, 3, 3, 17, 0)},
+		{"march 3 2027", at(2027, 3, 3, 9, 0)},
+		{"feb 1", at(2027, 2, 1, 9, 0)},
+
+		// offsets
+		{"in 2h30m", at(2026, 2, 11, 12, 30)},
+		{"in a fortnight", at(2026, 2, 25, 10, 0)},
+		{"in 1 week and 2 days", at(2026, 2, 20, 10, 0)},
+		{"in 3 days at 5pm", at(2026, 2, 14, 17, 0)},
+		{"3 days from now", at(2026, 2, 14, 10, 0)},
+		{"3 days ago", at(2026, 2, 8, 10, 0)},
+		{"2 weeks after next monday", at(2026, 3, 2, 9, 0)},
+		{"a week from tomorrow", at(2026, 2, 19, 9, 0)},
+		{"2 days before the 15th", at(2026, 2, 13, 9, 0)},
+
+		// times of day
+		{"noon", at(2026, 2, 11, 12, 0)},
+		{"midnight", at(2026, 2, 12, 0, 0)},
+		{"tonight", at(2026, 2, 11, 20, 0)},
+		{"tomorrow noon", at(2026, 2, 12, 12, 0)},
+		{"day after tomorrow", at(2026, 2, 13, 9, 0)},
+		{"at 9", at(2026, 2, 12, 9, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := p.ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateGrammarErrors(t *testing.T) {
+	p := New(WithNow(fixedNow))
+	for _, input := range []string{
+		"blursday",
+		"friday at 25pm",
+		"the 32nd",
+		"february 30",
+		"in 3 parsecs",
+		"3 days after never",
+	} {
+		if got, err := p.ParseDate(input); err == nil {
+			t.Errorf("ParseDate(%q) = %v, want error", input, got)
+		}
+	}
+}
+
+func TestParseDateNextWeekend(t *testing.T) {
+	saturday := time.Date(2026, 2, 14, 10, 0, 0, 0, time.Local)
+	p := New(WithNow(saturday))
+
+	for input, want := range map[string]time.Time{
+		"this weekend": time.Date(2026, 2, 14, 9, 0, 0, 0, time.Local),
+		"next weekend": time.Date(2026, 2, 21, 9, 0, 0, 0, time.Local),
+	} {
+		got, err := p.ParseDate(input)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) error: %v", input, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestParseDateBusinessDays(t *testing.T) {
+	at := func(month time.Month, d, hour, min int) time.Time {
+		return time.Date(2026, month, d, hour, min, 0, 0, time.Local)
+	}
+	holidays := NewCalendar()
+	holidays.AddHoliday(at(2, 13, 0, 0), "Company day")
+	fourDay := NewCalendar()
+	fourDay.SetWorkdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday)
+
+	tests := []struct {
+		input    string
+		calendar *Calendar
+		want     time.Time
+	}{
+		{"in 3 business days", nil, at(2, 16, 10, 0)},
+		{"in 1 working day at 5pm", nil, at(2, 12, 17, 0)},
+		{"next workday", nil, at(2, 12, 9, 0)},
+		{"next business day", nil, at(2, 12, 9, 0)},
+		{"end of business week", nil, at(2, 13, 17, 0)},
+		{"end of next work week", nil, at(2, 20, 17, 0)},
+		{"2 workdays before friday", nil, at(2, 11, 9, 0)},
+		{"5 business days from now", nil, at(2, 18, 10, 0)},
+		{"in 3 business days", holidays, at(2, 17, 10, 0)},
+		{"end of business week", holidays, at(2, 12, 17, 0)},
+		{"end of business week", fourDay, at(2, 12, 17, 0)},
+		{"next workday", fourDay, at(2, 12, 9, 0)},
+		{"in 2 workdays", fourDay, at(2, 16, 10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			opts := []Option{WithNow(fixedNow)}
+			if tt.calendar != nil {
+				opts = append(opts, WithCalendar(tt.calendar))
+			}
+			got, err := New(opts...).ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	p := New(WithNow(fixedNow)) // Wednesday, Feb 11 2026 10:00
+
+	tests := []struct {
+		input    string
+		rules    []string
+		warnings []string
+	}{
+		{"2026-02-15", []string{"standard format (2006-01-02)"}, []string{"no time given, using midnight"}},
+		{"2026-02-15 14:30", []string{"standard format (2006-01-02 15:04)"}, nil},
+		{"5pm", []string{"time only"}, nil},
+		{"9am", []string{"time only"}, []string{"09:00 has already passed today, scheduled for tomorrow"}},
+		{"next friday at 2pm", []string{"next weekday", "time of day"}, nil},
+		{"wednesday", []string{"weekday"}, []string{`wednesday is today; use "next wednesday" for next week`, "Wed Feb 11 09:00 is in the past"}},
+		{"in 3 days", []string{"relative offset"}, nil},
+		{"3 days ago", []string{"ago"}, nil},
+		{"2 weeks after next monday", []string{"offset after date", "next weekday"}, nil},
+		{"jan 5", []string{"month and day"}, []string{"January 5 has passed this year, using 2027"}},
+		{"tomorrow 3pm PST", []string{"time zone (PST)", "relative day", "time of day"}, nil},
+		{"today", []string{"relative day"}, []string{"Wed Feb 11 09:00 is in the past"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			e, err := p.Explain(tt.input)
+			if err != nil {
+				t.Fatalf("Explain(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(e.Rules, tt.rules) {
+				t.Errorf("Explain(%q) rules = %q, want %q", tt.input, e.Rules, tt.rules)
+			}
+			if !reflect.DeepEqual(e.Warnings, tt.warnings) {
+				t.Errorf("Explain(%q) warnings = %q, want %q", tt.input, e.Warnings, tt.warnings)
+			}
+			if want, _ := p.ParseDate(tt.input); !e.Time.Equal(want) {
+				t.Errorf("Explain(%q) time = %v, ParseDate = %v", tt.input, e.Time, want)
+			}
+		})
+	}
+}
+
+func TestExplainError(t *testing.T) {
+	_, err := New(WithNow(fixedNow)).Explain("blursday")
+	if err == nil || !strings.Contains(err.Error(), "unable to parse date") {
+		t.Errorf("Explain error = %v, want unable to parse date", err)
+	}
+}
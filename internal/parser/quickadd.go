@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// QuickAdd is a reminder read from a single line of text by ParseQuickAdd.
+type QuickAdd struct {
+	Title    string
+	ListName string
+	Priority reminder.Priority
+	Tags     []string
+	URL      string
+	Due      *time.Time
+	// DueText is the part of the input the due date was read from.
+	DueText string
+}
+
+var (
+	urlPattern      = regexp.MustCompile(`^(https?://|www\.)\S+$`)
+	bangsPriorities = map[string]reminder.Priority{
+		"!!!": reminder.PriorityHigh,
+		"!!":  reminder.PriorityMedium,
+		"!1":  reminder.PriorityHigh,
+		"!2":  reminder.PriorityMedium,
+		"!3":  reminder.PriorityLow,
+	}
+)
+
+// ParseQuickAdd reads a reminder from one line of text such as
+// "Buy milk tomorrow 5pm @Groceries !high #errand". It picks out:
+//
+//	@List             the list (underscores stand for spaces: @Home_Stuff)
+//	!high, !!, !1     the priority (also !medium, !low, !!!, !2, !3)
+//	#tag              tags
+//	https://...       a URL
+//
+// and the longest run of words that parses as a date, which becomes the
+// due date. The remaining words are the title.
+func (p *Parser) ParseQuickAdd(input string) (*QuickAdd, error) {
+	q := &QuickAdd{}
+	var words []string
+	for _, w := range strings.Fields(input) {
+		switch {
+		case len(w) > 1 && w[0] == '@':
+			if q.ListName != "" {
+				return nil, fmt.Errorf("more than one list: @%s and %s", q.ListName, w)
+			}
+			q.ListName = strings.ReplaceAll(w[1:], "_", " ")
+		case reminder.IsTag(w):
+			q.Tags = append(q.Tags, w[1:])
+		case urlPattern.MatchString(w):
+			q.URL = w
+		default:
+			if priority, ok := parseBangPriority(w); ok {
+				q.Priority = priority
+				continue
+			}
+			words = append(words, w)
+		}
+	}
+
+	if start, end, due, ok := p.findDate(words); ok {
+		q.Due = &due
+		q.DueText = strings.Join(words[start:end], " ")
+		words = append(words[:start:start], words[end:]...)
+	}
+
+	q.Title = strings.Join(words, " ")
+	if q.Title == "" {
+		return nil, fmt.Errorf("quick add %q has no title", input)
+	}
+	return q, nil
+}
+
+// parseBangPriority reads "!high", "!!", "!1" and the like.
+func parseBangPriority(w string) (reminder.Priority, bool) {
+	if priority, ok := bangsPriorities[w]; ok {
+		return priority, true
+	}
+	name, ok := strings.CutPrefix(strings.ToLower(w), "!")
+	if !ok {
+		return reminder.PriorityNone, false
+	}
+	switch name {
+	case "high", "medium", "med", "low", "5", "9":
+		return reminder.ParsePriority(name), true
+	case "none":
+		return reminder.PriorityNone, true
+	}
+	return reminder.PriorityNone, false
+}
+
+// findDate returns the longest run of words that parses as a date,
+// preferring the rightmost one when there are several of the same length.
+func (p *Parser) findDate(words []string) (int, int, time.Time, bool) {
+	for size := len(words); size > 0; size-- {
+		for start := len(words) - size; start >= 0; start-- {
+			t, err := p.ParseDate(strings.Join(words[start:start+size], " "))
+			if err == nil {
+				return start, start + size, t, true
+			}
+		}
+	}
+	return 0, 0, time.Time{}, false
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	p := New(WithNow(fixedNow)) // Wednesday, Feb 11 2026 10:00
+	at := func(month time.Month, d, hour, min int) *time.Time {
+		t := time.Date(2026, month, d, hour, min, 0, 0, time.Local)
+		return &t
+	}
+
+	tests := []struct {
+		input string
+		want  QuickAdd
+	}{
+		{
+			"Buy milk tomorrow 5pm @Groceries !high #errand",
+			QuickAdd{Title: "Buy milk", ListName: "Groceries", Priority: reminder.PriorityHigh,
+				Tags: []string{"errand"}, Due: at(2, 12, 17, 0), DueText: "tomorrow 5pm"},
+		},
+		{
+			"!! Review https://github.com/org/repo/pull/1 by friday at 3pm @Work",
+			QuickAdd{Title: "Review", ListName: "Work", Priority: reminder.PriorityMedium,
+				URL: "https://github.com/org/repo/pull/1", Due: at(2, 13, 15, 0), DueText: "by friday at 3pm"},
+		},
+		{
+			"Call dentist in 2 days !1",
+			QuickAdd{Title: "Call dentist", Priority: reminder.PriorityHigh,
+				Due: at(2, 13, 10, 0), DueText: "in 2 days"},
+		},
+		{
+			"Water plants @Home_Stuff #garden #weekly",
+			QuickAdd{Title: "Water plants", ListName: "Home Stuff", Tags: []string{"garden", "weekly"}},
+		},
+		{
+			"Wow! march 3 party !low",
+			QuickAdd{Title: "Wow! party", Priority: reminder.PriorityLow, Due: at(3, 3, 9, 0), DueText: "march 3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := p.ParseQuickAdd(tt.input)
+			if err != nil {
+				t.Fatalf("ParseQuickAdd(%q) error: %v", tt.input, err)
+			}
+			if got.Due != nil && tt.want.Due != nil && got.Due.Equal(*tt.want.Due) {
+				got.Due = tt.want.Due
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("ParseQuickAdd(%q) =\n  %+v\nwant\n  %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuickAddErrors(t *testing.T) {
+	p := New(WithNow(fixedNow))
+	for _, input := range []string{
+		"tomorrow 5pm @Groceries",
+		"Buy milk @Groceries @Errands",
+		"",
+	} {
+		if got, err := p.ParseQuickAdd(input); err == nil {
+			t.Errorf("ParseQuickAdd(%q) = %+v, want error", input, got)
+		}
+	}
+}
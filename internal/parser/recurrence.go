@@ -0,0 +1,368 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// ParseRepeat parses a recurrence given either as a raw RFC 5545 RRULE
+// ("FREQ=WEEKLY;BYDAY=TU,TH") or in natural language ("every 2 weeks on
+// tue,thu", "daily", "weekdays", "every monday until 2026-06-01").
+func ParseRepeat(input string) (*reminder.Recurrence, error) {
+	rule, _, err := ParseRecurrence(input, time.Now())
+	return rule, err
+}
+
+// ParseRecurrence parses a natural language recurrence such as "every day",
+// "every other friday", "every 3 months on the 15th", "weekdays at 8:30am"
+// or "last day of every month", or a raw RRULE. It returns the rule and the
+// first occurrence at or after now. Occurrences fall at the time given with
+// "at" (default 9:00 AM).
+func ParseRecurrence(input string, now time.Time) (*reminder.Recurrence, time.Time, error) {
+	return New(WithNow(now), WithLocation(now.Location())).ParseRecurrence(input)
+}
+
+// ParseRecurrence parses a recurrence relative to the parser's reference
+// time. End dates are parsed with the same clock and location, and rules
+// without an "at" time use the parser's default time of day.
+func (p *Parser) ParseRecurrence(input string) (*reminder.Recurrence, time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, time.Time{}, fmt.Errorf("empty recurrence")
+	}
+	now := p.Now()
+
+	upper := strings.ToUpper(input)
+	if strings.HasPrefix(upper, "RRULE:") || strings.HasPrefix(upper, "FREQ=") {
+		rule, err := reminder.ParseRRule(input, now.Location())
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		first, err := firstOccurrence(rule, now, p.defaultHour, p.defaultMinute)
+		return rule, first, err
+	}
+
+	lower := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+
+	// End conditions and time of day trail the rule:
+	// "<rule> [at <time>] [until <date> | [for] N times]"
+	var until *time.Time
+	if rest, dateStr, ok := strings.Cut(lower, " until "); ok {
+		t, err := p.ParseDate(dateStr)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid end date %q in recurrence: %w", dateStr, err)
+		}
+		until = &t
+		lower = rest
+	}
+	count := 0
+	if m := recurrenceCountPattern.FindStringSubmatch(lower); m != nil {
+		count, _ = strconv.Atoi(m[2])
+		if count < 1 {
+			return nil, time.Time{}, fmt.Errorf("occurrence count must be at least 1: %d", count)
+		}
+		lower = m[1]
+	}
+	hour, min := p.defaultHour, p.defaultMinute
+	if rest, timeStr, ok := strings.Cut(lower, " at "); ok {
+		h, m, err := parseAtTime(timeStr)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid time %q in recurrence: %w", timeStr, err)
+		}
+		hour, min = h, m
+		lower = rest
+	}
+
+	rule, err := parseRecurrenceRule(lower)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if count > 0 && until != nil {
+		return nil, time.Time{}, fmt.Errorf("recurrence cannot have both an end date and a count")
+	}
+	rule.Until = until
+	rule.Count = count
+
+	first, err := firstOccurrence(rule, now, hour, min)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return rule, first, nil
+}
+
+// firstOccurrence returns the first occurrence of rule at hour:min that is
+// not before now. The series is anchored on the first such time, so rules
+// without a day constraint repeat on that day.
+func firstOccurrence(rule *reminder.Recurrence, now time.Time, hour, min int) (time.Time, error) {
+	start := todayAt(now, hour, min)
+	if start.Before(now) {
+		start = start.AddDate(0, 0, 1)
+	}
+	first, ok := rule.Next(start, start.Add(-time.Second))
+	if !ok {
+		return time.Time{}, fmt.Errorf("recurrence %q has no occurrences after %s", rule.String(), now.Format("2006-01-02"))
+	}
+	return first, nil
+}
+
+var (
+	recurrenceCountPattern = regexp.MustCompile(`^(.*?),?\s+(?:for\s+)?(\d+)\s+times$`)
+	everyIntervalPattern   = regexp.MustCompile(`^every (\d+|other) (day|week|month|year)s?(?: on (.+))?$`)
+	everyUnitPattern       = regexp.MustCompile(`^every (day|week|month|year)(?: on (.+))?$`)
+	adverbPattern          = regexp.MustCompile(`^(daily|weekly|monthly|yearly|annually)(?: on (.+))?$`)
+	nthWeekdayPattern      = regexp.MustCompile(`^(?:every |on )?(?:the )?(first|second|third|fourth|fifth|last|1st|2nd|3rd|4th|5th) (\S+?)s?(?: of (?:every|each|the) (?:(\d+|other) )?months?)?$`)
+	lastDayPattern         = regexp.MustCompile(`^(?:every |on )?(?:the )?last day of (?:every|each|the) (?:(\d+|other) )?months?$`)
+	monthDayOfPattern      = regexp.MustCompile(`^(?:every |on )?(?:the )?(\d{1,2}(?:st|nd|rd|th)?) of (?:every|each|the) (?:(\d+|other) )?months?$`)
+	dayOfMonthPattern      = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+)
+
+var recurrenceUnits = map[string]reminder.Frequency{
+	"day":   reminder.FrequencyDaily,
+	"week":  reminder.FrequencyWeekly,
+	"month": reminder.FrequencyMonthly,
+	"year":  reminder.FrequencyYearly,
+}
+
+var recurrenceAdverbs = map[string]reminder.Frequency{
+	"daily":    reminder.FrequencyDaily,
+	"weekly":   reminder.FrequencyWeekly,
+	"monthly":  reminder.FrequencyMonthly,
+	"yearly":   reminder.FrequencyYearly,
+	"annually": reminder.FrequencyYearly,
+}
+
+var ordinals = map[string]int{
+	"first": 1, "1st": 1,
+	"second": 2, "2nd": 2,
+	"third": 3, "3rd": 3,
+	"fourth": 4, "4th": 4,
+	"fifth": 5, "5th": 5,
+	"last": -1,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// parseRecurrenceRule parses the rule part of a recurrence, without time
+// of day or end condition.
+func parseRecurrenceRule(input string) (*reminder.Recurrence, error) {
+	input = strings.Replace(input, "each ", "every ", 1)
+
+	switch input {
+	case "weekdays", "every weekday":
+		return weeklyOn(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday), nil
+	case "weekends", "every weekend":
+		return weeklyOn(time.Saturday, time.Sunday), nil
+	}
+
+	// "last day of every month"
+	if m := lastDayPattern.FindStringSubmatch(input); m != nil {
+		interval, err := parseInterval(m[1])
+		if err != nil {
+			return nil, err
+		}
+		return &reminder.Recurrence{Frequency: reminder.FrequencyMonthly, Interval: interval, ByMonthDay: []int{-1}}, nil
+	}
+
+	// "the 15th of every month"
+	if m := monthDayOfPattern.FindStringSubmatch(input); m != nil {
+		day, err := parseDayOfMonth(m[1])
+		if err != nil {
+			return nil, err
+		}
+		interval, err := parseInterval(m[2])
+		if err != nil {
+			return nil, err
+		}
+		return &reminder.Recurrence{Frequency: reminder.FrequencyMonthly, Interval: interval, ByMonthDay: []int{day}}, nil
+	}
+
+	// "first monday of every month", "every last friday"
+	if m := nthWeekdayPattern.FindStringSubmatch(input); m != nil {
+		n := ordinals[m[1]]
+		day, ok := weekdays[m[2]]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", m[2])
+		}
+		interval, err := parseInterval(m[3])
+		if err != nil {
+			return nil, err
+		}
+		return &reminder.Recurrence{
+			Frequency: reminder.FrequencyMonthly,
+			Interval:  interval,
+			ByDay:     []reminder.WeekdayNum{{N: n, Day: day}},
+		}, nil
+	}
+
+	// "every 3 months on the 15th", "every other week on tue"
+	if m := everyIntervalPattern.FindStringSubmatch(input); m != nil {
+		interval, err := parseInterval(m[1])
+		if err != nil {
+			return nil, err
+		}
+		rule := &reminder.Recurrence{Frequency: recurrenceUnits[m[2]], Interval: interval}
+		return rule, applyOn(rule, m[3])
+	}
+
+	// "every week on monday", "every month on the 1st and 15th"
+	if m := everyUnitPattern.FindStringSubmatch(input); m != nil {
+		rule := &reminder.Recurrence{Frequency: recurrenceUnits[m[1]]}
+		return rule, applyOn(rule, m[2])
+	}
+
+	// "daily", "monthly on the 15th", "yearly on march 3"
+	if m := adverbPattern.FindStringSubmatch(input); m != nil {
+		rule := &reminder.Recurrence{Frequency: recurrenceAdverbs[m[1]]}
+		return rule, applyOn(rule, m[2])
+	}
+
+	// "every monday", "every tue and thu", "every other friday"
+	if rest, ok := strings.CutPrefix(input, "every "); ok {
+		interval := 1
+		if r, ok := strings.CutPrefix(rest, "other "); ok {
+			interval, rest = 2, r
+		}
+		days, err := parseDayList(rest)
+		if err != nil {
+			return nil, err
+		}
+		rule := weeklyOn(days...)
+		rule.Interval = interval
+		return rule, nil
+	}
+
+	return nil, fmt.Errorf("unable to parse recurrence: %q", input)
+}
+
+func parseInterval(s string) (int, error) {
+	switch s {
+	case "":
+		return 1, nil
+	case "other":
+		return 2, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("interval must be a positive number: %q", s)
+	}
+	return n, nil
+}
+
+// applyOn narrows rule with an "on ..." qualifier: weekdays ("tue,thu"),
+// days of the month ("the 1st and 15th", "the last day") or, for yearly
+// rules, a date ("march 3").
+func applyOn(rule *reminder.Recurrence, on string) error {
+	if on == "" {
+		return nil
+	}
+
+	if days, err := parseDayList(on); err == nil {
+		if rule.Frequency == reminder.FrequencyDaily {
+			return fmt.Errorf("weekdays cannot narrow a daily recurrence; use \"every %s\"", on)
+		}
+		for _, d := range days {
+			rule.ByDay = append(rule.ByDay, reminder.WeekdayNum{Day: d})
+		}
+		return nil
+	}
+
+	words := strings.Fields(strings.NewReplacer(",", " ", " and ", " ").Replace(on))
+	if len(words) > 0 {
+		if month, ok := months[words[0]]; ok {
+			if rule.Frequency != reminder.FrequencyYearly {
+				return fmt.Errorf("a month can only narrow a yearly recurrence: %q", on)
+			}
+			if len(words) != 2 {
+				return fmt.Errorf("expected \"<month> <day>\": %q", on)
+			}
+			day, err := parseDayOfMonth(words[1])
+			if err != nil {
+				return err
+			}
+			rule.ByMonth = []int{int(month)}
+			rule.ByMonthDay = []int{day}
+			return nil
+		}
+	}
+
+	if rule.Frequency != reminder.FrequencyMonthly && rule.Frequency != reminder.FrequencyYearly {
+		return fmt.Errorf("days of the month only apply to monthly or yearly recurrences: %q", on)
+	}
+	on = strings.TrimPrefix(on, "the ")
+	if on == "last day" || on == "last" {
+		rule.ByMonthDay = []int{-1}
+		return nil
+	}
+	for _, w := range words {
+		if w == "the" {
+			continue
+		}
+		day, err := parseDayOfMonth(w)
+		if err != nil {
+			return err
+		}
+		rule.ByMonthDay = append(rule.ByMonthDay, day)
+	}
+	return nil
+}
+
+func parseDayOfMonth(s string) (int, error) {
+	m := dayOfMonthPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("unknown day of month %q", s)
+	}
+	day, _ := strconv.Atoi(m[1])
+	if day < 1 || day > 31 {
+		return 0, fmt.Errorf("day of month must be between 1 and 31: %d", day)
+	}
+	return day, nil
+}
+
+func weeklyOn(days ...time.Weekday) *reminder.Recurrence {
+	rule := &reminder.Recurrence{Frequency: reminder.FrequencyWeekly}
+	for _, d := range days {
+		rule.ByDay = append(rule.ByDay, reminder.WeekdayNum{Day: d})
+	}
+	return rule
+}
+
+// parseDayList parses "tue,thu", "mon and wed", "mondays, fridays".
+func parseDayList(s string) ([]time.Weekday, error) {
+	s = strings.ReplaceAll(s, ",", " ")
+	var days []time.Weekday
+	for _, word := range strings.Fields(s) {
+		if word == "and" {
+			continue
+		}
+		d, ok := weekdays[word]
+		if !ok {
+			d, ok = weekdays[strings.TrimSuffix(word, "s")]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", word)
+		}
+		days = append(days, d)
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no weekdays given")
+	}
+	return days, nil
+}
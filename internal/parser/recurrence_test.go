@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedNow is Wednesday, Feb 11 2026 at 10:00 local time.
+var fixedNow = time.Date(2026, 2, 11, 10, 0, 0, 0, time.Local)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		input string
+		rrule string
+		first string
+		desc  string
+	}{
+		{"every day", "FREQ=DAILY", "2026-02-12 09:00", "every day rolls to tomorrow after 9am"},
+		{"daily at 11am", "FREQ=DAILY", "2026-02-11 11:00", "daily later today"},
+		{"every other friday", "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", "2026-02-13 09:00", "every other weekday"},
+		{"every 3 months on the 15th", "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15", "2026-02-15 09:00", "interval with day of month"},
+		{"weekdays at 8:30am", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", "2026-02-12 08:30", "weekdays with time"},
+		{"last day of every month", "FREQ=MONTHLY;BYMONTHDAY=-1", "2026-02-28 09:00", "last day of month"},
+		{"first monday of each month", "FREQ=MONTHLY;BYDAY=1MO", "2026-03-02 09:00", "nth weekday"},
+		{"every last friday", "FREQ=MONTHLY;BYDAY=-1FR", "2026-02-27 09:00", "every last weekday"},
+		{"the 1st of every other month", "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1", "2026-04-01 09:00", "day of month with interval"},
+		{"monthly on the 1st and 15th", "FREQ=MONTHLY;BYMONTHDAY=1,15", "2026-02-15 09:00", "several days of month"},
+		{"every month on the last day", "FREQ=MONTHLY;BYMONTHDAY=-1", "2026-02-28 09:00", "on the last day"},
+		{"yearly on march 3", "FREQ=YEARLY;BYMONTHDAY=3;BYMONTH=3", "2026-03-03 09:00", "yearly date"},
+		{"every 2 weeks on tue,thu", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", "2026-02-12 09:00", "interval with weekdays"},
+		{"every mon and wed at 17:00", "FREQ=WEEKLY;BYDAY=MO,WE", "2026-02-11 17:00", "weekday list with time"},
+		{"each tuesday", "FREQ=WEEKLY;BYDAY=TU", "2026-02-17 09:00", "each as every"},
+		{"every weekend", "FREQ=WEEKLY;BYDAY=SA,SU", "2026-02-14 09:00", "weekends"},
+		{"every year", "FREQ=YEARLY", "2026-02-12 09:00", "every year anchored tomorrow"},
+		{"daily 5 times", "FREQ=DAILY;COUNT=5", "2026-02-12 09:00", "count"},
+		{"every week at 6pm for 3 times", "FREQ=WEEKLY;COUNT=3", "2026-02-11 18:00", "time and count"},
+		{"FREQ=MONTHLY;BYDAY=2TU", "FREQ=MONTHLY;BYDAY=2TU", "2026-03-10 09:00", "raw rrule"},
+		{"RRULE:FREQ=DAILY", "FREQ=DAILY", "2026-02-12 09:00", "rrule prefix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rule, first, err := ParseRecurrence(tt.input, fixedNow)
+			if err != nil {
+				t.Fatalf("unexpected error for input %q: %v", tt.input, err)
+			}
+			if got := rule.RRule(); got != tt.rrule {
+				t.Errorf("ParseRecurrence(%q) rule = %q, want %q", tt.input, got, tt.rrule)
+			}
+			if f := first.Format("2006-01-02 15:04"); f != tt.first {
+				t.Errorf("ParseRecurrence(%q) first = %s, want %s", tt.input, f, tt.first)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceUntil(t *testing.T) {
+	rule, first, err := ParseRecurrence("every monday at 4pm until 2026-03-01", fixedNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.Until == nil || rule.Until.Format("2006-01-02") != "2026-03-01" {
+		t.Errorf("Until = %v, want 2026-03-01", rule.Until)
+	}
+	if f := first.Format("2006-01-02 15:04"); f != "2026-02-16 16:00" {
+		t.Errorf("first = %s, want 2026-02-16 16:00", f)
+	}
+	if n := len(rule.Occurrences(first, first, first.AddDate(1, 0, 0))); n != 2 {
+		t.Errorf("expected 2 occurrences before the end date, got %d", n)
+	}
+}
+
+func TestParseRecurrenceErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"", "empty recurrence"},
+		{"sometimes", `unable to parse recurrence: "sometimes"`},
+		{"every blursday", `unknown weekday "blursday"`},
+		{"every 0 days", `interval must be a positive number: "0"`},
+		{"every day at 25pm", `invalid time "25pm" in recurrence: invalid hour: 25`},
+		{"every month on the 32nd", "day of month must be between 1 and 31: 32"},
+		{"every week on the 15th", `days of the month only apply to monthly or yearly recurrences: "the 15th"`},
+		{"every day on monday", `weekdays cannot narrow a daily recurrence; use "every monday"`},
+		{"monthly on march 3", `a month can only narrow a yearly recurrence: "march 3"`},
+		{"first blursday of every month", `unknown weekday "blursday"`},
+		{"daily until never", `invalid end date "never" in recurrence: unable to parse date: "never"`},
+		{"daily until 2026-01-01", `recurrence "every day, until 2026-01-01" has no occurrences after 2026-02-11`},
+		{"daily 0 times", "occurrence count must be at least 1: 0"},
+		{"FREQ=SECONDLY", `unsupported FREQ "SECONDLY" (use DAILY, WEEKLY, MONTHLY or YEARLY)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, _, err := ParseRecurrence(tt.input, fixedNow)
+			if err == nil {
+				t.Fatalf("expected error for input %q, got nil", tt.input)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("error for %q = %q, want %q", tt.input, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRepeat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"weekly", "FREQ=WEEKLY"},
+		{"every 6 months", "FREQ=MONTHLY;INTERVAL=6"},
+		{"every tue, thu", "FREQ=WEEKLY;BYDAY=TU,TH"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1", "FREQ=MONTHLY;BYMONTHDAY=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rule, err := ParseRepeat(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error for input %q: %v", tt.input, err)
+			}
+			if got := rule.RRule(); got != tt.want {
+				t.Errorf("ParseRepeat(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
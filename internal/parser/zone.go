@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// zoneAbbreviations maps common zone abbreviations to their UTC offsets.
+// Abbreviations that name a region rather than an offset ("PT", "ET")
+// follow daylight saving time through the matching IANA zone.
+var zoneAbbreviations = map[string]int{
+	"utc": 0, "gmt": 0, "z": 0,
+	"pst": -8 * 3600, "pdt": -7 * 3600,
+	"mst": -7 * 3600, "mdt": -6 * 3600,
+	"cst": -6 * 3600, "cdt": -5 * 3600,
+	"est": -5 * 3600, "edt": -4 * 3600,
+	"akst": -9 * 3600, "akdt": -8 * 3600,
+	"hst": -10 * 3600,
+	"bst": 1 * 3600, "wet": 0, "west": 1 * 3600,
+	"cet": 1 * 3600, "cest": 2 * 3600,
+	"eet": 2 * 3600, "eest": 3 * 3600,
+	"ist": 5*3600 + 1800,
+	"sgt": 8 * 3600, "hkt": 8 * 3600,
+	"jst": 9 * 3600, "kst": 9 * 3600,
+	"aest": 10 * 3600, "aedt": 11 * 3600,
+	"nzst": 12 * 3600, "nzdt": 13 * 3600,
+}
+
+var regionAbbreviations = map[string]string{
+	"pt": "America/Los_Angeles",
+	"mt": "America/Denver",
+	"ct": "America/Chicago",
+	"et": "America/New_York",
+}
+
+var (
+	utcOffsetPattern     = regexp.MustCompile(`^(?i:utc|gmt)([+-])(\d{1,2})(?::?(\d{2}))?$`)
+	numericOffsetPattern = regexp.MustCompile(`^([+-])(\d{2}):?(\d{2})$`)
+)
+
+// LoadLocation resolves a time zone given as an IANA name
+// ("Europe/Berlin"), an abbreviation ("PST", "CET"), a UTC offset
+// ("UTC+2", "GMT-05:30") or a numeric offset ("+0530", "-08:00").
+// IANA names are matched case-insensitively.
+func LoadLocation(name string) (*time.Location, error) {
+	lower := strings.ToLower(name)
+	if offset, ok := zoneAbbreviations[lower]; ok {
+		return time.FixedZone(strings.ToUpper(name), offset), nil
+	}
+	if region, ok := regionAbbreviations[lower]; ok {
+		return time.LoadLocation(region)
+	}
+	if m := utcOffsetPattern.FindStringSubmatch(name); m != nil {
+		return offsetZone(name, m[1], m[2], m[3])
+	}
+	if m := numericOffsetPattern.FindStringSubmatch(name); m != nil {
+		return offsetZone(name, m[1], m[2], m[3])
+	}
+	if strings.Contains(name, "/") {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc, nil
+		}
+		if loc, err := time.LoadLocation(titleZone(name)); err == nil {
+			return loc, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown time zone: %q", name)
+}
+
+func offsetZone(name, sign, hours, minutes string) (*time.Location, error) {
+	h, _ := strconv.Atoi(hours)
+	m, _ := strconv.Atoi(minutes)
+	if h > 14 || m > 59 {
+		return nil, fmt.Errorf("invalid UTC offset: %q", name)
+	}
+	offset := h*3600 + m*60
+	if sign == "-" {
+		offset = -offset
+	}
+	return time.FixedZone(strings.ToUpper(name), offset), nil
+}
+
+// titleZone capitalizes each word of an IANA name: "america/new_york"
+// becomes "America/New_York".
+func titleZone(name string) string {
+	b := []byte(strings.ToLower(name))
+	upper := true
+	for i, c := range b {
+		if upper && c >= 'a' && c <= 'z' {
+			b[i] = c - 'a' + 'A'
+		}
+		upper = c == '/' || c == '_' || c == '-'
+	}
+	return string(b)
+}
+
+// splitZone separates a trailing time zone from a date expression, as in
+// "3pm PST" or "tomorrow 9:00 Europe/Berlin".
+func splitZone(input string) (string, *time.Location, bool) {
+	fields := strings.Fields(input)
+	if len(fields) < 2 {
+		return "", nil, false
+	}
+	loc, err := LoadLocation(fields[len(fields)-1])
+	if err != nil {
+		return "", nil, false
+	}
+	return strings.Join(fields[:len(fields)-1], " "), loc, true
+}
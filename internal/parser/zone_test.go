@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateZones(t *testing.T) {
+	now := time.Date(2026, 2, 11, 10, 0, 0, 0, time.UTC)
+	p := New(WithNow(now), WithLocation(time.UTC))
+	utc := func(month time.Month, d, hour, min int) time.Time {
+		return time.Date(2026, month, d, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"3pm PST", utc(2, 11, 23, 0)},
+		{"9:00 Europe/Berlin", utc(2, 12, 8, 0)},
+		{"tomorrow at 9am utc+2", utc(2, 12, 7, 0)},
+		{"5pm +0530", utc(2, 11, 11, 30)},
+		{"2026-03-01 10:00 america/new_york", utc(3, 1, 15, 0)},
+		{"2026-03-01T10:00Z", utc(3, 1, 10, 0)},
+		{"2026-03-01T10:00:00+02:00", utc(3, 1, 8, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := p.ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("ParseDate(%q) location = %v, want the parser's location", tt.input, got.Location())
+			}
+		})
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int // seconds east of UTC on 2026-01-15
+	}{
+		{"PST", -8 * 3600},
+		{"cest", 2 * 3600},
+		{"PT", -8 * 3600},
+		{"UTC-3", -3 * 3600},
+		{"GMT+05:30", 5*3600 + 1800},
+		{"-0800", -8 * 3600},
+		{"Europe/Berlin", 3600},
+		{"asia/kolkata", 5*3600 + 1800},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadLocation(tt.name)
+			if err != nil {
+				t.Fatalf("LoadLocation(%q) error: %v", tt.name, err)
+			}
+			_, offset := time.Date(2026, 1, 15, 12, 0, 0, 0, loc).Zone()
+			if offset != tt.offset {
+				t.Errorf("LoadLocation(%q) offset = %d, want %d", tt.name, offset, tt.offset)
+			}
+		})
+	}
+
+	for _, name := range []string{"Mars/Olympus_Mons", "UTC+15", "Japan", "friday"} {
+		if _, err := LoadLocation(name); err == nil {
+			t.Errorf("LoadLocation(%q) succeeded, want error", name)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return "string"
+	case tokOp:
+		return "operator"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	}
+	return "word"
+}
+
+// token is a lexical token. pos is its byte offset in the query.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keyword reports whether t is the bare word kw ("and", "or", "not").
+func (t token) keyword(kw string) bool {
+	return t.kind == tokWord && strings.EqualFold(t.text, kw)
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokWord, tokOp:
+		return "'" + t.text + "'"
+	case tokString:
+		return `"` + t.text + `"`
+	}
+	return t.kind.String()
+}
+
+// operators, longest first so "<=" wins over "<".
+var operators = []string{"!=", "<=", ">=", ":", "=", "<", ">", "~"}
+
+// lex splits a query into tokens. Words run until whitespace, a
+// parenthesis, a quote or an operator character, except that a word right
+// after an operator may contain ':', so times such as 2026-03-01T10:00
+// need no quotes; strings are double-quoted with backslash escapes.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		c, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+			continue
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+			continue
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+			continue
+		case c == '"':
+			s, end, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tokString, s, i})
+			i = end
+			continue
+		}
+		if op := lexOperator(src[i:]); op != "" {
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+			continue
+		}
+		if c == '!' {
+			return nil, errorAt(src, i, "unexpected '!' (use != or not)")
+		}
+		stop := `()"!:=<>~`
+		if len(tokens) > 0 && tokens[len(tokens)-1].kind == tokOp {
+			stop = `()"!=<>~`
+		}
+		start := i
+		for i < len(src) {
+			c, size := utf8.DecodeRuneInString(src[i:])
+			if unicode.IsSpace(c) || strings.ContainsRune(stop, c) {
+				break
+			}
+			i += size
+		}
+		tokens = append(tokens, token{tokWord, src[start:i], start})
+	}
+	return append(tokens, token{tokEOF, "", len(src)}), nil
+}
+
+func lexOperator(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// lexString reads the quoted string starting at src[start], returning its
+// value and the offset just past the closing quote.
+func lexString(src string, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			if i+1 < len(src) {
+				i++
+				b.WriteByte(src[i])
+			}
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(src[i])
+		}
+	}
+	return "", 0, errorAt(src, start, "unterminated string")
+}
@@ -0,0 +1,335 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+type fieldKind int
+
+const (
+	textKind fieldKind = iota
+	dateKind
+	priorityKind
+	boolKind
+)
+
+// fields maps the field names a query accepts, including aliases, to their
+// canonical name and kind. completed is a boolean unless compared with a
+// date, when it is the completion date.
+var fields = map[string]struct {
+	name string
+	kind fieldKind
+}{
+	"list":      {"list", textKind},
+	"name":      {"name", textKind},
+	"title":     {"name", textKind},
+	"notes":     {"notes", textKind},
+	"body":      {"notes", textKind},
+	"url":       {"url", textKind},
+	"tag":       {"tag", textKind},
+	"id":        {"id", textKind},
+	"due":       {"due", dateKind},
+	"remind":    {"remind", dateKind},
+	"created":   {"created", dateKind},
+	"modified":  {"modified", dateKind},
+	"priority":  {"priority", priorityKind},
+	"flagged":   {"flagged", boolKind},
+	"completed": {"completed", boolKind},
+	"overdue":   {"overdue", boolKind},
+	"repeating": {"repeating", boolKind},
+}
+
+const fieldNames = "list, name, notes, url, tag, id, due, remind, created, modified, completed, priority, flagged, overdue or repeating"
+
+// Parse parses a query, reading its date values with dates.
+func Parse(src string, dates DateParser) (Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{src: src, tokens: tokens, dates: dates, now: dates.Now()}
+	if p.peek().kind == tokEOF {
+		return nil, errorAt(src, 0, "empty query")
+	}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.unexpected(t)
+	}
+	return e, nil
+}
+
+type queryParser struct {
+	src    string
+	tokens []token
+	i      int
+	dates  DateParser
+	now    time.Time
+}
+
+func (p *queryParser) peek() token { return p.tokens[p.i] }
+
+func (p *queryParser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *queryParser) unexpected(t token) error {
+	if t.kind == tokEOF {
+		return errorAt(p.src, t.pos, "unexpected end of query")
+	}
+	return errorAt(p.src, t.pos, "unexpected %s", t.describe())
+}
+
+// or parses conditions joined by "or", which binds loosest.
+func (p *queryParser) or() (Expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword("or") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{left, right}
+	}
+	return left, nil
+}
+
+// and parses conditions joined by "and" or written one after another.
+func (p *queryParser) and() (Expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.keyword("and"):
+			p.next()
+		case t.keyword("or"), t.kind == tokEOF, t.kind == tokRParen:
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{left, right}
+	}
+}
+
+func (p *queryParser) unary() (Expr, error) {
+	if p.peek().keyword("not") {
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{x}, nil
+	}
+	return p.primary()
+}
+
+func (p *queryParser) primary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokLParen:
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokRParen {
+			return nil, errorAt(p.src, c.pos, "expected ')' to close the '(' at column %d", column(p.src, t.pos))
+		}
+		return e, nil
+	case tokString:
+		return &Text{t.text}, nil
+	case tokWord:
+		if isKeyword(t.text) {
+			return nil, errorAt(p.src, t.pos, "expected a condition before '%s'", t.text)
+		}
+		if p.peek().kind == tokOp {
+			return p.compare(t)
+		}
+		if f, ok := fields[strings.ToLower(t.text)]; ok && f.kind == boolKind {
+			return &Flag{Field: f.name, now: p.now}, nil
+		}
+		return &Text{t.text}, nil
+	case tokOp:
+		return nil, errorAt(p.src, t.pos, "expected a field name before '%s'", t.text)
+	case tokEOF:
+		return nil, errorAt(p.src, t.pos, "expected a condition")
+	}
+	return nil, p.unexpected(t)
+}
+
+// compare parses the operator and values of a condition on field.
+func (p *queryParser) compare(field token) (Expr, error) {
+	f, ok := fields[strings.ToLower(field.text)]
+	if !ok {
+		return nil, errorAt(p.src, field.pos, "unknown field %q (use %s)", field.text, fieldNames)
+	}
+	op := p.next()
+	values, err := p.values(op)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Compare{Field: f.name, Op: op.text, kind: f.kind}
+	if c.Op == "=" {
+		c.Op = ":"
+	}
+	if f.name == "completed" {
+		if _, err := parseBool(values[0].text); err != nil {
+			c.kind = dateKind
+		}
+	}
+	for _, v := range values {
+		c.Values = append(c.Values, v.text)
+	}
+
+	switch c.kind {
+	case textKind:
+		if isOrdering(c.Op) {
+			return nil, errorAt(p.src, op.pos, "%s can't be compared with '%s' (use :, != or ~)", f.name, op.text)
+		}
+	case dateKind:
+		if c.Op == "~" {
+			return nil, errorAt(p.src, op.pos, "%s can't be compared with '~' (use :, !=, <, <=, > or >=)", f.name)
+		}
+		for _, v := range values {
+			d, err := p.date(v, c.Op)
+			if err != nil {
+				return nil, err
+			}
+			c.dates = append(c.dates, d)
+		}
+	case priorityKind:
+		if c.Op == "~" {
+			return nil, errorAt(p.src, op.pos, "priority can't be compared with '~' (use :, !=, <, <=, > or >=)")
+		}
+		for _, v := range values {
+			rank, ok := priorityRank(v.text)
+			if !ok {
+				return nil, errorAt(p.src, v.pos, "invalid priority %q (use high, medium, low, none or 0-9)", v.text)
+			}
+			c.priorities = append(c.priorities, rank)
+		}
+	case boolKind:
+		if c.Op != ":" && c.Op != "!=" {
+			return nil, errorAt(p.src, op.pos, "%s can't be compared with '%s' (use : or !=)", f.name, op.text)
+		}
+		if len(values) > 1 {
+			return nil, errorAt(p.src, values[1].pos, "%s takes a single value", f.name)
+		}
+		b, err := parseBool(values[0].text)
+		if err != nil {
+			return nil, errorAt(p.src, values[0].pos, "invalid value %q for %s (use true or false)", values[0].text, f.name)
+		}
+		c.flag = Flag{Field: f.name, now: p.now}
+		c.boolean = b
+	}
+	return c, nil
+}
+
+// values parses the value after op: a word, a string, or values joined by
+// "or" in parentheses.
+func (p *queryParser) values(op token) ([]token, error) {
+	value := func() (token, error) {
+		t := p.next()
+		if (t.kind == tokWord && !isKeyword(t.text)) || t.kind == tokString {
+			return t, nil
+		}
+		if t.kind == tokEOF {
+			return t, errorAt(p.src, t.pos, "expected a value after '%s'", op.text)
+		}
+		return t, errorAt(p.src, t.pos, "expected a value after '%s', got %s", op.text, t.describe())
+	}
+	if p.peek().kind != tokLParen {
+		t, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return []token{t}, nil
+	}
+	open := p.next()
+	var values []token
+	for {
+		t, err := value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, t)
+		switch sep := p.next(); {
+		case sep.keyword("or"):
+		case sep.kind == tokRParen:
+			return values, nil
+		default:
+			return nil, errorAt(p.src, sep.pos, "expected 'or' or ')' to close the '(' at column %d", column(p.src, open.pos))
+		}
+	}
+}
+
+// date reads a date value. Values without a time of day stand for the
+// whole day; none and any test whether the date is set.
+func (p *queryParser) date(v token, op string) (dateValue, error) {
+	switch strings.ToLower(v.text) {
+	case "none", "any":
+		if op != ":" && op != "!=" {
+			return dateValue{}, errorAt(p.src, v.pos, "%s can only be compared with : or !=", v.text)
+		}
+		if strings.EqualFold(v.text, "none") {
+			return dateValue{none: true}, nil
+		}
+		return dateValue{any: true}, nil
+	}
+	t, err := p.dates.ParseDate(v.text)
+	if err != nil {
+		return dateValue{}, errorAt(p.src, v.pos, "invalid date %q", v.text)
+	}
+	d := dateValue{start: t, end: t}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		d.end = t.AddDate(0, 0, 1)
+	}
+	return d, nil
+}
+
+func isOrdering(op string) bool {
+	return op == "<" || op == "<=" || op == ">" || op == ">="
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "true", "yes":
+		return true, nil
+	case "false", "no":
+		return false, nil
+	}
+	return false, strconv.ErrSyntax
+}
+
+// priorityRank reads a priority label or number as its rank.
+func priorityRank(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > 9 {
+			return 0, false
+		}
+		return reminder.Priority(n).Rank(), true
+	}
+	s = strings.ToLower(s)
+	if p := reminder.ParsePriority(s); p != reminder.PriorityNone || s == "none" {
+		return p.Rank(), true
+	}
+	return 0, false
+}
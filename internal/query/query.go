@@ -0,0 +1,313 @@
+// Package query implements rem's filter language, which selects reminders
+// with conditions on their fields joined by and, or and not:
+//
+//	list:(Work or Home) and due<eow and priority>=medium and not flagged and notes~"invoice"
+//
+// A query is parsed into an Expr once, resolving relative dates such as
+// "eow" or "tomorrow" at that point, and then matched against reminders.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// DateParser reads the date values in a query. A *parser.Parser created
+// with parser.WithDefaultTime(0, 0) is one: dates without a time of day
+// come back at midnight and then stand for the whole day.
+type DateParser interface {
+	ParseDate(input string) (time.Time, error)
+	Now() time.Time
+}
+
+// Error is a query that can't be parsed. Offset is the byte offset of the
+// offending text.
+type Error struct {
+	Query  string
+	Offset int
+	Msg    string
+}
+
+// Column returns the 1-based column of the offending text.
+func (e *Error) Column() int {
+	return column(e.Query, e.Offset)
+}
+
+func column(src string, offset int) int {
+	return utf8.RuneCountInString(src[:offset]) + 1
+}
+
+// Error describes the problem and points at it under the query.
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid query at column %d: %s\n  %s\n  %s^", e.Column(), e.Msg, e.Query, strings.Repeat(" ", e.Column()-1))
+}
+
+func errorAt(src string, offset int, format string, args ...any) *Error {
+	return &Error{Query: src, Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Expr is a parsed query.
+type Expr interface {
+	// Match reports whether r satisfies the query.
+	Match(r *reminder.Reminder) bool
+	// String returns the query in canonical form.
+	String() string
+}
+
+// And matches reminders that match both sides.
+type And struct{ Left, Right Expr }
+
+// Or matches reminders that match either side.
+type Or struct{ Left, Right Expr }
+
+// Not matches reminders that X doesn't.
+type Not struct{ X Expr }
+
+// Text matches reminders whose title or notes contain Value, ignoring case.
+type Text struct{ Value string }
+
+// Flag is a bare condition: flagged, completed, overdue or repeating.
+type Flag struct {
+	Field string
+	now   time.Time
+}
+
+// Compare is a condition on a field: Field Op Values, where a list of
+// values in parentheses matches any of them (or, for !=, none of them).
+type Compare struct {
+	Field  string
+	Op     string
+	Values []string
+
+	kind       fieldKind
+	dates      []dateValue
+	priorities []int
+	flag       Flag
+	boolean    bool
+}
+
+func (e *And) Match(r *reminder.Reminder) bool { return e.Left.Match(r) && e.Right.Match(r) }
+func (e *Or) Match(r *reminder.Reminder) bool  { return e.Left.Match(r) || e.Right.Match(r) }
+func (e *Not) Match(r *reminder.Reminder) bool { return !e.X.Match(r) }
+
+func (e *Text) Match(r *reminder.Reminder) bool {
+	v := strings.ToLower(e.Value)
+	return strings.Contains(strings.ToLower(r.Name), v) || strings.Contains(strings.ToLower(r.Body), v)
+}
+
+func (e *Flag) Match(r *reminder.Reminder) bool {
+	switch e.Field {
+	case "flagged":
+		return r.Flagged
+	case "completed":
+		return r.Completed
+	case "overdue":
+		return !r.Completed && r.DueDate != nil && r.DueDate.Before(e.now)
+	case "repeating":
+		return r.Recurrence != nil
+	}
+	return false
+}
+
+func (e *And) String() string  { return group(e.Left, "and") + " and " + group(e.Right, "and") }
+func (e *Or) String() string   { return group(e.Left, "or") + " or " + group(e.Right, "or") }
+func (e *Not) String() string  { return "not " + group(e.X, "not") }
+func (e *Text) String() string { return quote(e.Value) }
+func (e *Flag) String() string { return e.Field }
+
+func (e *Compare) String() string {
+	if len(e.Values) == 1 {
+		return e.Field + e.Op + quote(e.Values[0])
+	}
+	quoted := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		quoted[i] = quote(v)
+	}
+	return e.Field + e.Op + "(" + strings.Join(quoted, " or ") + ")"
+}
+
+// group parenthesizes x where it would otherwise bind differently under
+// parent: an or anywhere but in another or, and an and under not.
+func group(x Expr, parent string) string {
+	switch x.(type) {
+	case *Or:
+		if parent != "or" {
+			return "(" + x.String() + ")"
+		}
+	case *And:
+		if parent == "not" {
+			return "(" + x.String() + ")"
+		}
+	}
+	return x.String()
+}
+
+// quote returns v as a bare word when it lexes as one, or quoted.
+func quote(v string) string {
+	if v != "" && !strings.ContainsAny(v, " \t\n()\"!:=<>~\\") && !isKeyword(v) {
+		return v
+	}
+	return strconv.Quote(v)
+}
+
+func isKeyword(s string) bool {
+	s = strings.ToLower(s)
+	return s == "and" || s == "or" || s == "not"
+}
+
+// Match reports whether r matches the condition.
+func (e *Compare) Match(r *reminder.Reminder) bool {
+	if e.Op == "!=" {
+		for i := range e.Values {
+			if e.match(r, "=", i) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range e.Values {
+		if e.match(r, e.Op, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// match tests the i-th value with op, which is never "!=".
+func (e *Compare) match(r *reminder.Reminder, op string, i int) bool {
+	switch e.kind {
+	case textKind:
+		return matchText(e.Field, r, op, e.Values[i])
+	case dateKind:
+		return e.dates[i].match(dateField(e.Field, r), op)
+	case priorityKind:
+		return compareInts(r.Priority.Rank(), op, e.priorities[i])
+	case boolKind:
+		return e.flag.Match(r) == e.boolean
+	}
+	return false
+}
+
+func matchText(field string, r *reminder.Reminder, op, value string) bool {
+	value = strings.ToLower(value)
+	test := func(s string) bool {
+		s = strings.ToLower(s)
+		if op == "~" {
+			return strings.Contains(s, value)
+		}
+		return s == value
+	}
+	switch field {
+	case "list":
+		return test(r.ListName)
+	case "name":
+		return test(r.Name)
+	case "notes":
+		return test(r.Body)
+	case "url":
+		return test(r.URL)
+	case "id":
+		if op == "~" {
+			return test(r.ID)
+		}
+		return value != "" && strings.HasPrefix(strings.ToLower(r.ID), value)
+	case "tag":
+		for _, tag := range r.Tags() {
+			if test(tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func dateField(field string, r *reminder.Reminder) *time.Time {
+	switch field {
+	case "due":
+		return r.DueDate
+	case "remind":
+		return r.RemindMeDate
+	case "created":
+		return r.CreationDate
+	case "modified":
+		return r.ModificationDate
+	case "completed":
+		return r.CompletionDate
+	}
+	return nil
+}
+
+func compareInts(a int, op string, b int) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return a == b
+}
+
+// dateValue is a date in a query: a whole day when it was given without a
+// time of day, or an instant. none and any match a missing or set date.
+type dateValue struct {
+	start, end time.Time
+	none, any  bool
+}
+
+func (d dateValue) match(t *time.Time, op string) bool {
+	switch {
+	case d.none:
+		return t == nil
+	case d.any:
+		return t != nil
+	case t == nil:
+		return false
+	}
+	day := !d.end.Equal(d.start)
+	switch op {
+	case "<":
+		return t.Before(d.start)
+	case "<=":
+		if day {
+			return t.Before(d.end)
+		}
+		return !t.After(d.start)
+	case ">":
+		if day {
+			return !t.Before(d.end)
+		}
+		return t.After(d.start)
+	case ">=":
+		return !t.Before(d.start)
+	}
+	// : and = compare calendar days.
+	y1, m1, d1 := t.In(d.start.Location()).Date()
+	y2, m2, d2 := d.start.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// Uses reports whether e has a condition on field, by its canonical name.
+func Uses(e Expr, field string) bool {
+	switch e := e.(type) {
+	case *And:
+		return Uses(e.Left, field) || Uses(e.Right, field)
+	case *Or:
+		return Uses(e.Left, field) || Uses(e.Right, field)
+	case *Not:
+		return Uses(e.X, field)
+	case *Flag:
+		return e.Field == field
+	case *Compare:
+		return e.Field == field
+	}
+	return false
+}
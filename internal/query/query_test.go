@@ -0,0 +1,156 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BRO3886/rem/internal/parser"
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// now is Wednesday 2026-02-11 10:00, so eow is Friday 2026-02-13 17:00.
+var now = time.Date(2026, 2, 11, 10, 0, 0, 0, time.UTC)
+
+func testParser() DateParser {
+	return parser.New(parser.WithNow(now), parser.WithLocation(time.UTC), parser.WithDefaultTime(0, 0))
+}
+
+func testReminders() []*reminder.Reminder {
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2026, 2, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+	return []*reminder.Reminder{
+		{ID: "aaa111", Name: "Send invoice", Body: "invoice #42 for ACME\n#billing", ListName: "Work", DueDate: at(12, 9), Priority: reminder.PriorityHigh},
+		{ID: "bbb222", Name: "Fix sink", ListName: "Home", DueDate: at(20, 9), Priority: reminder.PriorityMedium, Flagged: true},
+		{ID: "ccc333", Name: "Water plants", ListName: "Home", DueDate: at(11, 0), Priority: reminder.PriorityLow, Recurrence: &reminder.Recurrence{Frequency: reminder.FrequencyWeekly, Interval: 1}},
+		{ID: "ddd444", Name: "Old report", ListName: "Work", DueDate: at(2, 9), Completed: true, CompletionDate: at(3, 12)},
+		{ID: "eee555", Name: "Read book", ListName: "Personal"},
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string // matching IDs' first letters
+	}{
+		{`list:(Work or Home) and due<eow and priority>=medium and not flagged and notes~"invoice"`, "a"},
+		{`list:work`, "ad"},
+		{`list!=(Work or Home)`, "e"},
+		{`list = Home flagged`, "b"},
+		{`due:today`, "c"},
+		{`due<=tomorrow`, "acd"},
+		{`due>tomorrow`, "b"},
+		{`due>="2026-02-12 09:00"`, "ab"},
+		{`due<2026-02-12T09:30`, "acd"},
+		{`due>=2026-02-12T09:00Z and due<2026-02-20T10:00+01:00`, "a"},
+		{`due:none`, "e"},
+		{`due!=none and not completed`, "abc"},
+		{`overdue`, "c"},
+		{`priority:none`, "de"},
+		{`priority<medium`, "cde"},
+		{`priority>=5`, "ab"},
+		{`completed:true or repeating`, "cd"},
+		{`completed>2026-02-02`, "d"},
+		{`tag:billing`, "a"},
+		{`name~"read" or id:bbb`, "be"},
+		{`invoice`, "a"},
+		{`"water plants" or sink`, "bc"},
+		{`not (list:Work or list:Home)`, "e"},
+		{`flagged:false and list:Home`, "c"},
+	}
+	p := testParser()
+	for _, tt := range tests {
+		e, err := Parse(tt.query, p)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.query, err)
+			continue
+		}
+		var got string
+		for _, r := range testReminders() {
+			if e.Match(r) {
+				got += r.ID[:1]
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%q matched %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct{ query, want string }{
+		{`list:(Work or Home) and due<eow`, `list:(Work or Home) and due<eow`},
+		{`a OR b c`, `a or b and c`},
+		{`(a or b) c`, `(a or b) and c`},
+		{`not (a and b)`, `not (a and b)`},
+		{`name = "Fix sink"`, `name:"Fix sink"`},
+		{`notes~"and"`, `notes~"and"`},
+	}
+	for _, tt := range tests {
+		e, err := Parse(tt.query, testParser())
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.query, err)
+		}
+		if got := e.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		column int
+		msg    string
+	}{
+		{``, 1, "empty query"},
+		{`list:(Work or Home`, 19, "expected 'or' or ')'"},
+		{`(list:Work`, 11, "expected ')' to close the '(' at column 1"},
+		{`list:Work)`, 10, "unexpected ')'"},
+		{`colour:red`, 1, `unknown field "colour"`},
+		{`due<`, 5, "expected a value after '<'"},
+		{`due<someday-ish`, 5, `invalid date "someday-ish"`},
+		{`priority>=urgent`, 11, `invalid priority "urgent"`},
+		{`list<Work`, 5, "list can't be compared with '<'"},
+		{`flagged:maybe`, 9, `invalid value "maybe" for flagged`},
+		{`name:"unterminated`, 6, "unterminated string"},
+		{`list:Work and`, 14, "expected a condition"},
+		{`and flagged`, 1, "expected a condition before 'and'"},
+		{`:Work`, 1, "expected a field name before ':'"},
+		{`flagged ! completed`, 9, "unexpected '!'"},
+		{`née:x`, 1, `unknown field "née"`},
+		{`name:née and due<x`, 18, `invalid date "x"`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query, testParser())
+		var qerr *Error
+		if !errors.As(err, &qerr) {
+			t.Errorf("Parse(%q) error = %v, want a query error", tt.query, err)
+			continue
+		}
+		if qerr.Column() != tt.column || !strings.Contains(qerr.Msg, tt.msg) {
+			t.Errorf("Parse(%q) = column %d %q, want column %d %q", tt.query, qerr.Column(), qerr.Msg, tt.column, tt.msg)
+		}
+	}
+
+	_, err := Parse(`due<x`, testParser())
+	want := "invalid query at column 5: invalid date \"x\"\n  due<x\n      ^"
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestUses(t *testing.T) {
+	e, err := Parse(`list:Work and not (flagged or priority:high)`, testParser())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for field, want := range map[string]bool{"list": true, "flagged": true, "priority": true, "due": false} {
+		if got := Uses(e, field); got != want {
+			t.Errorf("Uses(%s) = %v, want %v", field, got, want)
+		}
+	}
+}
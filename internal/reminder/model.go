@@ -41,6 +41,20 @@ func ParsePriority(s string) Priority {
 	}
 }
 
+// Rank orders priorities from none (0) through low and medium to high (3),
+// the reverse of their numeric values.
+func (p Priority) Rank() int {
+	switch p.String() {
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	case "low":
+		return 1
+	}
+	return 0
+}
+
 // Reminder represents a single reminder item.
 type Reminder struct {
 	ID               string
@@ -57,6 +71,7 @@ type Reminder struct {
 	Flagged          bool
 	Completed        bool
 	URL              string // stored in body, extracted for convenience
+	Recurrence       *Recurrence
 }
 
 // List represents a Reminders list.
@@ -76,4 +91,7 @@ type ListFilter struct {
 	DueAfter     *time.Time
 	SearchQuery  string
 	PriorityMin  *Priority
+	// LoadFlags fills in Flagged on every reminder, which EventKit doesn't
+	// expose and the EventKit backend reads with a slower AppleScript call.
+	LoadFlags bool
 }
@@ -0,0 +1,505 @@
+package reminder
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the base period of a recurrence rule.
+type Frequency int
+
+const (
+	FrequencyDaily Frequency = iota
+	FrequencyWeekly
+	FrequencyMonthly
+	FrequencyYearly
+)
+
+var frequencyNames = map[Frequency]string{
+	FrequencyDaily:   "DAILY",
+	FrequencyWeekly:  "WEEKLY",
+	FrequencyMonthly: "MONTHLY",
+	FrequencyYearly:  "YEARLY",
+}
+
+var frequencyUnits = map[Frequency]string{
+	FrequencyDaily:   "day",
+	FrequencyWeekly:  "week",
+	FrequencyMonthly: "month",
+	FrequencyYearly:  "year",
+}
+
+func (f Frequency) String() string {
+	if name, ok := frequencyNames[f]; ok {
+		return strings.ToLower(name)
+	}
+	return "unknown"
+}
+
+// WeekdayNum is an RRULE BYDAY entry: a weekday, optionally restricted to
+// the Nth (or, if negative, Nth-from-last) occurrence within the month.
+// N == 0 means every such weekday.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Recurrence describes how a reminder repeats, modelled on RFC 5545 RRULE.
+// Interval is the number of Frequency periods between occurrences (0 means 1).
+// ByDay, ByMonthDay and ByMonth narrow the occurrences within each period;
+// ByMonthDay accepts negative values counting back from the end of the month.
+// Until (inclusive) and Count optionally end the series.
+type Recurrence struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+	Until      *time.Time
+	Count      int
+}
+
+var rruleDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRRule parses an RFC 5545 RRULE value such as
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH". A leading "RRULE:" is accepted.
+// UNTIL values without a zone are interpreted in loc.
+func ParseRRule(s string, loc *time.Location) (*Recurrence, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	if s == "" {
+		return nil, fmt.Errorf("empty RRULE")
+	}
+
+	r := &Recurrence{Frequency: -1}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+
+		switch key {
+		case "FREQ":
+			found := false
+			for f, name := range frequencyNames {
+				if name == value {
+					r.Frequency = f
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unsupported FREQ %q (use DAILY, WEEKLY, MONTHLY or YEARLY)", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseRRuleTime(value, loc)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = &t
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, err := parseWeekdayNum(d)
+				if err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			days, err := parseIntList(value, -31, 31)
+			if err != nil {
+				return nil, fmt.Errorf("invalid BYMONTHDAY %q", value)
+			}
+			r.ByMonthDay = days
+		case "BYMONTH":
+			months, err := parseIntList(value, 1, 12)
+			if err != nil {
+				return nil, fmt.Errorf("invalid BYMONTH %q", value)
+			}
+			r.ByMonth = months
+		case "WKST":
+			// Weeks always start on Monday, the RFC 5545 default.
+		default:
+			return nil, fmt.Errorf("unsupported RRULE part %q", key)
+		}
+	}
+
+	if r.Frequency < 0 {
+		return nil, fmt.Errorf("RRULE is missing FREQ")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, fmt.Errorf("RRULE cannot have both COUNT and UNTIL")
+	}
+	return r, nil
+}
+
+func parseRRuleTime(value string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	if strings.HasSuffix(value, "Z") {
+		if t, err := time.Parse("20060102T150405Z", value); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{"20060102T150405", "20060102"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			if layout == "20060102" {
+				// A date-only UNTIL includes the whole day.
+				t = time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, loc)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	code := s[len(s)-2:]
+	day := -1
+	for i, d := range rruleDays {
+		if d == code {
+			day = i
+		}
+	}
+	if day < 0 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	wd := WeekdayNum{Day: time.Weekday(day)}
+	if prefix := s[:len(s)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+		}
+		wd.N = n
+	}
+	return wd, nil
+}
+
+func parseIntList(s string, min, max int) ([]int, error) {
+	var out []int
+	for _, p := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("invalid value %q", p)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+// RRule formats the recurrence as an RFC 5545 RRULE value (without the
+// "RRULE:" prefix). UNTIL is written in UTC.
+func (r *Recurrence) RRule() string {
+	parts := []string{"FREQ=" + frequencyNames[r.Frequency]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, d := range r.ByDay {
+			code := rruleDays[d.Day]
+			if d.N != 0 {
+				code = strconv.Itoa(d.N) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(ns []int) string {
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
+
+// String returns a human-readable description such as "every 2 weeks on Tue, Thu".
+func (r *Recurrence) String() string {
+	var b strings.Builder
+	unit := frequencyUnits[r.Frequency]
+	if r.interval() == 1 {
+		b.WriteString("every " + unit)
+	} else {
+		fmt.Fprintf(&b, "every %d %ss", r.interval(), unit)
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, d := range r.ByDay {
+			name := d.Day.String()[:3]
+			if d.N != 0 {
+				name = ordinal(d.N) + " " + name
+			}
+			days = append(days, name)
+		}
+		b.WriteString(" on " + strings.Join(days, ", "))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, d := range r.ByMonthDay {
+			days = append(days, ordinal(d))
+		}
+		b.WriteString(" on the " + strings.Join(days, ", "))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, 0, len(r.ByMonth))
+		for _, m := range r.ByMonth {
+			months = append(months, time.Month(m).String()[:3])
+		}
+		b.WriteString(" in " + strings.Join(months, ", "))
+	}
+	if r.Count > 0 {
+		fmt.Fprintf(&b, ", %d times", r.Count)
+	}
+	if r.Until != nil {
+		b.WriteString(", until " + r.Until.Format("2006-01-02"))
+	}
+	return b.String()
+}
+
+func ordinal(n int) string {
+	if n == -1 {
+		return "last"
+	}
+	if n < 0 {
+		return ordinal(-n) + " to last"
+	}
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+func (r *Recurrence) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+// maxPeriods bounds expansion so rules that can never match (e.g. the 31st
+// of February) terminate.
+const maxPeriods = 10000
+
+// Occurrences returns the occurrences of a series starting at start that
+// fall within [from, to], in chronological order. Every occurrence has the
+// time of day of start; start itself counts as the first occurrence when it
+// matches the rule. Unlike RFC 5545, a start that doesn't match is neither
+// returned nor counted towards Count.
+func (r *Recurrence) Occurrences(start, from, to time.Time) []time.Time {
+	var out []time.Time
+	r.each(start, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+		return true
+	})
+	return out
+}
+
+// Next returns the first occurrence strictly after t, or false if the
+// series has ended.
+func (r *Recurrence) Next(start, t time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.each(start, func(o time.Time) bool {
+		if o.After(t) {
+			next, found = o, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// each calls fn for every occurrence in order until fn returns false or the
+// series ends.
+func (r *Recurrence) each(start time.Time, fn func(time.Time) bool) {
+	n := 0
+	for period := 0; period < maxPeriods; period++ {
+		for _, t := range r.candidates(start, period*r.interval()) {
+			if t.Before(start) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return
+			}
+			n++
+			if r.Count > 0 && n > r.Count {
+				return
+			}
+			if !fn(t) {
+				return
+			}
+		}
+	}
+}
+
+// candidates returns the sorted occurrences within the period that is
+// offset periods after the one containing start.
+func (r *Recurrence) candidates(start time.Time, offset int) []time.Time {
+	h, m, s := start.Clock()
+	loc := start.Location()
+	at := func(y int, mon time.Month, d int) time.Time {
+		return time.Date(y, mon, d, h, m, s, 0, loc)
+	}
+
+	var out []time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		t := at(start.Year(), start.Month(), start.Day()+offset)
+		if r.matchesDay(t) {
+			out = append(out, t)
+		}
+	case FrequencyWeekly:
+		// Weeks start on Monday.
+		back := (int(start.Weekday()) + 6) % 7
+		monday := at(start.Year(), start.Month(), start.Day()-back+7*offset)
+		for i := 0; i < 7; i++ {
+			t := monday.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 {
+				if t.Weekday() == start.Weekday() && r.matchesMonth(t) {
+					out = append(out, t)
+				}
+			} else if r.matchesDay(t) {
+				out = append(out, t)
+			}
+		}
+	case FrequencyMonthly:
+		first := at(start.Year(), start.Month()+time.Month(offset), 1)
+		if r.matchesMonth(first) {
+			out = r.daysInMonth(first, start.Day())
+		}
+	case FrequencyYearly:
+		year := start.Year() + offset
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []int{int(start.Month())}
+		}
+		for _, mon := range months {
+			out = append(out, r.daysInMonth(at(year, time.Month(mon), 1), start.Day())...)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// daysInMonth returns the matching days of the month starting at first.
+// Without BYDAY or BYMONTHDAY the series falls on defaultDay, skipping
+// months that are too short.
+func (r *Recurrence) daysInMonth(first time.Time, defaultDay int) []time.Time {
+	var out []time.Time
+	last := first.AddDate(0, 1, -1).Day()
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		if defaultDay <= last {
+			out = append(out, first.AddDate(0, 0, defaultDay-1))
+		}
+		return out
+	}
+	for d := 1; d <= last; d++ {
+		t := first.AddDate(0, 0, d-1)
+		if r.matchesDay(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// matchesDay reports whether t satisfies the BYDAY, BYMONTHDAY and BYMONTH
+// constraints. Ordinal BYDAY entries are relative to the month.
+func (r *Recurrence) matchesDay(t time.Time) bool {
+	if !r.matchesMonth(t) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 {
+		last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		ok := false
+		for _, d := range r.ByMonthDay {
+			if d == t.Day() || (d < 0 && last+d+1 == t.Day()) {
+				ok = true
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 {
+		last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		nth := (t.Day()-1)/7 + 1
+		nthFromEnd := -((last-t.Day())/7 + 1)
+		ok := false
+		for _, d := range r.ByDay {
+			if d.Day == t.Weekday() && (d.N == 0 || d.N == nth || d.N == nthFromEnd) {
+				ok = true
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Recurrence) matchesMonth(t time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == t.Month() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRRuleRoundTrip(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH"},
+		{"freq=monthly;byday=1mo", "FREQ=MONTHLY;BYDAY=1MO"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "FREQ=MONTHLY;BYMONTHDAY=-1"},
+		{"FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=15;COUNT=5", "FREQ=YEARLY;BYMONTHDAY=15;BYMONTH=3;COUNT=5"},
+		{"FREQ=DAILY;UNTIL=20260301T090000Z", "FREQ=DAILY;UNTIL=20260301T090000Z"},
+		{"FREQ=WEEKLY;WKST=MO;INTERVAL=1", "FREQ=WEEKLY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := ParseRRule(tt.input, time.UTC)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) error: %v", tt.input, err)
+			}
+			if got := r.RRule(); got != tt.want {
+				t.Errorf("RRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;COUNT=3;UNTIL=20260301",
+		"FREQ=DAILY;BYSECOND=5",
+		"FREQ",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseRRule(input, time.UTC); err == nil {
+				t.Errorf("expected error for %q, got nil", input)
+			}
+		})
+	}
+}
+
+func TestRecurrenceString(t *testing.T) {
+	tests := []struct {
+		rrule string
+		want  string
+	}{
+		{"FREQ=DAILY", "every day"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", "every 2 weeks on Tue, Thu"},
+		{"FREQ=MONTHLY;BYDAY=1MO", "every month on 1st Mon"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "every month on the last"},
+		{"FREQ=YEARLY;COUNT=3", "every year, 3 times"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rrule, func(t *testing.T) {
+			r, _ := ParseRRule(tt.rrule, time.UTC)
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func dates(ts []time.Time) []string {
+	out := make([]string, len(ts))
+	for i, t := range ts {
+		out[i] = t.Format("2006-01-02 15:04")
+	}
+	return out
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	// Monday, 2026-02-02 09:00
+	start := time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rrule string
+		from  time.Time
+		to    time.Time
+		want  []string
+	}{
+		{
+			"FREQ=DAILY;INTERVAL=2",
+			start, start.AddDate(0, 0, 6),
+			[]string{"2026-02-02 09:00", "2026-02-04 09:00", "2026-02-06 09:00", "2026-02-08 09:00"},
+		},
+		{
+			"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			start.AddDate(0, 0, 3), start.AddDate(0, 0, 8),
+			[]string{"2026-02-05 09:00", "2026-02-06 09:00", "2026-02-09 09:00", "2026-02-10 09:00"},
+		},
+		{
+			"FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+			start, start.AddDate(0, 0, 30),
+			[]string{"2026-02-06 09:00", "2026-02-20 09:00"},
+		},
+		{
+			"FREQ=MONTHLY;BYDAY=1MO",
+			start, start.AddDate(0, 3, 0),
+			[]string{"2026-02-02 09:00", "2026-03-02 09:00", "2026-04-06 09:00"},
+		},
+		{
+			"FREQ=MONTHLY;BYMONTHDAY=-1",
+			start, start.AddDate(0, 3, 0),
+			[]string{"2026-02-28 09:00", "2026-03-31 09:00", "2026-04-30 09:00"},
+		},
+		{
+			"FREQ=MONTHLY;BYDAY=-1FR",
+			start, start.AddDate(0, 2, 0),
+			[]string{"2026-02-27 09:00", "2026-03-27 09:00"},
+		},
+		{
+			"FREQ=WEEKLY;COUNT=3",
+			start, start.AddDate(1, 0, 0),
+			[]string{"2026-02-02 09:00", "2026-02-09 09:00", "2026-02-16 09:00"},
+		},
+		// Unlike RFC 5545, a start that doesn't match the rule is not an
+		// occurrence and isn't counted: RFC 5545 would give 02-02 and 02-06.
+		{
+			"FREQ=WEEKLY;BYDAY=FR;COUNT=2",
+			start, start.AddDate(1, 0, 0),
+			[]string{"2026-02-06 09:00", "2026-02-13 09:00"},
+		},
+		{
+			"FREQ=DAILY;UNTIL=20260204T090000Z",
+			start, start.AddDate(0, 1, 0),
+			[]string{"2026-02-02 09:00", "2026-02-03 09:00", "2026-02-04 09:00"},
+		},
+		{
+			"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+			start, start.AddDate(4, 0, 0),
+			[]string{"2028-02-29 09:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rrule, func(t *testing.T) {
+			r, err := ParseRRule(tt.rrule, time.UTC)
+			if err != nil {
+				t.Fatalf("ParseRRule error: %v", err)
+			}
+			got := dates(r.Occurrences(start, tt.from, tt.to))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Occurrences = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRRuleUntilDate(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// Clocks go forward on 2026-03-08, a 23-hour day.
+	r, err := ParseRRule("FREQ=DAILY;UNTIL=20260308", ny)
+	if err != nil {
+		t.Fatalf("ParseRRule error: %v", err)
+	}
+	want := time.Date(2026, 3, 8, 23, 59, 59, 0, ny)
+	if r.Until == nil || !r.Until.Equal(want) {
+		t.Errorf("Until = %v, want %v", r.Until, want)
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	start := time.Date(2026, 1, 31, 8, 0, 0, 0, time.UTC)
+	r := &Recurrence{Frequency: FrequencyMonthly}
+
+	// Months without a 31st are skipped.
+	next, ok := r.Next(start, start)
+	if !ok || next.Format("2006-01-02") != "2026-03-31" {
+		t.Errorf("Next = %v, %v; want 2026-03-31", next, ok)
+	}
+
+	r.Count = 1
+	if _, ok := r.Next(start, start); ok {
+		t.Error("expected series with COUNT=1 to have no next occurrence")
+	}
+}
@@ -0,0 +1,101 @@
+package reminder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortKey is one key of a sort order: a field and its direction.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// SortFields are the fields reminders can be sorted by.
+var SortFields = []string{"due", "priority", "name", "created", "modified", "list"}
+
+// ParseSortKeys parses a comma-separated sort order such as
+// "due,-priority,name", where a leading '-' sorts that key descending.
+func ParseSortKeys(s string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		key := SortKey{Field: strings.TrimLeft(part, "+-"), Desc: strings.HasPrefix(part, "-")}
+		valid := false
+		for _, f := range SortFields {
+			valid = valid || f == key.Field
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid sort key %q (use %s, with '-' for descending)", part, strings.Join(SortFields, ", "))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Sort sorts reminders by keys, keeping the existing order of reminders
+// that compare equal. Missing dates sort last in either direction, and
+// priorities order from none to high.
+func Sort(reminders []*Reminder, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	sort.SliceStable(reminders, func(i, j int) bool {
+		for _, key := range keys {
+			if c := compareField(reminders[i], reminders[j], key); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareField compares a and b on key, returning -1 when a sorts first.
+func compareField(a, b *Reminder, key SortKey) int {
+	var c int
+	switch key.Field {
+	case "due":
+		return compareDates(a.DueDate, b.DueDate, key.Desc)
+	case "created":
+		return compareDates(a.CreationDate, b.CreationDate, key.Desc)
+	case "modified":
+		return compareDates(a.ModificationDate, b.ModificationDate, key.Desc)
+	case "priority":
+		c = a.Priority.Rank() - b.Priority.Rank()
+	case "name":
+		c = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case "list":
+		c = strings.Compare(strings.ToLower(a.ListName), strings.ToLower(b.ListName))
+	}
+	if key.Desc {
+		c = -c
+	}
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
+
+func compareDates(a, b *time.Time, desc bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	c := a.Compare(*b)
+	if desc {
+		c = -c
+	}
+	return c
+}
@@ -0,0 +1,56 @@
+package reminder
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSort(t *testing.T) {
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 2, d, 9, 0, 0, 0, time.UTC)
+		return &t
+	}
+	reminders := func() []*Reminder {
+		return []*Reminder{
+			{Name: "b", ListName: "Work", DueDate: day(12), Priority: PriorityLow},
+			{Name: "a", ListName: "home", Priority: PriorityHigh},
+			{Name: "c", ListName: "Home", DueDate: day(10), Priority: PriorityHigh, CreationDate: day(1)},
+			{Name: "D", ListName: "Work", DueDate: day(12), Priority: PriorityNone, CreationDate: day(2)},
+		}
+	}
+
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{"due", "cbDa"},
+		{"-due", "bDca"},
+		{"due,-priority", "cbDa"},
+		{"-priority,name", "acbD"},
+		{"priority", "Dbac"},
+		{"name", "abcD"},
+		{"list,-name", "caDb"},
+		{"-created", "Dcba"},
+		{"", "bacD"},
+	}
+	for _, tt := range tests {
+		keys, err := ParseSortKeys(tt.sort)
+		if err != nil {
+			t.Fatalf("ParseSortKeys(%q) failed: %v", tt.sort, err)
+		}
+		rs := reminders()
+		Sort(rs, keys)
+		var got strings.Builder
+		for _, r := range rs {
+			got.WriteString(r.Name)
+		}
+		if got.String() != tt.want {
+			t.Errorf("sort %q = %s, want %s", tt.sort, got.String(), tt.want)
+		}
+	}
+
+	if _, err := ParseSortKeys("due,colour"); err == nil || !strings.Contains(err.Error(), `"colour"`) {
+		t.Errorf("expected an error naming the invalid key, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package reminder
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tags returns the #tags in the reminder's notes, without the '#'.
+// Reminders has no tag field, so rem keeps tags as words in the notes.
+func (r *Reminder) Tags() []string {
+	var tags []string
+	for _, word := range strings.Fields(r.Body) {
+		if IsTag(word) {
+			tags = append(tags, word[1:])
+		}
+	}
+	return tags
+}
+
+// SetTags replaces the #tags in the reminder's notes. The rest of the
+// notes is kept and the new tags are added on a line of their own.
+func (r *Reminder) SetTags(tags []string) {
+	var lines []string
+	for _, line := range strings.Split(r.Body, "\n") {
+		words := strings.Fields(line)
+		kept := words[:0]
+		for _, word := range words {
+			if !IsTag(word) {
+				kept = append(kept, word)
+			}
+		}
+		switch {
+		case len(kept) == len(words):
+			lines = append(lines, line)
+		case len(kept) > 0:
+			lines = append(lines, strings.Join(kept, " "))
+		}
+	}
+	if len(tags) > 0 {
+		lines = append(lines, "#"+strings.Join(tags, " #"))
+	}
+	r.Body = strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// IsTag reports whether word is a #tag: '#' followed by letters, digits,
+// '_' or '-'.
+func IsTag(word string) bool {
+	if len(word) < 2 || word[0] != '#' {
+		return false
+	}
+	for _, c := range word[1:] {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
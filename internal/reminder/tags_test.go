@@ -0,0 +1,34 @@
+package reminder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	r := &Reminder{Body: "Pick up #groceries on the way\n#errand #home-office #1 # #not!a:tag"}
+	want := "groceries errand home-office 1"
+	if got := strings.Join(r.Tags(), " "); got != want {
+		t.Errorf("Tags() = %q, want %q", got, want)
+	}
+}
+
+func TestSetTags(t *testing.T) {
+	tests := []struct {
+		body string
+		tags []string
+		want string
+	}{
+		{"", []string{"errand"}, "#errand"},
+		{"Milk, eggs", []string{"errand", "home"}, "Milk, eggs\n#errand #home"},
+		{"Milk #old\n\nMore notes\n#old #older", []string{"new"}, "Milk\n\nMore notes\n#new"},
+		{"#old", nil, ""},
+	}
+	for _, tt := range tests {
+		r := &Reminder{Body: tt.body}
+		r.SetTags(tt.tags)
+		if r.Body != tt.want {
+			t.Errorf("SetTags(%q, %v) body = %q, want %q", tt.body, tt.tags, r.Body, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package reminder
+
+import (
+	"strings"
+	"time"
+)
+
+// timestampPrefix starts the lines of the notes that record a reminder's
+// original dates, such as "rem:created 2026-01-03T10:00:00+01:00".
+const timestampPrefix = "rem:"
+
+// KeepTimestamps records the reminder's creation, modification and, if it
+// is completed, completion dates as lines at the end of its notes,
+// replacing any recorded before. Reminders sets these dates itself when a
+// reminder is created, so a restored backup keeps its originals this way.
+func (r *Reminder) KeepTimestamps() {
+	text, _ := SplitTimestampNotes(r.Body)
+	var lines []string
+	add := func(field string, t *time.Time) {
+		if t != nil {
+			lines = append(lines, timestampPrefix+field+" "+t.Format(time.RFC3339))
+		}
+	}
+	add("created", r.CreationDate)
+	add("modified", r.ModificationDate)
+	if r.Completed {
+		add("completed", r.CompletionDate)
+	}
+	if len(lines) == 0 {
+		r.Body = text
+		return
+	}
+	if text != "" {
+		text += "\n\n"
+	}
+	r.Body = text + strings.Join(lines, "\n")
+}
+
+// RestoreTimestamps reads back the dates KeepTimestamps recorded and
+// removes their lines from the notes. The creation date always comes from
+// the notes; the modification and completion dates only while the store's
+// own are within a minute of its creation date, that is until the
+// reminder is changed after it was restored.
+func (r *Reminder) RestoreTimestamps() {
+	text, stamps := SplitTimestampNotes(r.Body)
+	if stamps == "" {
+		return
+	}
+	r.Body = text
+	stored := r.CreationDate
+	untouched := func(t *time.Time) bool {
+		return t == nil || stored == nil || t.Sub(*stored) < time.Minute
+	}
+	for _, line := range strings.Split(stamps, "\n") {
+		field, value, _ := strings.Cut(strings.TrimPrefix(line, timestampPrefix), " ")
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+		switch field {
+		case "created":
+			r.CreationDate = &t
+		case "modified":
+			if untouched(r.ModificationDate) {
+				r.ModificationDate = &t
+			}
+		case "completed":
+			if r.Completed && untouched(r.CompletionDate) {
+				r.CompletionDate = &t
+			}
+		}
+	}
+}
+
+// SplitTimestampNotes splits notes into their text and the trailing lines
+// recorded by KeepTimestamps.
+func SplitTimestampNotes(notes string) (text, stamps string) {
+	lines := strings.Split(strings.TrimRight(notes, "\n"), "\n")
+	i := len(lines)
+	for i > 0 && isTimestampLine(lines[i-1]) {
+		i--
+	}
+	if i == len(lines) {
+		return notes, ""
+	}
+	return strings.TrimSpace(strings.Join(lines[:i], "\n")), strings.Join(lines[i:], "\n")
+}
+
+func isTimestampLine(line string) bool {
+	for _, field := range []string{"created ", "modified ", "completed "} {
+		if strings.HasPrefix(line, timestampPrefix+field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeepAndRestoreTimestamps(t *testing.T) {
+	created := time.Date(2025, 11, 3, 9, 15, 0, 0, time.UTC)
+	modified := created.Add(48 * time.Hour)
+	completed := created.Add(72 * time.Hour)
+
+	r := &Reminder{Body: "Call the bank", Completed: true, CreationDate: &created, ModificationDate: &modified, CompletionDate: &completed}
+	r.KeepTimestamps()
+	want := "Call the bank\n\nrem:created 2025-11-03T09:15:00Z\nrem:modified 2025-11-05T09:15:00Z\nrem:completed 2025-11-06T09:15:00Z"
+	if r.Body != want {
+		t.Fatalf("KeepTimestamps body = %q, want %q", r.Body, want)
+	}
+	r.KeepTimestamps()
+	if r.Body != want {
+		t.Fatalf("KeepTimestamps twice body = %q", r.Body)
+	}
+
+	// The store stamps its own dates when the backup is restored.
+	now := time.Now()
+	restored := &Reminder{Body: r.Body, Completed: true, CreationDate: &now, ModificationDate: &now, CompletionDate: &now}
+	restored.RestoreTimestamps()
+	if restored.Body != "Call the bank" {
+		t.Errorf("RestoreTimestamps body = %q", restored.Body)
+	}
+	if !restored.CreationDate.Equal(created) || !restored.ModificationDate.Equal(modified) || !restored.CompletionDate.Equal(completed) {
+		t.Errorf("RestoreTimestamps dates = %v, %v, %v", restored.CreationDate, restored.ModificationDate, restored.CompletionDate)
+	}
+
+	// Once the reminder is changed again, the store's dates win.
+	later := now.Add(time.Hour)
+	edited := &Reminder{Body: r.Body, CreationDate: &now, ModificationDate: &later}
+	edited.RestoreTimestamps()
+	if !edited.CreationDate.Equal(created) || !edited.ModificationDate.Equal(later) || edited.CompletionDate != nil {
+		t.Errorf("RestoreTimestamps after an edit = %v, %v, %v", edited.CreationDate, edited.ModificationDate, edited.CompletionDate)
+	}
+}
+
+func TestSplitTimestampNotes(t *testing.T) {
+	tests := []struct {
+		notes, text, stamps string
+	}{
+		{"", "", ""},
+		{"Just notes", "Just notes", ""},
+		{"rem:created 2025-11-03T09:15:00Z", "", "rem:created 2025-11-03T09:15:00Z"},
+		{"rem:created in the middle\nof the notes", "rem:created in the middle\nof the notes", ""},
+		{"Notes\n\nrem:modified 2025-11-03T09:15:00Z\n", "Notes", "rem:modified 2025-11-03T09:15:00Z"},
+	}
+	for _, tt := range tests {
+		text, stamps := SplitTimestampNotes(tt.notes)
+		if text != tt.text || stamps != tt.stamps {
+			t.Errorf("SplitTimestampNotes(%q) = %q, %q, want %q, %q", tt.notes, text, stamps, tt.text, tt.stamps)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import "github.com/BRO3886/rem/internal/reminder"
+
+// Backend is the storage layer behind ReminderService and ListService.
+// The EventKit backend talks to macOS Reminders; other implementations
+// let the command logic run on any platform.
+type Backend interface {
+	// Lists returns all reminder lists.
+	Lists() ([]*reminder.List, error)
+	// CreateList creates a new list with the given name.
+	CreateList(name string) (*reminder.List, error)
+	// RenameList renames the list named oldName to newName.
+	RenameList(oldName, newName string) error
+	// DeleteList deletes the list with the given name and all its reminders.
+	DeleteList(name string) error
+	// DefaultListName returns the name of the list new reminders go to
+	// when no list is specified.
+	DefaultListName() (string, error)
+
+	// Reminders returns reminders matching the filter. A nil filter matches all.
+	Reminders(filter *reminder.ListFilter) ([]*reminder.Reminder, error)
+	// Reminder returns a single reminder by ID or ID prefix.
+	Reminder(id string) (*reminder.Reminder, error)
+	// CreateReminder creates a reminder, completed and flagged if r is, and
+	// returns its ID. Backends that can't set the creation, modification
+	// and completion dates keep the ones r has in its notes (see
+	// reminder.KeepTimestamps) and read them back from there.
+	CreateReminder(r *reminder.Reminder) (string, error)
+	// UpdateReminder applies field updates keyed by "name", "body", "due_date",
+	// "remind_me_date", "priority", "flagged", "completed", "url" and
+	// "recurrence" (a *reminder.Recurrence, or nil to stop repeating).
+	UpdateReminder(id string, updates map[string]any) error
+	// DeleteReminder deletes a reminder by ID.
+	DeleteReminder(id string) error
+	// CompleteReminder marks a reminder as completed.
+	CompleteReminder(id string) error
+	// UncompleteReminder marks a reminder as incomplete.
+	UncompleteReminder(id string) error
+	// FlagReminder flags a reminder.
+	FlagReminder(id string) error
+	// UnflagReminder removes the flag from a reminder.
+	UnflagReminder(id string) error
+}
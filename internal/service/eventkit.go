@@ -0,0 +1,545 @@
+//go:build darwin
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/go-eventkit"
+	"github.com/BRO3886/go-eventkit/reminders"
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// EventKitBackend is the macOS Reminders backend. It uses go-eventkit for
+// reads and writes, with AppleScript fallback for flagged operations and
+// the default list name.
+type EventKitBackend struct {
+	client *reminders.Client
+	exec   *Executor
+}
+
+// NewEventKitBackend creates a new EventKitBackend.
+func NewEventKitBackend(client *reminders.Client, exec *Executor) *EventKitBackend {
+	return &EventKitBackend{client: client, exec: exec}
+}
+
+// Lists returns all reminder lists via go-eventkit.
+func (b *EventKitBackend) Lists() ([]*reminder.List, error) {
+	ekLists, err := b.client.Lists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	lists := make([]*reminder.List, 0, len(ekLists))
+	for _, l := range ekLists {
+		lists = append(lists, fromEventKitList(&l))
+	}
+
+	return lists, nil
+}
+
+// findListByName looks up a list by name and returns the go-eventkit List.
+func (b *EventKitBackend) findListByName(name string) (*reminders.List, error) {
+	ekLists, err := b.client.Lists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	for _, l := range ekLists {
+		if l.Title == name {
+			return &l, nil
+		}
+	}
+
+	return nil, fmt.Errorf("list not found: %s", name)
+}
+
+// defaultSource discovers the default source name from existing lists.
+// Falls back to "iCloud" if no lists exist.
+func (b *EventKitBackend) defaultSource() (string, error) {
+	ekLists, err := b.client.Lists()
+	if err != nil {
+		return "", fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	for _, l := range ekLists {
+		if l.Source != "" {
+			return l.Source, nil
+		}
+	}
+
+	return "iCloud", nil
+}
+
+// CreateList creates a new reminder list via go-eventkit.
+// The list is created in the default source (discovered from existing lists).
+func (b *EventKitBackend) CreateList(name string) (*reminder.List, error) {
+	source, err := b.defaultSource()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := b.client.CreateList(reminders.CreateListInput{
+		Title:  name,
+		Source: source,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list: %w", err)
+	}
+
+	return fromEventKitList(created), nil
+}
+
+// RenameList renames an existing list via go-eventkit.
+func (b *EventKitBackend) RenameList(oldName, newName string) error {
+	ekList, err := b.findListByName(oldName)
+	if err != nil {
+		return err
+	}
+
+	if ekList.ReadOnly {
+		return fmt.Errorf("cannot rename list '%s': list is immutable", oldName)
+	}
+
+	_, err = b.client.UpdateList(ekList.ID, reminders.UpdateListInput{
+		Title: &newName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rename list: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteList deletes a list by name via go-eventkit.
+func (b *EventKitBackend) DeleteList(name string) error {
+	ekList, err := b.findListByName(name)
+	if err != nil {
+		return err
+	}
+
+	if ekList.ReadOnly {
+		return fmt.Errorf("cannot delete list '%s': list is immutable", name)
+	}
+
+	if err := b.client.DeleteList(ekList.ID); err != nil {
+		return fmt.Errorf("failed to delete list: %w", err)
+	}
+
+	return nil
+}
+
+// DefaultListName returns the name of the default reminder list via AppleScript.
+// EventKit does not expose which list is the "default" list, so AppleScript is used.
+func (b *EventKitBackend) DefaultListName() (string, error) {
+	output, err := b.exec.Run(`tell application "Reminders" to get name of default list`)
+	if err != nil {
+		return "", fmt.Errorf("failed to get default list: %w", err)
+	}
+	return output, nil
+}
+
+// fromEventKitList converts a go-eventkit List to an internal List.
+func fromEventKitList(l *reminders.List) *reminder.List {
+	return &reminder.List{
+		ID:    l.ID,
+		Name:  l.Title,
+		Color: l.Color,
+		Count: l.Count,
+	}
+}
+
+// CreateReminder creates a new reminder and returns its ID.
+func (b *EventKitBackend) CreateReminder(r *reminder.Reminder) (string, error) {
+	// EventKit stamps its own dates, so keep any the reminder came with
+	// (from an import) in the notes.
+	notes := r.Body
+	if r.CreationDate != nil || r.ModificationDate != nil || (r.Completed && r.CompletionDate != nil) {
+		c := *r
+		c.KeepTimestamps()
+		notes = c.Body
+	}
+	input := reminders.CreateReminderInput{
+		Title:    r.Name,
+		Notes:    notes,
+		ListName: r.ListName,
+		DueDate:  r.DueDate,
+		Priority: reminders.Priority(r.Priority),
+	}
+
+	if r.RemindMeDate != nil {
+		input.RemindMeDate = r.RemindMeDate
+	}
+
+	if r.URL != "" {
+		input.URL = r.URL
+	}
+
+	if r.Recurrence != nil {
+		input.RecurrenceRules = []eventkit.RecurrenceRule{toEventKitRule(r.Recurrence)}
+	}
+
+	created, err := b.client.CreateReminder(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to create reminder: %w", err)
+	}
+
+	// Set flagged via AppleScript if needed (EventKit can't set flagged)
+	if r.Flagged {
+		_ = b.FlagReminder(created.ID)
+	}
+
+	if r.Completed {
+		if err := b.CompleteReminder(created.ID); err != nil {
+			return created.ID, err
+		}
+	}
+
+	return created.ID, nil
+}
+
+// Reminder retrieves a single reminder by ID or ID prefix.
+func (b *EventKitBackend) Reminder(id string) (*reminder.Reminder, error) {
+	r, err := b.client.Reminder(id)
+	if err != nil {
+		return nil, fmt.Errorf("reminder not found: %s", id)
+	}
+	return fromEventKitReminder(r), nil
+}
+
+// Reminders returns reminders matching the given filter.
+func (b *EventKitBackend) Reminders(filter *reminder.ListFilter) ([]*reminder.Reminder, error) {
+	var opts []reminders.ListOption
+
+	if filter != nil {
+		if filter.ListName != "" {
+			opts = append(opts, reminders.WithList(filter.ListName))
+		}
+		if filter.Completed != nil {
+			opts = append(opts, reminders.WithCompleted(*filter.Completed))
+		}
+		if filter.SearchQuery != "" {
+			opts = append(opts, reminders.WithSearch(filter.SearchQuery))
+		}
+		if filter.DueBefore != nil {
+			opts = append(opts, reminders.WithDueBefore(*filter.DueBefore))
+		}
+		if filter.DueAfter != nil {
+			opts = append(opts, reminders.WithDueAfter(*filter.DueAfter))
+		}
+	}
+
+	ekReminders, err := b.client.Reminders(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	// Apply flagged filter — EventKit doesn't expose flagged, so we need
+	// JXA fallback when --flagged is active or the flags are asked for.
+	onlyFlagged := filter != nil && filter.Flagged != nil && *filter.Flagged
+	needsFlagged := onlyFlagged || (filter != nil && filter.LoadFlags)
+
+	var flaggedIDs map[string]bool
+	if needsFlagged {
+		flaggedIDs, err = b.fetchFlaggedIDs()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*reminder.Reminder, 0, len(ekReminders))
+	for i := range ekReminders {
+		r := fromEventKitReminder(&ekReminders[i])
+
+		if needsFlagged {
+			r.Flagged = flaggedIDs[r.ID]
+			if onlyFlagged && !r.Flagged {
+				continue
+			}
+		}
+		if filter != nil && filter.PriorityMin != nil && r.Priority.Rank() < filter.PriorityMin.Rank() {
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// fetchFlaggedIDs uses JXA to get the set of flagged reminder IDs.
+func (b *EventKitBackend) fetchFlaggedIDs() (map[string]bool, error) {
+	script := `
+const app = Application('Reminders');
+const lists = app.lists();
+const result = [];
+for (const list of lists) {
+	const n = list.reminders.length;
+	if (n === 0) continue;
+	const ids = list.reminders.id();
+	const flagged = list.reminders.flagged();
+	for (let i = 0; i < n; i++) {
+		if (flagged[i]) result.push(ids[i]);
+	}
+}
+JSON.stringify(result);`
+
+	output, err := b.exec.RunJXA(script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch flagged status: %w", err)
+	}
+
+	var ids []string
+	if output != "" && output != "[]" {
+		if err := json.Unmarshal([]byte(output), &ids); err != nil {
+			return nil, fmt.Errorf("failed to parse flagged IDs: %w", err)
+		}
+	}
+
+	m := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		m[id] = true
+	}
+	return m, nil
+}
+
+// UpdateReminder updates properties of an existing reminder.
+func (b *EventKitBackend) UpdateReminder(id string, updates map[string]any) error {
+	input := reminders.UpdateReminderInput{}
+	var needsAppleScript bool
+	var appleScriptUpdates map[string]any
+
+	for key, value := range updates {
+		switch key {
+		case "name":
+			v := value.(string)
+			input.Title = &v
+		case "body":
+			v := b.keepTimestampNotes(id, value.(string))
+			input.Notes = &v
+		case "due_date":
+			if value == nil {
+				input.ClearDueDate = true
+			} else {
+				t := value.(time.Time)
+				input.DueDate = &t
+			}
+		case "remind_me_date":
+			if value == nil {
+				// Clear remind me date by setting to zero time
+				t := time.Time{}
+				input.RemindMeDate = &t
+			} else {
+				t := value.(time.Time)
+				input.RemindMeDate = &t
+			}
+		case "priority":
+			p := reminders.Priority(value.(reminder.Priority))
+			input.Priority = &p
+		case "flagged":
+			// EventKit can't set flagged — use AppleScript
+			needsAppleScript = true
+			if appleScriptUpdates == nil {
+				appleScriptUpdates = make(map[string]any)
+			}
+			appleScriptUpdates["flagged"] = value
+		case "completed":
+			v := value.(bool)
+			input.Completed = &v
+		case "url":
+			v := value.(string)
+			input.URL = &v
+		case "recurrence":
+			rules := []eventkit.RecurrenceRule{}
+			if rec, ok := value.(*reminder.Recurrence); ok && rec != nil {
+				rules = append(rules, toEventKitRule(rec))
+			}
+			input.RecurrenceRules = &rules
+		}
+	}
+
+	// Apply EventKit updates (all fields except flagged)
+	hasEventKitUpdates := input.Title != nil || input.Notes != nil ||
+		input.DueDate != nil || input.ClearDueDate ||
+		input.RemindMeDate != nil || input.Priority != nil ||
+		input.Completed != nil || input.URL != nil ||
+		input.RecurrenceRules != nil
+
+	if hasEventKitUpdates {
+		if _, err := b.client.UpdateReminder(id, input); err != nil {
+			return fmt.Errorf("failed to update reminder: %w", err)
+		}
+	}
+
+	// Apply AppleScript updates (flagged)
+	if needsAppleScript {
+		if err := b.updateViaAppleScript(id, appleScriptUpdates); err != nil {
+			return fmt.Errorf("failed to update reminder flags: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// keepTimestampNotes carries the dates recorded in a reminder's notes over
+// to its new notes, which never include them since they are stripped when
+// the reminder is read.
+func (b *EventKitBackend) keepTimestampNotes(id, notes string) string {
+	current, err := b.client.Reminder(id)
+	if err != nil {
+		return notes
+	}
+	_, stamps := reminder.SplitTimestampNotes(current.Notes)
+	if stamps == "" {
+		return notes
+	}
+	if notes = strings.TrimSpace(notes); notes != "" {
+		notes += "\n\n"
+	}
+	return notes + stamps
+}
+
+// updateViaAppleScript updates reminder properties that EventKit doesn't support.
+func (b *EventKitBackend) updateViaAppleScript(id string, updates map[string]any) error {
+	var setStatements []string
+
+	for key, value := range updates {
+		switch key {
+		case "flagged":
+			if value.(bool) {
+				setStatements = append(setStatements, `set flagged of r to true`)
+			} else {
+				setStatements = append(setStatements, `set flagged of r to false`)
+			}
+		}
+	}
+
+	if len(setStatements) == 0 {
+		return nil
+	}
+
+	script := fmt.Sprintf(`tell application "Reminders"
+	set r to first reminder whose id is "%s"
+	%s
+end tell`, EscapeString(id), strings.Join(setStatements, "\n\t"))
+
+	_, err := b.exec.Run(script)
+	return err
+}
+
+// DeleteReminder deletes a reminder by ID.
+func (b *EventKitBackend) DeleteReminder(id string) error {
+	if err := b.client.DeleteReminder(id); err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+	return nil
+}
+
+// CompleteReminder marks a reminder as completed.
+func (b *EventKitBackend) CompleteReminder(id string) error {
+	if _, err := b.client.CompleteReminder(id); err != nil {
+		return fmt.Errorf("failed to complete reminder: %w", err)
+	}
+	return nil
+}
+
+// UncompleteReminder marks a reminder as incomplete.
+func (b *EventKitBackend) UncompleteReminder(id string) error {
+	if _, err := b.client.UncompleteReminder(id); err != nil {
+		return fmt.Errorf("failed to uncomplete reminder: %w", err)
+	}
+	return nil
+}
+
+// FlagReminder flags a reminder via AppleScript (EventKit doesn't support flagged).
+func (b *EventKitBackend) FlagReminder(id string) error {
+	return b.updateViaAppleScript(id, map[string]any{"flagged": true})
+}
+
+// UnflagReminder removes the flag from a reminder via AppleScript.
+func (b *EventKitBackend) UnflagReminder(id string) error {
+	return b.updateViaAppleScript(id, map[string]any{"flagged": false})
+}
+
+// fromEventKitReminder converts a go-eventkit Reminder to an internal Reminder.
+func fromEventKitReminder(r *reminders.Reminder) *reminder.Reminder {
+	result := &reminder.Reminder{
+		ID:               r.ID,
+		Name:             r.Title,
+		Body:             r.Notes,
+		ListName:         r.List,
+		DueDate:          r.DueDate,
+		RemindMeDate:     r.RemindMeDate,
+		CompletionDate:   r.CompletionDate,
+		CreationDate:     r.CreatedAt,
+		ModificationDate: r.ModifiedAt,
+		Priority:         reminder.Priority(r.Priority),
+		Completed:        r.Completed,
+		Flagged:          r.Flagged,
+		URL:              r.URL,
+	}
+
+	// For backwards compatibility: if URL is empty but notes contain a URL, extract it
+	if result.URL == "" && result.Body != "" {
+		result.URL = extractURL(result.Body)
+	}
+
+	// rem models a single rule; Reminders.app never creates more than one.
+	if len(r.RecurrenceRules) > 0 {
+		result.Recurrence = fromEventKitRule(&r.RecurrenceRules[0])
+	}
+
+	result.RestoreTimestamps()
+
+	return result
+}
+
+// toEventKitRule converts an internal Recurrence to a go-eventkit RecurrenceRule.
+// EventKit numbers weekdays from Sunday=1.
+func toEventKitRule(rec *reminder.Recurrence) eventkit.RecurrenceRule {
+	rule := eventkit.RecurrenceRule{
+		Frequency:       eventkit.RecurrenceFrequency(rec.Frequency),
+		Interval:        rec.Interval,
+		DaysOfTheMonth:  rec.ByMonthDay,
+		MonthsOfTheYear: rec.ByMonth,
+	}
+	if rule.Interval < 1 {
+		rule.Interval = 1
+	}
+	for _, d := range rec.ByDay {
+		rule.DaysOfTheWeek = append(rule.DaysOfTheWeek, eventkit.RecurrenceDayOfWeek{
+			DayOfTheWeek: eventkit.Weekday(d.Day + 1),
+			WeekNumber:   d.N,
+		})
+	}
+	if rec.Until != nil {
+		rule = rule.Until(*rec.Until)
+	} else if rec.Count > 0 {
+		rule = rule.Count(rec.Count)
+	}
+	return rule
+}
+
+// fromEventKitRule converts a go-eventkit RecurrenceRule to an internal Recurrence.
+func fromEventKitRule(rule *eventkit.RecurrenceRule) *reminder.Recurrence {
+	rec := &reminder.Recurrence{
+		Frequency:  reminder.Frequency(rule.Frequency),
+		Interval:   rule.Interval,
+		ByMonthDay: rule.DaysOfTheMonth,
+		ByMonth:    rule.MonthsOfTheYear,
+	}
+	for _, d := range rule.DaysOfTheWeek {
+		rec.ByDay = append(rec.ByDay, reminder.WeekdayNum{
+			N:   d.WeekNumber,
+			Day: time.Weekday(d.DayOfTheWeek - 1),
+		})
+	}
+	if rule.End != nil {
+		rec.Until = rule.End.EndDate
+		rec.Count = rule.End.OccurrenceCount
+	}
+	return rec
+}
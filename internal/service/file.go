@@ -0,0 +1,322 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// FileBackend is a Backend that keeps all lists and reminders in a single
+// JSON file. It works on any platform and needs no Reminders access. Each
+// write reloads the file and saves it while holding a lock on
+// <store>.lock, so rem processes running at once don't lose each other's
+// changes.
+type FileBackend struct {
+	*MemoryBackend
+	path string
+}
+
+// storeFile is the on-disk layout of a FileBackend store.
+type storeFile struct {
+	Version     int             `json:"version"`
+	DefaultList string          `json:"default_list"`
+	Lists       []storeList     `json:"lists"`
+	Reminders   []storeReminder `json:"reminders"`
+}
+
+type storeList struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+type storeReminder struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	Body             string     `json:"body,omitempty"`
+	ListName         string     `json:"list_name"`
+	DueDate          *time.Time `json:"due_date,omitempty"`
+	RemindMeDate     *time.Time `json:"remind_me_date,omitempty"`
+	CompletionDate   *time.Time `json:"completion_date,omitempty"`
+	CreationDate     *time.Time `json:"creation_date,omitempty"`
+	ModificationDate *time.Time `json:"modification_date,omitempty"`
+	Priority         int        `json:"priority"`
+	Flagged          bool       `json:"flagged"`
+	Completed        bool       `json:"completed"`
+	URL              string     `json:"url,omitempty"`
+	Recurrence       string     `json:"recurrence,omitempty"`
+}
+
+const storeVersion = 1
+
+// DefaultStorePath returns the store location for the file backend:
+// $REM_STORE if set, otherwise rem/store.json under $XDG_DATA_HOME
+// (falling back to ~/.local/share).
+func DefaultStorePath() (string, error) {
+	if p := os.Getenv("REM_STORE"); p != "" {
+		return p, nil
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "rem", "store.json"), nil
+}
+
+// NewFileBackend opens the store at path. A missing file is treated as an
+// empty store and is created on the first write.
+func NewFileBackend(path string) (*FileBackend, error) {
+	b := &FileBackend{MemoryBackend: NewMemoryBackend(), path: path}
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Path returns the location of the store file.
+func (b *FileBackend) Path() string {
+	return b.path
+}
+
+// CreateList creates a new list and saves the store.
+func (b *FileBackend) CreateList(name string) (*reminder.List, error) {
+	var l *reminder.List
+	err := b.update(func() (err error) {
+		l, err = b.MemoryBackend.CreateList(name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// RenameList renames a list and saves the store.
+func (b *FileBackend) RenameList(oldName, newName string) error {
+	return b.update(func() error {
+		return b.MemoryBackend.RenameList(oldName, newName)
+	})
+}
+
+// DeleteList deletes a list with its reminders and saves the store.
+func (b *FileBackend) DeleteList(name string) error {
+	return b.update(func() error {
+		return b.MemoryBackend.DeleteList(name)
+	})
+}
+
+// CreateReminder creates a reminder and saves the store.
+func (b *FileBackend) CreateReminder(r *reminder.Reminder) (string, error) {
+	var id string
+	err := b.update(func() (err error) {
+		id, err = b.MemoryBackend.CreateReminder(r)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UpdateReminder updates a reminder and saves the store.
+func (b *FileBackend) UpdateReminder(id string, updates map[string]any) error {
+	return b.update(func() error {
+		return b.MemoryBackend.UpdateReminder(id, updates)
+	})
+}
+
+// DeleteReminder deletes a reminder and saves the store.
+func (b *FileBackend) DeleteReminder(id string) error {
+	return b.update(func() error {
+		return b.MemoryBackend.DeleteReminder(id)
+	})
+}
+
+// CompleteReminder marks a reminder as completed and saves the store.
+func (b *FileBackend) CompleteReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"completed": true})
+}
+
+// UncompleteReminder marks a reminder as incomplete and saves the store.
+func (b *FileBackend) UncompleteReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"completed": false})
+}
+
+// FlagReminder flags a reminder and saves the store.
+func (b *FileBackend) FlagReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"flagged": true})
+}
+
+// UnflagReminder removes the flag from a reminder and saves the store.
+func (b *FileBackend) UnflagReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"flagged": false})
+}
+
+// update runs op on the latest contents of the store and saves the result,
+// holding the store lock throughout.
+func (b *FileBackend) update(op func() error) error {
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+	unlock, err := lockFile(b.path + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock store: %w", err)
+	}
+	defer unlock()
+
+	if err := b.reload(); err != nil {
+		return err
+	}
+	if err := op(); err != nil {
+		return err
+	}
+	return b.save()
+}
+
+// reload replaces the lists and reminders in memory with the store's.
+func (b *FileBackend) reload() error {
+	m := b.MemoryBackend
+	m.mu.Lock()
+	m.lists, m.reminders, m.defaultList = nil, nil, DefaultListName
+	m.mu.Unlock()
+	return b.load()
+}
+
+func (b *FileBackend) load() error {
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read store: %w", err)
+	}
+
+	var sf storeFile
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return fmt.Errorf("failed to parse store %s: %w", b.path, err)
+	}
+	if sf.Version > storeVersion {
+		return fmt.Errorf("store %s has version %d, this rem supports up to %d", b.path, sf.Version, storeVersion)
+	}
+
+	m := b.MemoryBackend
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sf.DefaultList != "" {
+		m.defaultList = sf.DefaultList
+	}
+	for _, l := range sf.Lists {
+		m.lists = append(m.lists, &reminder.List{ID: l.ID, Name: l.Name, Color: l.Color})
+	}
+	for _, r := range sf.Reminders {
+		var rec *reminder.Recurrence
+		if r.Recurrence != "" {
+			rec, err = reminder.ParseRRule(r.Recurrence, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid recurrence for reminder %s in %s: %w", r.ID, b.path, err)
+			}
+		}
+		m.reminders = append(m.reminders, &reminder.Reminder{
+			ID:               r.ID,
+			Name:             r.Name,
+			Body:             r.Body,
+			ListName:         r.ListName,
+			DueDate:          r.DueDate,
+			RemindMeDate:     r.RemindMeDate,
+			CompletionDate:   r.CompletionDate,
+			CreationDate:     r.CreationDate,
+			ModificationDate: r.ModificationDate,
+			Priority:         reminder.Priority(r.Priority),
+			Flagged:          r.Flagged,
+			Completed:        r.Completed,
+			URL:              r.URL,
+			Recurrence:       rec,
+		})
+	}
+	return nil
+}
+
+// save writes the store atomically.
+func (b *FileBackend) save() error {
+	m := b.MemoryBackend
+	m.mu.Lock()
+	sf := storeFile{
+		Version:     storeVersion,
+		DefaultList: m.defaultList,
+		Lists:       make([]storeList, 0, len(m.lists)),
+		Reminders:   make([]storeReminder, 0, len(m.reminders)),
+	}
+	for _, l := range m.lists {
+		sf.Lists = append(sf.Lists, storeList{ID: l.ID, Name: l.Name, Color: l.Color})
+	}
+	for _, r := range m.reminders {
+		rec := ""
+		if r.Recurrence != nil {
+			rec = r.Recurrence.RRule()
+		}
+		sf.Reminders = append(sf.Reminders, storeReminder{
+			ID:               r.ID,
+			Name:             r.Name,
+			Body:             r.Body,
+			ListName:         r.ListName,
+			DueDate:          r.DueDate,
+			RemindMeDate:     r.RemindMeDate,
+			CompletionDate:   r.CompletionDate,
+			CreationDate:     r.CreationDate,
+			ModificationDate: r.ModificationDate,
+			Priority:         int(r.Priority),
+			Flagged:          r.Flagged,
+			Completed:        r.Completed,
+			URL:              r.URL,
+			Recurrence:       rec,
+		})
+	}
+	m.mu.Unlock()
+
+	data, err := json.MarshalIndent(sf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode store: %w", err)
+	}
+
+	if err := writeFileAtomic(b.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to path by renaming a temporary file into
+// place with mode perm, creating the parent directory if needed.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
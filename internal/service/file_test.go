@@ -0,0 +1,133 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+func TestFileBackendPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rem", "store.json")
+
+	b, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+	if _, err := b.CreateList("Work"); err != nil {
+		t.Fatalf("CreateList failed: %v", err)
+	}
+
+	due := time.Date(2026, 2, 15, 14, 30, 0, 0, time.UTC)
+	id, err := b.CreateReminder(&reminder.Reminder{
+		Name:     "Review PR",
+		ListName: "Work",
+		DueDate:  &due,
+		Priority: reminder.PriorityMedium,
+		URL:      "https://example.com",
+		Recurrence: &reminder.Recurrence{
+			Frequency: reminder.FrequencyWeekly,
+			ByDay:     []reminder.WeekdayNum{{Day: time.Monday}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+	if err := b.FlagReminder(id); err != nil {
+		t.Fatalf("FlagReminder failed: %v", err)
+	}
+
+	reopened, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("reopening store failed: %v", err)
+	}
+	r, err := reopened.Reminder(id)
+	if err != nil {
+		t.Fatalf("Reminder failed after reopen: %v", err)
+	}
+	if r.Name != "Review PR" || r.ListName != "Work" || r.URL != "https://example.com" {
+		t.Errorf("fields not persisted: %+v", r)
+	}
+	if r.DueDate == nil || !r.DueDate.Equal(due) {
+		t.Errorf("DueDate = %v, want %v", r.DueDate, due)
+	}
+	if r.Recurrence == nil || r.Recurrence.RRule() != "FREQ=WEEKLY;BYDAY=MO" {
+		t.Errorf("Recurrence not persisted: %v", r.Recurrence)
+	}
+	if r.Priority != reminder.PriorityMedium || !r.Flagged {
+		t.Errorf("Priority/Flagged not persisted: %v/%v", r.Priority, r.Flagged)
+	}
+
+	lists, _ := reopened.Lists()
+	if len(lists) != 1 || lists[0].Name != "Work" || lists[0].Count != 1 {
+		t.Errorf("lists not persisted: %+v", lists)
+	}
+}
+
+func TestFileBackendKeepsOtherWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	a, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+	b, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+
+	// Both stores were opened before either wrote, like two rem processes.
+	first, _ := a.CreateReminder(&reminder.Reminder{Name: "From a"})
+	if _, err := b.CreateReminder(&reminder.Reminder{Name: "From b"}); err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+	if err := a.FlagReminder(first); err != nil {
+		t.Fatalf("FlagReminder failed: %v", err)
+	}
+
+	reopened, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("reopening store failed: %v", err)
+	}
+	all, _ := reopened.Reminders(nil)
+	if len(all) != 2 {
+		t.Fatalf("expected both writers' reminders, got %+v", all)
+	}
+	if r, err := reopened.Reminder(first); err != nil || !r.Flagged {
+		t.Errorf("expected %s flagged, got %+v, %v", first, r, err)
+	}
+}
+
+func TestFileBackendMissingFile(t *testing.T) {
+	b, err := NewFileBackend(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("NewFileBackend failed: %v", err)
+	}
+	all, _ := b.Reminders(nil)
+	if len(all) != 0 {
+		t.Errorf("expected empty store, got %d reminders", len(all))
+	}
+}
+
+func TestFileBackendCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	os.WriteFile(path, []byte("{not json"), 0o644)
+
+	if _, err := NewFileBackend(path); err == nil {
+		t.Error("expected error for corrupt store, got nil")
+	}
+}
+
+func TestDefaultStorePath(t *testing.T) {
+	t.Setenv("REM_STORE", "/tmp/custom.json")
+	if p, _ := DefaultStorePath(); p != "/tmp/custom.json" {
+		t.Errorf("DefaultStorePath() = %q with REM_STORE set", p)
+	}
+
+	t.Setenv("REM_STORE", "")
+	t.Setenv("XDG_DATA_HOME", "/data")
+	if p, _ := DefaultStorePath(); p != filepath.Join("/data", "rem", "store.json") {
+		t.Errorf("DefaultStorePath() = %q with XDG_DATA_HOME set", p)
+	}
+}
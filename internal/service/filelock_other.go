@@ -0,0 +1,9 @@
+//go:build !unix
+
+package service
+
+// lockFile does nothing where flock isn't available; only one rem process
+// should use a store at a time there.
+func lockFile(path string) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// ImportMode says what an import does with reminders that already exist.
+type ImportMode string
+
+const (
+	// ImportCreate creates every imported reminder.
+	ImportCreate ImportMode = "create"
+	// ImportUpsert updates the reminders that match an imported one and
+	// creates the rest.
+	ImportUpsert ImportMode = "upsert"
+	// ImportSync upserts, then completes or deletes the reminders in the
+	// imported lists that the file no longer has.
+	ImportSync ImportMode = "sync"
+)
+
+// ParseImportMode reads an import mode name.
+func ParseImportMode(s string) (ImportMode, error) {
+	switch m := ImportMode(strings.ToLower(s)); m {
+	case ImportCreate, ImportUpsert, ImportSync:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid import mode %q (use create, upsert or sync)", s)
+}
+
+// ImportAction is what an import did to one reminder.
+type ImportAction string
+
+const (
+	ImportCreated   ImportAction = "created"
+	ImportUpdated   ImportAction = "updated"
+	ImportUnchanged ImportAction = "unchanged"
+	ImportCompleted ImportAction = "completed"
+	ImportDeleted   ImportAction = "deleted"
+)
+
+// FieldChange is a field an update changes, with both values formatted
+// for display.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ImportChange is a single change made, or in a dry run planned, by an
+// import.
+type ImportChange struct {
+	Action ImportAction
+	// ID is the reminder's ID; it is empty for creates in a dry run.
+	ID     string
+	Name   string
+	List   string
+	Fields []FieldChange
+}
+
+// importKeyFields are the fields a natural key can be built from.
+var importKeyFields = map[string]bool{"list": true, "name": true, "due": true, "url": true}
+
+// ParseImportKey reads the key imported reminders are matched on: "id",
+// or a natural key of fields joined with '+' from list, name, due and url
+// ("list+name+due").
+func ParseImportKey(s string) ([]string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "id" {
+		return []string{"id"}, nil
+	}
+	var key []string
+	for _, f := range strings.Split(s, "+") {
+		f = strings.TrimSpace(f)
+		if !importKeyFields[f] {
+			return nil, fmt.Errorf("invalid import key %q (use id, or fields from list, name, due and url joined with '+')", s)
+		}
+		key = append(key, f)
+	}
+	return key, nil
+}
+
+// ImportOptions configures an Importer.
+type ImportOptions struct {
+	Mode ImportMode
+	// Key holds the fields reminders are matched on (see ParseImportKey);
+	// the default is the reminder ID.
+	Key []string
+	// DeleteMissing makes sync delete reminders missing from the file
+	// instead of completing them.
+	DeleteMissing bool
+	// DryRun reports the changes without making them.
+	DryRun bool
+}
+
+// Importer applies imported reminders one at a time, creating the lists
+// they name as needed. Created reminders keep their completed state and
+// timestamps (see Backend.CreateReminder). In upsert and sync mode a
+// reminder that matches an existing one on the key updates it; fields
+// that are empty in the import (no date, no notes, priority none, not
+// flagged) leave the existing value alone, since most formats don't carry
+// every field. The completed state is always applied. The list of an
+// existing reminder is never changed.
+type Importer struct {
+	reminders *ReminderService
+	lists     *ListService
+	opts      ImportOptions
+
+	loaded      bool
+	defaultList string
+	existing    []*reminder.Reminder
+	byKey       map[string]*reminder.Reminder
+	seen        map[string]bool
+	listsSeen   map[string]bool
+}
+
+// NewImporter creates an Importer.
+func NewImporter(reminders *ReminderService, lists *ListService, opts ImportOptions) *Importer {
+	if opts.Mode == "" {
+		opts.Mode = ImportCreate
+	}
+	if len(opts.Key) == 0 {
+		opts.Key = []string{"id"}
+	}
+	return &Importer{reminders: reminders, lists: lists, opts: opts}
+}
+
+// load indexes the existing reminders by key.
+func (im *Importer) load() error {
+	if im.loaded {
+		return nil
+	}
+	var err error
+	if im.defaultList, err = im.lists.GetDefaultListName(); err != nil {
+		return fmt.Errorf("failed to get default list: %w", err)
+	}
+	if im.existing, err = im.reminders.ListReminders(nil); err != nil {
+		return err
+	}
+	im.byKey = make(map[string]*reminder.Reminder, len(im.existing))
+	for _, r := range im.existing {
+		if k := im.key(r); k != "" {
+			if _, dup := im.byKey[k]; !dup {
+				im.byKey[k] = r
+			}
+		}
+	}
+	im.seen = map[string]bool{}
+	im.listsSeen = map[string]bool{}
+	im.loaded = true
+	return nil
+}
+
+// key returns r's match key, or "" when r can't be matched.
+func (im *Importer) key(r *reminder.Reminder) string {
+	parts := make([]string, 0, len(im.opts.Key))
+	for _, f := range im.opts.Key {
+		switch f {
+		case "id":
+			if r.ID == "" {
+				return ""
+			}
+			parts = append(parts, r.ID)
+		case "list":
+			parts = append(parts, strings.ToLower(im.list(r)))
+		case "name":
+			parts = append(parts, strings.ToLower(strings.Join(strings.Fields(r.Name), " ")))
+		case "due":
+			due := ""
+			if r.DueDate != nil {
+				due = r.DueDate.In(time.Local).Format("2006-01-02 15:04")
+			}
+			parts = append(parts, due)
+		case "url":
+			parts = append(parts, r.URL)
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// list returns the list r is in, or goes to.
+func (im *Importer) list(r *reminder.Reminder) string {
+	if r.ListName == "" {
+		return im.defaultList
+	}
+	return r.ListName
+}
+
+// Apply imports one reminder.
+func (im *Importer) Apply(r *reminder.Reminder) (ImportChange, error) {
+	if im.opts.Mode == ImportCreate {
+		return im.create(r)
+	}
+	if err := im.load(); err != nil {
+		return ImportChange{}, err
+	}
+	im.listsSeen[strings.ToLower(im.list(r))] = true
+
+	k := im.key(r)
+	existing, ok := im.byKey[k]
+	if k == "" || !ok {
+		change, err := im.create(r)
+		if err == nil && k != "" && !im.opts.DryRun {
+			// Later rows with the same key update this reminder.
+			created := *r
+			created.ID, created.ListName = change.ID, im.list(r)
+			im.byKey[k] = &created
+			im.seen[change.ID] = true
+		}
+		return change, err
+	}
+
+	im.seen[existing.ID] = true
+	updates, fields := reminderUpdates(existing, r)
+	change := ImportChange{Action: ImportUnchanged, ID: existing.ID, Name: existing.Name, List: existing.ListName}
+	if len(updates) == 0 {
+		return change, nil
+	}
+	change.Action, change.Fields = ImportUpdated, fields
+	if im.opts.DryRun {
+		return change, nil
+	}
+	if err := im.reminders.UpdateReminder(existing.ID, updates); err != nil {
+		return change, fmt.Errorf("failed to update '%s': %w", existing.Name, err)
+	}
+	applyUpdates(existing, updates)
+	return change, nil
+}
+
+func (im *Importer) create(r *reminder.Reminder) (ImportChange, error) {
+	change := ImportChange{Action: ImportCreated, Name: r.Name, List: r.ListName}
+	if im.opts.DryRun {
+		return change, nil
+	}
+	if r.ListName != "" {
+		if _, err := im.lists.GetList(r.ListName); err != nil {
+			if _, err := im.lists.CreateList(r.ListName); err != nil {
+				return change, err
+			}
+		}
+	}
+	c := *r
+	c.ID = ""
+	id, err := im.reminders.CreateReminder(&c)
+	change.ID = id
+	return change, err
+}
+
+// Finish ends a sync: reminders in the imported lists that no imported
+// reminder matched are completed, or deleted with DeleteMissing. It does
+// nothing in the other modes.
+func (im *Importer) Finish() ([]ImportChange, error) {
+	if im.opts.Mode != ImportSync || !im.loaded {
+		return nil, nil
+	}
+	var changes []ImportChange
+	for _, r := range im.existing {
+		if im.seen[r.ID] || !im.listsSeen[strings.ToLower(im.list(r))] {
+			continue
+		}
+		change := ImportChange{Action: ImportDeleted, ID: r.ID, Name: r.Name, List: r.ListName}
+		if !im.opts.DeleteMissing {
+			if r.Completed {
+				continue
+			}
+			change.Action = ImportCompleted
+		}
+		changes = append(changes, change)
+		if im.opts.DryRun {
+			continue
+		}
+		var err error
+		if im.opts.DeleteMissing {
+			err = im.reminders.DeleteReminder(r.ID)
+		} else {
+			err = im.reminders.CompleteReminder(r.ID)
+		}
+		if err != nil {
+			return changes, fmt.Errorf("failed to %s '%s': %w", strings.TrimSuffix(string(change.Action), "d"), r.Name, err)
+		}
+	}
+	return changes, nil
+}
+
+// reminderUpdates returns the UpdateReminder fields that bring r in line
+// with the non-empty fields of in, and the changes for display.
+func reminderUpdates(r, in *reminder.Reminder) (map[string]any, []FieldChange) {
+	updates := map[string]any{}
+	var fields []FieldChange
+	set := func(key string, value any, old, new string) {
+		updates[key] = value
+		fields = append(fields, FieldChange{Field: key, Old: old, New: new})
+	}
+	date := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.In(time.Local).Format("2006-01-02 15:04")
+	}
+
+	if in.Name != "" && in.Name != r.Name {
+		set("name", in.Name, r.Name, in.Name)
+	}
+	if in.Body != "" && in.Body != r.Body {
+		set("body", in.Body, r.Body, in.Body)
+	}
+	if in.DueDate != nil && (r.DueDate == nil || !in.DueDate.Equal(*r.DueDate)) {
+		set("due_date", *in.DueDate, date(r.DueDate), date(in.DueDate))
+	}
+	if in.RemindMeDate != nil && (r.RemindMeDate == nil || !in.RemindMeDate.Equal(*r.RemindMeDate)) {
+		set("remind_me_date", *in.RemindMeDate, date(r.RemindMeDate), date(in.RemindMeDate))
+	}
+	if in.Priority != reminder.PriorityNone && in.Priority != r.Priority {
+		set("priority", in.Priority, r.Priority.String(), in.Priority.String())
+	}
+	if in.Flagged && !r.Flagged {
+		set("flagged", true, "false", "true")
+	}
+	if in.Completed != r.Completed {
+		set("completed", in.Completed, strconv.FormatBool(r.Completed), strconv.FormatBool(in.Completed))
+	}
+	if in.URL != "" && in.URL != r.URL {
+		set("url", in.URL, r.URL, in.URL)
+	}
+	if in.Recurrence != nil && (r.Recurrence == nil || in.Recurrence.RRule() != r.Recurrence.RRule()) {
+		old := ""
+		if r.Recurrence != nil {
+			old = r.Recurrence.String()
+		}
+		set("recurrence", in.Recurrence, old, in.Recurrence.String())
+	}
+	return updates, fields
+}
+
+// applyUpdates mirrors an update on the Importer's copy of a reminder, so
+// a later row with the same key is compared with the new values.
+func applyUpdates(r *reminder.Reminder, updates map[string]any) {
+	for key, value := range updates {
+		switch key {
+		case "name":
+			r.Name = value.(string)
+		case "body":
+			r.Body = value.(string)
+		case "due_date":
+			t := value.(time.Time)
+			r.DueDate = &t
+		case "remind_me_date":
+			t := value.(time.Time)
+			r.RemindMeDate = &t
+		case "priority":
+			r.Priority = value.(reminder.Priority)
+		case "flagged":
+			r.Flagged = value.(bool)
+		case "completed":
+			r.Completed = value.(bool)
+		case "url":
+			r.URL = value.(string)
+		case "recurrence":
+			r.Recurrence = value.(*reminder.Recurrence)
+		}
+	}
+}
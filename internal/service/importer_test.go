@@ -0,0 +1,121 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+func TestImporter(t *testing.T) {
+	b := NewMemoryBackend()
+	b.CreateList("Work")
+	due := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	reportID, _ := b.CreateReminder(&reminder.Reminder{Name: "Write report", ListName: "Work", DueDate: &due})
+	staleID, _ := b.CreateReminder(&reminder.Reminder{Name: "Stale task", ListName: "Work"})
+	b.CreateList("Home")
+	homeID, _ := b.CreateReminder(&reminder.Reminder{Name: "Water plants", ListName: "Home"})
+
+	later := due.Add(24 * time.Hour)
+	rows := func() []*reminder.Reminder {
+		return []*reminder.Reminder{
+			{ID: reportID, Name: "Write report", ListName: "Work", DueDate: &later, Priority: reminder.PriorityHigh},
+			{Name: "Plan offsite", ListName: "Work"},
+		}
+	}
+	apply := func(opts ImportOptions) []ImportChange {
+		t.Helper()
+		im := NewImporter(NewReminderService(b), NewListService(b), opts)
+		var changes []ImportChange
+		for _, r := range rows() {
+			c, err := im.Apply(r)
+			if err != nil {
+				t.Fatalf("Apply(%s) failed: %v", r.Name, err)
+			}
+			changes = append(changes, c)
+		}
+		missing, err := im.Finish()
+		if err != nil {
+			t.Fatalf("Finish failed: %v", err)
+		}
+		return append(changes, missing...)
+	}
+	actions := func(changes []ImportChange) string {
+		var got []string
+		for _, c := range changes {
+			got = append(got, string(c.Action))
+		}
+		return strings.Join(got, ",")
+	}
+
+	changes := apply(ImportOptions{Mode: ImportSync, DryRun: true})
+	if got := actions(changes); got != "updated,created,completed" {
+		t.Fatalf("dry-run actions = %s", got)
+	}
+	if f := changes[0].Fields; len(f) != 2 || f[0].Field != "due_date" || f[1].Field != "priority" || f[1].New != "high" {
+		t.Errorf("unexpected field changes: %+v", f)
+	}
+	if all, _ := b.Reminders(nil); len(all) != 3 {
+		t.Fatalf("dry run changed reminders: %d", len(all))
+	}
+
+	if got := actions(apply(ImportOptions{Mode: ImportSync})); got != "updated,created,completed" {
+		t.Fatalf("sync actions = %s", got)
+	}
+	report, _ := b.Reminder(reportID)
+	if !report.DueDate.Equal(later) || report.Priority != reminder.PriorityHigh {
+		t.Errorf("report not updated: %+v", report)
+	}
+	if stale, _ := b.Reminder(staleID); !stale.Completed {
+		t.Error("expected the reminder missing from the file to be completed")
+	}
+	if home, _ := b.Reminder(homeID); home.Completed {
+		t.Error("sync touched a list the file doesn't have")
+	}
+
+	// Matching on the natural key finds the reminder created above.
+	key, err := ParseImportKey("list+name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(apply(ImportOptions{Mode: ImportUpsert, Key: key})); got != "unchanged,unchanged" {
+		t.Fatalf("upsert actions = %s", got)
+	}
+	if all, _ := b.Reminders(nil); len(all) != 4 {
+		t.Fatalf("upsert created duplicates: %d reminders", len(all))
+	}
+
+	// Matching on ID, the row without one is created again and the
+	// earlier copy counts as missing.
+	if got := actions(apply(ImportOptions{Mode: ImportSync, DeleteMissing: true})); got != "unchanged,created,deleted,deleted" {
+		t.Fatalf("sync --missing delete actions = %s", got)
+	}
+	if _, err := b.Reminder(staleID); err == nil {
+		t.Error("expected the missing reminder to be deleted")
+	}
+}
+
+func TestParseImportKey(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"id", "id", false},
+		{"list+name+due", "list,name,due", false},
+		{" Name + URL ", "name,url", false},
+		{"list+title", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseImportKey(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseImportKey(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("ParseImportKey(%q) = %v, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// DefaultListName is the list used by non-EventKit backends when a reminder
+// is created without a list, matching the macOS Reminders default.
+const DefaultListName = "Reminders"
+
+// MemoryBackend is an in-memory Backend. It is used as a fake in tests and
+// as the core of backends that persist state elsewhere.
+type MemoryBackend struct {
+	mu          sync.Mutex
+	lists       []*reminder.List
+	reminders   []*reminder.Reminder
+	defaultList string
+}
+
+// NewMemoryBackend creates an empty MemoryBackend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{defaultList: DefaultListName}
+}
+
+// Lists returns all lists with their reminder counts.
+func (b *MemoryBackend) Lists() ([]*reminder.List, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make([]*reminder.List, 0, len(b.lists))
+	for _, l := range b.lists {
+		c := *l
+		c.Count = 0
+		for _, r := range b.reminders {
+			if r.ListName == l.Name {
+				c.Count++
+			}
+		}
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+// CreateList creates a new list.
+func (b *MemoryBackend) CreateList(name string) (*reminder.List, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.findList(name) != nil {
+		return nil, fmt.Errorf("list already exists: %s", name)
+	}
+	l := b.addList(name)
+	c := *l
+	return &c, nil
+}
+
+// RenameList renames a list and moves its reminders along with it.
+func (b *MemoryBackend) RenameList(oldName, newName string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l := b.findList(oldName)
+	if l == nil {
+		return fmt.Errorf("list not found: %s", oldName)
+	}
+	if b.findList(newName) != nil {
+		return fmt.Errorf("list already exists: %s", newName)
+	}
+
+	l.Name = newName
+	for _, r := range b.reminders {
+		if r.ListName == oldName {
+			r.ListName = newName
+		}
+	}
+	if b.defaultList == oldName {
+		b.defaultList = newName
+	}
+	return nil
+}
+
+// DeleteList deletes a list and all its reminders.
+func (b *MemoryBackend) DeleteList(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	idx := -1
+	for i, l := range b.lists {
+		if l.Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("list not found: %s", name)
+	}
+	b.lists = append(b.lists[:idx], b.lists[idx+1:]...)
+
+	kept := b.reminders[:0]
+	for _, r := range b.reminders {
+		if r.ListName != name {
+			kept = append(kept, r)
+		}
+	}
+	b.reminders = kept
+	return nil
+}
+
+// DefaultListName returns the name of the default list.
+func (b *MemoryBackend) DefaultListName() (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.defaultList, nil
+}
+
+// Reminders returns copies of all reminders matching the filter.
+func (b *MemoryBackend) Reminders(filter *reminder.ListFilter) ([]*reminder.Reminder, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make([]*reminder.Reminder, 0, len(b.reminders))
+	for _, r := range b.reminders {
+		if matchesFilter(r, filter) {
+			c := *r
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
+
+// Reminder returns a copy of the reminder with the given ID or unique ID prefix.
+func (b *MemoryBackend) Reminder(id string) (*reminder.Reminder, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	r, err := b.findReminder(id)
+	if err != nil {
+		return nil, err
+	}
+	c := *r
+	return &c, nil
+}
+
+// CreateReminder stores a new reminder and returns its generated ID.
+// The reminder goes to the default list when ListName is empty. Creation,
+// modification and completion dates set on r are kept.
+func (b *MemoryBackend) CreateReminder(r *reminder.Reminder) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	listName := r.ListName
+	if listName == "" {
+		listName = b.defaultList
+		if b.findList(listName) == nil {
+			b.addList(listName)
+		}
+	}
+	if b.findList(listName) == nil {
+		return "", fmt.Errorf("failed to create reminder: list not found: %s", listName)
+	}
+
+	now := time.Now()
+	c := *r
+	c.ID = newID()
+	c.ListName = listName
+	if c.CreationDate == nil {
+		c.CreationDate = &now
+	}
+	if c.ModificationDate == nil {
+		c.ModificationDate = &now
+	}
+	switch {
+	case !c.Completed:
+		c.CompletionDate = nil
+	case c.CompletionDate == nil:
+		c.CompletionDate = &now
+	}
+	b.reminders = append(b.reminders, &c)
+	return c.ID, nil
+}
+
+// UpdateReminder applies field updates to a reminder.
+func (b *MemoryBackend) UpdateReminder(id string, updates map[string]any) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	r, err := b.findReminder(id)
+	if err != nil {
+		return fmt.Errorf("failed to update reminder: %w", err)
+	}
+
+	for key, value := range updates {
+		switch key {
+		case "name":
+			r.Name = value.(string)
+		case "body":
+			r.Body = value.(string)
+		case "due_date":
+			r.DueDate = timeValue(value)
+		case "remind_me_date":
+			r.RemindMeDate = timeValue(value)
+		case "priority":
+			r.Priority = value.(reminder.Priority)
+		case "flagged":
+			r.Flagged = value.(bool)
+		case "completed":
+			setCompleted(r, value.(bool))
+		case "url":
+			r.URL = value.(string)
+		case "recurrence":
+			r.Recurrence, _ = value.(*reminder.Recurrence)
+		}
+	}
+	touch(r)
+	return nil
+}
+
+// DeleteReminder removes a reminder.
+func (b *MemoryBackend) DeleteReminder(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	r, err := b.findReminder(id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+	for i, existing := range b.reminders {
+		if existing == r {
+			b.reminders = append(b.reminders[:i], b.reminders[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// CompleteReminder marks a reminder as completed.
+func (b *MemoryBackend) CompleteReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"completed": true})
+}
+
+// UncompleteReminder marks a reminder as incomplete.
+func (b *MemoryBackend) UncompleteReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"completed": false})
+}
+
+// FlagReminder flags a reminder.
+func (b *MemoryBackend) FlagReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"flagged": true})
+}
+
+// UnflagReminder removes the flag from a reminder.
+func (b *MemoryBackend) UnflagReminder(id string) error {
+	return b.UpdateReminder(id, map[string]any{"flagged": false})
+}
+
+func (b *MemoryBackend) findList(name string) *reminder.List {
+	for _, l := range b.lists {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBackend) addList(name string) *reminder.List {
+	l := &reminder.List{ID: newID(), Name: name}
+	b.lists = append(b.lists, l)
+	return l
+}
+
+// findReminder looks up a reminder by exact ID, falling back to a unique prefix.
+func (b *MemoryBackend) findReminder(id string) (*reminder.Reminder, error) {
+	for _, r := range b.reminders {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+
+	var match *reminder.Reminder
+	for _, r := range b.reminders {
+		if id != "" && strings.HasPrefix(r.ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("ambiguous reminder ID prefix: %s", id)
+			}
+			match = r
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("reminder not found: %s", id)
+	}
+	return match, nil
+}
+
+// matchesFilter reports whether r satisfies filter, mirroring the filtering
+// EventKit applies for the same ListFilter.
+func matchesFilter(r *reminder.Reminder, filter *reminder.ListFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.ListName != "" && r.ListName != filter.ListName {
+		return false
+	}
+	if filter.Completed != nil && r.Completed != *filter.Completed {
+		return false
+	}
+	if filter.Flagged != nil && *filter.Flagged && !r.Flagged {
+		return false
+	}
+	if filter.DueBefore != nil && (r.DueDate == nil || !r.DueDate.Before(*filter.DueBefore)) {
+		return false
+	}
+	if filter.DueAfter != nil && (r.DueDate == nil || !r.DueDate.After(*filter.DueAfter)) {
+		return false
+	}
+	if filter.PriorityMin != nil && r.Priority.Rank() < filter.PriorityMin.Rank() {
+		return false
+	}
+	if filter.SearchQuery != "" {
+		q := strings.ToLower(filter.SearchQuery)
+		if !strings.Contains(strings.ToLower(r.Name), q) && !strings.Contains(strings.ToLower(r.Body), q) {
+			return false
+		}
+	}
+	return true
+}
+
+func setCompleted(r *reminder.Reminder, completed bool) {
+	if completed == r.Completed {
+		return
+	}
+	r.Completed = completed
+	if completed {
+		now := time.Now()
+		r.CompletionDate = &now
+	} else {
+		r.CompletionDate = nil
+	}
+}
+
+func touch(r *reminder.Reminder) {
+	now := time.Now()
+	r.ModificationDate = &now
+}
+
+// timeValue converts an update value (nil or time.Time) to a time pointer.
+// A nil value or zero time clears the date.
+func timeValue(value any) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t := value.(time.Time)
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// newID returns a random UUID-style identifier.
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
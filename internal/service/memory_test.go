@@ -0,0 +1,198 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+func TestMemoryBackendCreateAndGet(t *testing.T) {
+	svc := NewReminderService(NewMemoryBackend())
+
+	due := time.Date(2026, 2, 15, 14, 30, 0, 0, time.Local)
+	id, err := svc.CreateReminder(&reminder.Reminder{
+		Name:     "Buy groceries",
+		DueDate:  &due,
+		Priority: reminder.PriorityHigh,
+	})
+	if err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+
+	r, err := svc.GetReminder(id[:8])
+	if err != nil {
+		t.Fatalf("GetReminder by prefix failed: %v", err)
+	}
+	if r.Name != "Buy groceries" {
+		t.Errorf("Name = %q, want %q", r.Name, "Buy groceries")
+	}
+	if r.ListName != DefaultListName {
+		t.Errorf("ListName = %q, want %q", r.ListName, DefaultListName)
+	}
+	if r.CreationDate == nil {
+		t.Error("CreationDate should be set")
+	}
+}
+
+func TestMemoryBackendCreateRequiresName(t *testing.T) {
+	svc := NewReminderService(NewMemoryBackend())
+	if _, err := svc.CreateReminder(&reminder.Reminder{}); err == nil {
+		t.Error("expected error for empty name, got nil")
+	}
+}
+
+func TestMemoryBackendUnknownList(t *testing.T) {
+	svc := NewReminderService(NewMemoryBackend())
+	if _, err := svc.CreateReminder(&reminder.Reminder{Name: "x", ListName: "Nope"}); err == nil {
+		t.Error("expected error for unknown list, got nil")
+	}
+}
+
+func TestMemoryBackendFilter(t *testing.T) {
+	b := NewMemoryBackend()
+	svc := NewReminderService(b)
+	lists := NewListService(b)
+
+	if _, err := lists.CreateList("Work"); err != nil {
+		t.Fatalf("CreateList failed: %v", err)
+	}
+
+	now := time.Now()
+	soon := now.Add(time.Hour)
+	later := now.AddDate(0, 0, 10)
+	svc.CreateReminder(&reminder.Reminder{Name: "Review PR", ListName: "Work", DueDate: &soon})
+	svc.CreateReminder(&reminder.Reminder{Name: "Write invoice", ListName: "Work", DueDate: &later, Flagged: true, Priority: reminder.PriorityHigh})
+	svc.CreateReminder(&reminder.Reminder{Name: "Buy milk", Body: "whole milk", Priority: reminder.PriorityLow})
+
+	incomplete := false
+	flagged := true
+	cutoff := now.AddDate(0, 0, 7)
+	medium, low := reminder.PriorityMedium, reminder.PriorityLow
+
+	tests := []struct {
+		name   string
+		filter *reminder.ListFilter
+		want   int
+	}{
+		{"nil filter", nil, 3},
+		{"by list", &reminder.ListFilter{ListName: "Work"}, 2},
+		{"incomplete", &reminder.ListFilter{Completed: &incomplete}, 3},
+		{"flagged", &reminder.ListFilter{Flagged: &flagged}, 1},
+		{"due before", &reminder.ListFilter{DueBefore: &cutoff}, 1},
+		{"due after", &reminder.ListFilter{DueAfter: &now}, 2},
+		{"search notes", &reminder.ListFilter{SearchQuery: "WHOLE"}, 1},
+		{"priority at least medium", &reminder.ListFilter{PriorityMin: &medium}, 1},
+		{"priority at least low", &reminder.ListFilter{PriorityMin: &low}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ListReminders(tt.filter)
+			if err != nil {
+				t.Fatalf("ListReminders failed: %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("got %d reminders, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryBackendUpdateAndComplete(t *testing.T) {
+	svc := NewReminderService(NewMemoryBackend())
+	id, _ := svc.CreateReminder(&reminder.Reminder{Name: "Task"})
+
+	due := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	err := svc.UpdateReminder(id, map[string]any{
+		"name":     "Renamed",
+		"due_date": due,
+		"priority": reminder.PriorityLow,
+	})
+	if err != nil {
+		t.Fatalf("UpdateReminder failed: %v", err)
+	}
+	if err := svc.CompleteReminder(id); err != nil {
+		t.Fatalf("CompleteReminder failed: %v", err)
+	}
+	if err := svc.FlagReminder(id); err != nil {
+		t.Fatalf("FlagReminder failed: %v", err)
+	}
+
+	r, _ := svc.GetReminder(id)
+	if r.Name != "Renamed" || r.Priority != reminder.PriorityLow {
+		t.Errorf("update not applied: %+v", r)
+	}
+	if r.DueDate == nil || !r.DueDate.Equal(due) {
+		t.Errorf("DueDate = %v, want %v", r.DueDate, due)
+	}
+	if !r.Completed || r.CompletionDate == nil {
+		t.Error("expected reminder to be completed with a completion date")
+	}
+	if !r.Flagged {
+		t.Error("expected reminder to be flagged")
+	}
+
+	if err := svc.UpdateReminder(id, map[string]any{"due_date": nil}); err != nil {
+		t.Fatalf("clearing due date failed: %v", err)
+	}
+	if err := svc.UncompleteReminder(id); err != nil {
+		t.Fatalf("UncompleteReminder failed: %v", err)
+	}
+	r, _ = svc.GetReminder(id)
+	if r.DueDate != nil {
+		t.Errorf("DueDate = %v, want nil", r.DueDate)
+	}
+	if r.Completed || r.CompletionDate != nil {
+		t.Error("expected reminder to be incomplete without a completion date")
+	}
+}
+
+func TestMemoryBackendListOperations(t *testing.T) {
+	b := NewMemoryBackend()
+	svc := NewReminderService(b)
+	lists := NewListService(b)
+
+	lists.CreateList("Work")
+	svc.CreateReminder(&reminder.Reminder{Name: "A", ListName: "Work"})
+	svc.CreateReminder(&reminder.Reminder{Name: "B", ListName: "Work"})
+
+	if _, err := lists.CreateList("Work"); err == nil {
+		t.Error("expected error creating duplicate list")
+	}
+
+	if err := lists.RenameList("Work", "Office"); err != nil {
+		t.Fatalf("RenameList failed: %v", err)
+	}
+	l, err := lists.GetList("Office")
+	if err != nil {
+		t.Fatalf("GetList failed: %v", err)
+	}
+	if l.Count != 2 {
+		t.Errorf("Count = %d, want 2", l.Count)
+	}
+
+	if err := lists.DeleteList("Office"); err != nil {
+		t.Fatalf("DeleteList failed: %v", err)
+	}
+	all, _ := svc.ListReminders(nil)
+	if len(all) != 0 {
+		t.Errorf("expected reminders to be deleted with their list, got %d", len(all))
+	}
+}
+
+func TestMemoryBackendAmbiguousPrefix(t *testing.T) {
+	b := NewMemoryBackend()
+	b.reminders = []*reminder.Reminder{
+		{ID: "ABC-1", Name: "one", ListName: DefaultListName},
+		{ID: "ABC-2", Name: "two", ListName: DefaultListName},
+	}
+
+	if _, err := b.Reminder("ABC"); err == nil {
+		t.Error("expected error for ambiguous prefix")
+	}
+	r, err := b.Reminder("ABC-2")
+	if err != nil || r.Name != "two" {
+		t.Errorf("Reminder(ABC-2) = %v, %v", r, err)
+	}
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BRO3886/rem/internal/export"
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// SyncAction describes what a sync did to one task.
+type SyncAction string
+
+const (
+	SyncCreated     SyncAction = "created reminder"
+	SyncUpdated     SyncAction = "updated reminder"
+	SyncDeleted     SyncAction = "deleted reminder"
+	SyncAddedToFile SyncAction = "added to file"
+	SyncUpdatedFile SyncAction = "updated in file"
+	SyncRemovedFile SyncAction = "removed from file"
+	SyncConflict    SyncAction = "conflict, kept reminder"
+)
+
+// SyncChange is a single change made by a sync.
+type SyncChange struct {
+	Action SyncAction
+	Name   string
+}
+
+// TodoTxtSync reconciles reminders with a todo.txt file in both directions.
+// Synced lines carry the reminder ID as rem:<id>. A state file keeps every
+// task as it was last synced, which tells a change made in the file apart
+// from one made in Reminders. When both sides changed, Reminders wins.
+type TodoTxtSync struct {
+	reminders *ReminderService
+	lists     *ListService
+	opts      []export.Option
+}
+
+// NewTodoTxtSync creates a TodoTxtSync. The options are passed to the
+// todo.txt codec.
+func NewTodoTxtSync(reminders *ReminderService, lists *ListService, opts ...export.Option) *TodoTxtSync {
+	return &TodoTxtSync{reminders: reminders, lists: lists, opts: opts}
+}
+
+const syncStateVersion = 1
+
+type syncState struct {
+	Version int               `json:"version"`
+	Tasks   map[string]string `json:"tasks"`
+}
+
+// todoLine is a line of the todo.txt file; task is nil for blank lines.
+type todoLine struct {
+	text string
+	task *reminder.Reminder
+}
+
+// DefaultSyncStatePath returns where the sync state for the todo.txt file
+// at path is kept: rem/sync/<hash>.json under $XDG_STATE_HOME (falling
+// back to ~/.local/state).
+func DefaultSyncStatePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate home directory: %w", err)
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(stateHome, "rem", "sync", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// Sync reconciles the todo.txt file at path with Reminders, using the
+// state file at statePath, and returns the changes made. New lines become
+// reminders and new reminders are appended to the file; completed
+// reminders that were never synced are left out. With dryRun nothing is
+// written on either side.
+func (s *TodoTxtSync) Sync(path, statePath string, dryRun bool) ([]SyncChange, error) {
+	lines, err := s.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state, err := readSyncState(statePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.Tasks) > 0 {
+		// Read as empty, a moved or mistyped file would delete every
+		// reminder synced from it.
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s does not exist but %d tasks were synced from it; restore it, or remove %s to start a new sync", path, len(state.Tasks), statePath)
+		}
+	}
+	all, err := s.reminders.ListReminders(nil)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*reminder.Reminder, len(all))
+	for _, r := range all {
+		byID[r.ID] = r
+	}
+
+	var changes []SyncChange
+	synced := make(map[string]string)
+	seen := make(map[string]bool)
+	var out []string
+
+	for _, l := range lines {
+		if l.task == nil {
+			out = append(out, l.text)
+			continue
+		}
+		task := l.task
+		fileLine := s.format(task)
+		base, known := state.Tasks[task.ID]
+		r, exists := byID[task.ID]
+
+		if task.ID != "" && exists && !seen[task.ID] {
+			seen[task.ID] = true
+			remLine := s.format(r)
+			switch {
+			case fileLine == remLine:
+				out = append(out, l.text)
+			case fileLine != base && remLine == base:
+				if !dryRun {
+					if r, err = s.update(r, task); err != nil {
+						return nil, err
+					}
+					remLine = s.format(r)
+				}
+				changes = append(changes, SyncChange{SyncUpdated, task.Name})
+				out = append(out, remLine)
+			case fileLine != base && known:
+				changes = append(changes, SyncChange{SyncConflict, r.Name})
+				out = append(out, remLine)
+			default:
+				changes = append(changes, SyncChange{SyncUpdatedFile, r.Name})
+				out = append(out, remLine)
+			}
+			synced[r.ID] = remLine
+			continue
+		}
+
+		if task.ID != "" && known && fileLine == base {
+			// Synced before and untouched in the file: deleted in Reminders.
+			changes = append(changes, SyncChange{SyncRemovedFile, task.Name})
+			continue
+		}
+
+		// A new line, or one whose reminder is gone but was edited since.
+		changes = append(changes, SyncChange{SyncCreated, task.Name})
+		if dryRun {
+			out = append(out, l.text)
+			continue
+		}
+		created, err := s.create(task)
+		if err != nil {
+			return nil, err
+		}
+		line := s.format(created)
+		out = append(out, line)
+		synced[created.ID] = line
+		seen[created.ID] = true
+	}
+
+	for _, r := range all {
+		if seen[r.ID] {
+			continue
+		}
+		remLine := s.format(r)
+		base, known := state.Tasks[r.ID]
+		switch {
+		case known && remLine == base:
+			// Synced before and untouched in Reminders: deleted from the file.
+			changes = append(changes, SyncChange{SyncDeleted, r.Name})
+			if !dryRun {
+				if err := s.reminders.DeleteReminder(r.ID); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		case known:
+			changes = append(changes, SyncChange{SyncConflict, r.Name})
+		case r.Completed:
+			continue
+		default:
+			changes = append(changes, SyncChange{SyncAddedToFile, r.Name})
+		}
+		out = append(out, remLine)
+		synced[r.ID] = remLine
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+	data := ""
+	if len(out) > 0 {
+		data = strings.Join(out, "\n") + "\n"
+	}
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := writeFileAtomic(path, []byte(data), perm); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := writeSyncState(statePath, synced); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (s *TodoTxtSync) format(r *reminder.Reminder) string {
+	return export.FormatTodoTxt(r, s.opts...)
+}
+
+// readFile reads the todo.txt file; a missing file has no lines.
+func (s *TodoTxtSync) readFile(path string) ([]todoLine, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var lines []todoLine
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, todoLine{text: line})
+			continue
+		}
+		task, err := export.ParseTodoTxt(line, s.opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, err)
+		}
+		lines = append(lines, todoLine{text: line, task: task})
+	}
+	return lines, nil
+}
+
+// create adds a reminder for a todo.txt task, creating its list if needed.
+func (s *TodoTxtSync) create(task *reminder.Reminder) (*reminder.Reminder, error) {
+	if task.ListName != "" {
+		if _, err := s.lists.GetList(task.ListName); err != nil {
+			if _, err := s.lists.CreateList(task.ListName); err != nil {
+				return nil, err
+			}
+		}
+	}
+	c := *task
+	c.ID = ""
+	id, err := s.reminders.CreateReminder(&c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create '%s': %w", task.Name, err)
+	}
+	return s.reminders.GetReminder(id)
+}
+
+// update applies the fields a todo.txt line carries to r. The list can't
+// be changed this way; the file is rewritten with the reminder's list.
+func (s *TodoTxtSync) update(r, task *reminder.Reminder) (*reminder.Reminder, error) {
+	updates := map[string]any{}
+	if task.Name != r.Name {
+		updates["name"] = task.Name
+	}
+	if task.Priority != r.Priority {
+		updates["priority"] = task.Priority
+	}
+	// Compare due dates at the precision todo.txt keeps them.
+	if s.format(&reminder.Reminder{DueDate: task.DueDate}) != s.format(&reminder.Reminder{DueDate: r.DueDate}) {
+		if task.DueDate == nil {
+			updates["due_date"] = nil
+		} else {
+			updates["due_date"] = *task.DueDate
+		}
+	}
+	if task.URL != r.URL {
+		updates["url"] = task.URL
+	}
+	if strings.Join(task.Tags(), " ") != strings.Join(r.Tags(), " ") {
+		c := *r
+		c.SetTags(task.Tags())
+		updates["body"] = c.Body
+	}
+	if task.Completed != r.Completed {
+		updates["completed"] = task.Completed
+	}
+	if len(updates) > 0 {
+		if err := s.reminders.UpdateReminder(r.ID, updates); err != nil {
+			return nil, fmt.Errorf("failed to update '%s': %w", r.Name, err)
+		}
+	}
+	return s.reminders.GetReminder(r.ID)
+}
+
+func readSyncState(path string) (*syncState, error) {
+	state := &syncState{Tasks: map[string]string{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", path, err)
+	}
+	if state.Version > syncStateVersion {
+		return nil, fmt.Errorf("sync state %s has version %d, this rem supports up to %d", path, state.Version, syncStateVersion)
+	}
+	if state.Tasks == nil {
+		state.Tasks = map[string]string{}
+	}
+	return state, nil
+}
+
+func writeSyncState(path string, tasks map[string]string) error {
+	data, err := json.MarshalIndent(syncState{Version: syncStateVersion, Tasks: tasks}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	if err := writeFileAtomic(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
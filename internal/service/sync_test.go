@@ -0,0 +1,148 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+func TestTodoTxtSync(t *testing.T) {
+	b := NewMemoryBackend()
+	b.CreateList("Groceries")
+	milkID, _ := b.CreateReminder(&reminder.Reminder{Name: "Buy milk", ListName: "Groceries"})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "todo.txt")
+	statePath := filepath.Join(dir, "state.json")
+	write := func(s string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func() string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	s := NewTodoTxtSync(NewReminderService(b), NewListService(b))
+	sync := func(dryRun bool, want ...SyncAction) {
+		t.Helper()
+		changes, err := s.Sync(path, statePath, dryRun)
+		if err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		var got, wantActions []string
+		for _, c := range changes {
+			got = append(got, string(c.Action))
+		}
+		for _, a := range want {
+			wantActions = append(wantActions, string(a))
+		}
+		if strings.Join(got, ",") != strings.Join(wantActions, ",") {
+			t.Fatalf("changes = %v, want %v", changes, want)
+		}
+	}
+
+	write("(B) Call mom +Family @phone due:2026-03-01\n")
+	sync(true, SyncCreated, SyncAddedToFile)
+	if all, _ := b.Reminders(nil); len(all) != 1 {
+		t.Fatalf("dry run created reminders: %d", len(all))
+	}
+
+	sync(false, SyncCreated, SyncAddedToFile)
+	all, _ := b.Reminders(&reminder.ListFilter{ListName: "Family"})
+	if len(all) != 1 || all[0].Name != "Call mom" || all[0].Priority != reminder.PriorityMedium || all[0].Body != "#phone" {
+		t.Fatalf("expected Call mom in Family, got %+v", all)
+	}
+	momID := all[0].ID
+	content := read()
+	if !strings.Contains(content, "Call mom +Family @phone due:2026-03-01 rem:"+momID) || !strings.Contains(content, "Buy milk +Groceries rem:"+milkID) {
+		t.Fatalf("unexpected file after first sync:\n%s", content)
+	}
+
+	sync(false)
+
+	// Complete a task in the file and rename one in Reminders.
+	write(strings.Replace(content, "(B) ", "x ", 1))
+	b.UpdateReminder(milkID, map[string]any{"name": "Buy oat milk"})
+	sync(false, SyncUpdated, SyncUpdatedFile)
+	if r, _ := b.Reminder(momID); !r.Completed {
+		t.Errorf("expected Call mom to be completed")
+	}
+	if content = read(); !strings.Contains(content, "Buy oat milk") {
+		t.Errorf("expected rename in file:\n%s", content)
+	}
+
+	// Both sides changed: the reminder wins.
+	write(strings.Replace(content, "Buy oat milk", "Buy soy milk", 1))
+	b.UpdateReminder(milkID, map[string]any{"name": "Buy almond milk"})
+	sync(false, SyncConflict)
+	if content = read(); !strings.Contains(content, "Buy almond milk") {
+		t.Errorf("expected reminder to win the conflict:\n%s", content)
+	}
+
+	// Deleting a line deletes the reminder, and the other way round.
+	var kept []string
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		if !strings.Contains(line, milkID) {
+			kept = append(kept, line)
+		}
+	}
+	write(strings.Join(kept, "\n") + "\n")
+	b.DeleteReminder(momID)
+	sync(false, SyncRemovedFile, SyncDeleted)
+	if all, _ := b.Reminders(nil); len(all) != 0 {
+		t.Errorf("expected no reminders left, got %d", len(all))
+	}
+	if content = read(); content != "" {
+		t.Errorf("expected empty file, got:\n%s", content)
+	}
+}
+
+func TestTodoTxtSyncErrors(t *testing.T) {
+	b := NewMemoryBackend()
+	path := filepath.Join(t.TempDir(), "todo.txt")
+	if err := os.WriteFile(path, []byte("Pay rent\nx 2026-02-01\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewTodoTxtSync(NewReminderService(b), NewListService(b))
+	_, err := s.Sync(path, filepath.Join(t.TempDir(), "state.json"), false)
+	if err == nil || !strings.Contains(err.Error(), "todo.txt:2") {
+		t.Fatalf("expected error on line 2, got %v", err)
+	}
+	if all, _ := b.Reminders(nil); len(all) != 0 {
+		t.Errorf("expected nothing created on a bad file, got %d", len(all))
+	}
+}
+
+func TestTodoTxtSyncMissingFile(t *testing.T) {
+	b := NewMemoryBackend()
+	b.CreateReminder(&reminder.Reminder{Name: "Buy milk"})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "todo.txt")
+	statePath := filepath.Join(dir, "state.json")
+
+	s := NewTodoTxtSync(NewReminderService(b), NewListService(b))
+	if _, err := s.Sync(path, statePath, false); err != nil {
+		t.Fatalf("first sync failed: %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.Sync(path, statePath, false)
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected an error for the missing file, got %v", err)
+	}
+	if all, _ := b.Reminders(nil); len(all) != 1 {
+		t.Errorf("expected the reminder to be kept, got %d", len(all))
+	}
+}
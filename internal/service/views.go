@@ -0,0 +1,188 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// View is a saved set of list filters. Dates and the query are kept as
+// written, e.g. "eow", and read again each time the view runs, so relative
+// dates always mean the same thing relative to now.
+type View struct {
+	Name      string `json:"name"`
+	List      string `json:"list,omitempty"`
+	Completed *bool  `json:"completed,omitempty"`
+	Flagged   bool   `json:"flagged,omitempty"`
+	DueBefore string `json:"due_before,omitempty"`
+	DueAfter  string `json:"due_after,omitempty"`
+	Search    string `json:"search,omitempty"`
+	Query     string `json:"query,omitempty"`
+}
+
+// Filter returns the view's filter, reading its dates with parseDate. The
+// query isn't part of it; callers match it against the filtered reminders.
+func (v *View) Filter(parseDate func(string) (time.Time, error)) (*reminder.ListFilter, error) {
+	filter := &reminder.ListFilter{
+		ListName:    v.List,
+		SearchQuery: v.Search,
+		Completed:   v.Completed,
+	}
+	if v.Flagged {
+		flagged := true
+		filter.Flagged = &flagged
+	}
+	if v.DueBefore != "" {
+		t, err := parseDate(v.DueBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due-before date %q in view '%s': %w", v.DueBefore, v.Name, err)
+		}
+		filter.DueBefore = &t
+	}
+	if v.DueAfter != "" {
+		t, err := parseDate(v.DueAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due-after date %q in view '%s': %w", v.DueAfter, v.Name, err)
+		}
+		filter.DueAfter = &t
+	}
+	return filter, nil
+}
+
+// Describe returns the view's filters as the flags that would recreate it.
+func (v *View) Describe() string {
+	var parts []string
+	add := func(flag, value string) {
+		if value != "" {
+			parts = append(parts, fmt.Sprintf("--%s %q", flag, value))
+		}
+	}
+	add("list", v.List)
+	if v.Completed != nil {
+		if *v.Completed {
+			parts = append(parts, "--completed")
+		} else {
+			parts = append(parts, "--incomplete")
+		}
+	}
+	if v.Flagged {
+		parts = append(parts, "--flagged")
+	}
+	add("due-before", v.DueBefore)
+	add("due-after", v.DueAfter)
+	add("search", v.Search)
+	add("query", v.Query)
+	return strings.Join(parts, " ")
+}
+
+// ViewStore keeps saved views in a JSON file.
+type ViewStore struct {
+	path  string
+	views map[string]*View
+}
+
+type viewsFile struct {
+	Version int     `json:"version"`
+	Views   []*View `json:"views"`
+}
+
+const viewsVersion = 1
+
+// DefaultViewsPath returns where saved views are kept: rem/views.json under
+// $XDG_CONFIG_HOME (falling back to ~/.config).
+func DefaultViewsPath() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate home directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "rem", "views.json"), nil
+}
+
+// OpenViewStore reads the views saved at path. A missing file is an empty
+// store.
+func OpenViewStore(path string) (*ViewStore, error) {
+	s := &ViewStore{path: path, views: map[string]*View{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read views: %w", err)
+	}
+	var f viewsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse views %s: %w", path, err)
+	}
+	if f.Version > viewsVersion {
+		return nil, fmt.Errorf("views %s have version %d, this rem supports up to %d", path, f.Version, viewsVersion)
+	}
+	for _, v := range f.Views {
+		s.views[strings.ToLower(v.Name)] = v
+	}
+	return s, nil
+}
+
+// Get returns the view called name, ignoring case.
+func (s *ViewStore) Get(name string) (*View, error) {
+	v, ok := s.views[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("view not found: %s", name)
+	}
+	return v, nil
+}
+
+// Views returns every saved view, sorted by name.
+func (s *ViewStore) Views() []*View {
+	views := make([]*View, 0, len(s.views))
+	for _, v := range s.views {
+		views = append(views, v)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return strings.ToLower(views[i].Name) < strings.ToLower(views[j].Name)
+	})
+	return views
+}
+
+// Save adds v, replacing any view with the same name, and writes the store.
+// It reports whether a view was replaced.
+func (s *ViewStore) Save(v *View) (bool, error) {
+	if strings.TrimSpace(v.Name) == "" {
+		return false, fmt.Errorf("view name is required")
+	}
+	key := strings.ToLower(v.Name)
+	_, replaced := s.views[key]
+	s.views[key] = v
+	return replaced, s.write()
+}
+
+// Delete removes the view called name and writes the store.
+func (s *ViewStore) Delete(name string) error {
+	key := strings.ToLower(name)
+	if _, ok := s.views[key]; !ok {
+		return fmt.Errorf("view not found: %s", name)
+	}
+	delete(s.views, key)
+	return s.write()
+}
+
+func (s *ViewStore) write() error {
+	data, err := json.MarshalIndent(viewsFile{Version: viewsVersion, Views: s.Views()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode views: %w", err)
+	}
+	if err := writeFileAtomic(s.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write views: %w", err)
+	}
+	return nil
+}
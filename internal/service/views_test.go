@@ -0,0 +1,65 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestViewStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rem", "views.json")
+	s, err := OpenViewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	incomplete := false
+	if _, err := s.Save(&View{Name: "Work week", List: "Work", Completed: &incomplete, DueBefore: "eow", Query: "priority>=medium"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Save(&View{Name: "Errands", Search: "buy"}); err != nil {
+		t.Fatal(err)
+	}
+	replaced, err := s.Save(&View{Name: "errands", Flagged: true})
+	if err != nil || !replaced {
+		t.Fatalf("Save of an existing name = %v, %v, want a replacement", replaced, err)
+	}
+
+	s, err = OpenViewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	views := s.Views()
+	if len(views) != 2 || views[0].Name != "errands" || views[1].Name != "Work week" {
+		t.Fatalf("Views() = %+v", views)
+	}
+	v, err := s.Get("WORK WEEK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.DueBefore != "eow" {
+		t.Errorf("DueBefore = %q, want it kept unevaluated", v.DueBefore)
+	}
+	if got, want := v.Describe(), `--list "Work" --incomplete --due-before "eow" --query "priority>=medium"`; got != want {
+		t.Errorf("Describe() = %s, want %s", got, want)
+	}
+
+	// Dates are read when the filter is built, not when the view is saved.
+	friday := time.Date(2026, 2, 13, 17, 0, 0, 0, time.UTC)
+	filter, err := v.Filter(func(s string) (time.Time, error) { return friday, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.ListName != "Work" || filter.DueBefore == nil || !filter.DueBefore.Equal(friday) || filter.Completed == nil || *filter.Completed {
+		t.Errorf("Filter() = %+v", filter)
+	}
+
+	if err := s.Delete("errands"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("errands"); err == nil {
+		t.Error("expected an error deleting a missing view")
+	}
+	if s, _ = OpenViewStore(path); len(s.Views()) != 1 {
+		t.Errorf("expected 1 view after delete, got %+v", s.Views())
+	}
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// column is a column of the reminders table and of plain output with
+// --columns. A column shows either text or a date. exact, when set, gives
+// the complete value where text is shortened for display.
+type column struct {
+	name   string
+	header string
+	text   func(r *reminder.Reminder) string
+	date   func(r *reminder.Reminder) *time.Time
+	exact  func(r *reminder.Reminder) string
+}
+
+// value returns the column's value for r, with dates in layout, and the
+// exact value if asked for.
+func (c column) value(r *reminder.Reminder, layout string, exact bool) string {
+	if exact && c.exact != nil {
+		return c.exact(r)
+	}
+	if c.date != nil {
+		t := c.date(r)
+		if t == nil {
+			return ""
+		}
+		return inDisplayZone(t).Format(layout)
+	}
+	return c.text(r)
+}
+
+var allColumns = []column{
+	{name: "id", header: "ID", text: func(r *reminder.Reminder) string { return shortID(r.ID) }, exact: func(r *reminder.Reminder) string { return r.ID }},
+	{name: "name", header: "Name", text: func(r *reminder.Reminder) string { return r.Name }},
+	{name: "list", header: "List", text: func(r *reminder.Reminder) string { return r.ListName }},
+	{name: "due", header: "Due", date: func(r *reminder.Reminder) *time.Time { return r.DueDate }},
+	{name: "priority", header: "Priority", text: func(r *reminder.Reminder) string { return r.Priority.String() }},
+	{name: "status", header: "Status", text: statusString},
+	{name: "notes", header: "Notes", text: func(r *reminder.Reminder) string { return oneLine(r.Body) }, exact: func(r *reminder.Reminder) string { return r.Body }},
+	{name: "url", header: "URL", text: func(r *reminder.Reminder) string { return r.URL }},
+	{name: "tags", header: "Tags", text: func(r *reminder.Reminder) string { return strings.Join(r.Tags(), ", ") }},
+	{name: "flagged", header: "Flagged", text: func(r *reminder.Reminder) string { return yesOrEmpty(r.Flagged) }},
+	{name: "repeat", header: "Repeats", text: func(r *reminder.Reminder) string {
+		if r.Recurrence == nil {
+			return ""
+		}
+		return r.Recurrence.String()
+	}},
+	{name: "remind", header: "Remind", date: func(r *reminder.Reminder) *time.Time { return r.RemindMeDate }},
+	{name: "created", header: "Created", date: func(r *reminder.Reminder) *time.Time { return r.CreationDate }},
+	{name: "modified", header: "Modified", date: func(r *reminder.Reminder) *time.Time { return r.ModificationDate }},
+	{name: "completed", header: "Completed", date: func(r *reminder.Reminder) *time.Time { return r.CompletionDate }},
+}
+
+var defaultColumns = []string{"id", "name", "list", "due", "priority", "status"}
+
+// columns are the columns chosen with SetColumns; nil shows the defaults.
+var columns []column
+
+// SetColumns chooses the columns of the reminders table and of plain
+// output from a comma-separated list such as "id,name,due,notes". An
+// empty list restores the defaults.
+func SetColumns(s string) error {
+	columns = nil
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var chosen []column
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		c, ok := findColumn(name)
+		if !ok {
+			return fmt.Errorf("invalid column %q (use %s)", name, columnNames())
+		}
+		chosen = append(chosen, c)
+	}
+	columns = chosen
+	return nil
+}
+
+// tableColumns returns the columns to show.
+func tableColumns() []column {
+	if columns != nil {
+		return columns
+	}
+	cols := make([]column, len(defaultColumns))
+	for i, name := range defaultColumns {
+		cols[i], _ = findColumn(name)
+	}
+	return cols
+}
+
+func findColumn(name string) (column, bool) {
+	for _, c := range allColumns {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return column{}, false
+}
+
+func columnNames() string {
+	names := make([]string, len(allColumns))
+	for i, c := range allColumns {
+		names[i] = c.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// oneLine joins the lines of s with spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func yesOrEmpty(b bool) string {
+	if b {
+		return "yes"
+	}
+	return ""
+}
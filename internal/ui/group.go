@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/BRO3886/rem/internal/export"
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/fatih/color"
+)
+
+// GroupBy is what reminders are grouped by in a listing.
+type GroupBy string
+
+const (
+	GroupNone     GroupBy = ""
+	GroupList     GroupBy = "list"
+	GroupDueDay   GroupBy = "due-day"
+	GroupPriority GroupBy = "priority"
+	GroupStatus   GroupBy = "status"
+)
+
+// ParseGroupBy parses a --group-by value.
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch g := GroupBy(strings.ToLower(s)); g {
+	case GroupNone, GroupList, GroupDueDay, GroupPriority, GroupStatus:
+		return g, nil
+	}
+	return GroupNone, fmt.Errorf("invalid group %q (use list, due-day, priority or status)", s)
+}
+
+// Group is a titled section of a grouped listing.
+type Group struct {
+	Name      string
+	Reminders []*reminder.Reminder
+}
+
+// groupKey returns the name of r's group and a key that orders groups:
+// lists by name, days by date with no due date last, priorities from high
+// to none and incomplete before completed.
+func (g GroupBy) groupKey(r *reminder.Reminder) (name, order string) {
+	switch g {
+	case GroupList:
+		return r.ListName, strings.ToLower(r.ListName)
+	case GroupDueDay:
+		if r.DueDate == nil {
+			return "No due date", "~"
+		}
+		t := inDisplayZone(r.DueDate)
+		return t.Format("Mon Jan 02, 2006"), t.Format("2006-01-02")
+	case GroupPriority:
+		return r.Priority.String(), fmt.Sprint(3 - r.Priority.Rank())
+	case GroupStatus:
+		if r.Completed {
+			return "completed", "1"
+		}
+		return "incomplete", "0"
+	}
+	return "", ""
+}
+
+// SortByGroup stably sorts reminders into the order of their groups, so
+// that each group's reminders are together and keep their relative order.
+func SortByGroup(reminders []*reminder.Reminder, g GroupBy) {
+	if g == GroupNone {
+		return
+	}
+	sort.SliceStable(reminders, func(i, j int) bool {
+		_, a := g.groupKey(reminders[i])
+		_, b := g.groupKey(reminders[j])
+		return a < b
+	})
+}
+
+// GroupReminders splits reminders sorted with SortByGroup into groups.
+func GroupReminders(reminders []*reminder.Reminder, g GroupBy) []Group {
+	var groups []Group
+	for _, r := range reminders {
+		name, _ := g.groupKey(r)
+		if len(groups) == 0 || groups[len(groups)-1].Name != name {
+			groups = append(groups, Group{Name: name})
+		}
+		last := &groups[len(groups)-1]
+		last.Reminders = append(last.Reminders, r)
+	}
+	return groups
+}
+
+// jsonGroup is a group in JSON output.
+type jsonGroup struct {
+	Group     string                `json:"group"`
+	Count     int                   `json:"count"`
+	Reminders []export.JSONReminder `json:"reminders"`
+}
+
+func toJSONGroup(g Group) jsonGroup {
+	jg := jsonGroup{Group: g.Name, Count: len(g.Reminders), Reminders: []export.JSONReminder{}}
+	for _, r := range g.Reminders {
+		jg.Reminders = append(jg.Reminders, export.ToJSON(r, exportOptions()...))
+	}
+	return jg
+}
+
+// PrintReminderGroups prints grouped reminders: a heading and a table or
+// plain lines per group, or an array of group objects in JSON and YAML (one
+// group per line in NDJSON). CSV and TSV get a leading group column, and
+// the template format prints the reminders in group order without
+// headings.
+func PrintReminderGroups(w io.Writer, groups []Group, format OutputFormat) {
+	switch format {
+	case FormatJSON, FormatYAML:
+		out := []jsonGroup{}
+		for _, g := range groups {
+			out = append(out, toJSONGroup(g))
+		}
+		if format == FormatYAML {
+			WriteYAML(w, out)
+			return
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(out)
+		return
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, g := range groups {
+			enc.Encode(toJSONGroup(g))
+		}
+		return
+	case FormatTemplate:
+		for _, g := range groups {
+			printRemindersTemplate(w, g.Reminders)
+		}
+		return
+	case FormatCSV, FormatTSV:
+		header, _ := reminderRows(nil, tableColumns(), format)
+		var rows [][]string
+		for _, g := range groups {
+			_, rs := reminderRows(g.Reminders, tableColumns(), format)
+			for _, row := range rs {
+				rows = append(rows, append([]string{g.Name}, row...))
+			}
+		}
+		WriteTable(w, append([]string{"group"}, header...), rows, format)
+		return
+	case FormatMarkdown:
+		for i, g := range groups {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "### %s (%d)\n\n", g.Name, len(g.Reminders))
+			header, rows := reminderRows(g.Reminders, tableColumns(), format)
+			WriteTable(w, header, rows, format)
+		}
+		return
+	}
+
+	if len(groups) == 0 {
+		if format != FormatPlain {
+			fmt.Fprintln(w, "No reminders found.")
+		}
+		return
+	}
+	bold := color.New(color.Bold).SprintFunc()
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if format == FormatPlain {
+			fmt.Fprintf(w, "%s (%d)\n", g.Name, len(g.Reminders))
+			printRemindersPlain(w, g.Reminders)
+			continue
+		}
+		fmt.Fprintf(w, "%s (%d)\n", bold(g.Name), len(g.Reminders))
+		printRemindersTable(w, g.Reminders)
+	}
+}
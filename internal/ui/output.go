@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/BRO3886/rem/internal/export"
 	"github.com/BRO3886/rem/internal/reminder"
@@ -18,21 +19,92 @@ import (
 type OutputFormat string
 
 const (
-	FormatTable OutputFormat = "table"
-	FormatJSON  OutputFormat = "json"
-	FormatPlain OutputFormat = "plain"
+	FormatTable  OutputFormat = "table"
+	FormatJSON   OutputFormat = "json"
+	FormatNDJSON OutputFormat = "ndjson"
+	FormatPlain  OutputFormat = "plain"
+	FormatYAML   OutputFormat = "yaml"
+	FormatCSV    OutputFormat = "csv"
+	FormatTSV    OutputFormat = "tsv"
+	// FormatMarkdown is a GitHub-flavored Markdown table.
+	FormatMarkdown OutputFormat = "markdown"
+	// FormatTemplate prints each reminder with the template set by
+	// SetTemplate, selected with -o template='...'.
+	FormatTemplate OutputFormat = "template"
 )
 
-// ParseOutputFormat parses an output format string.
-func ParseOutputFormat(s string) OutputFormat {
+// OutputFormats lists the values -o accepts, for help and error messages.
+const OutputFormats = "table, json, ndjson, plain, yaml, csv, tsv, markdown or template='...'"
+
+// ParseOutputFormat parses an output format string. An empty string is the
+// table format.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	if _, ok := TemplateText(s); ok {
+		return FormatTemplate, nil
+	}
 	switch strings.ToLower(s) {
+	case "", "table":
+		return FormatTable, nil
 	case "json":
-		return FormatJSON
+		return FormatJSON, nil
+	case "ndjson", "jsonl":
+		return FormatNDJSON, nil
 	case "plain", "text":
-		return FormatPlain
-	default:
-		return FormatTable
+		return FormatPlain, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "csv":
+		return FormatCSV, nil
+	case "tsv":
+		return FormatTSV, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	}
+	return FormatTable, fmt.Errorf("invalid output format %q (use %s)", s, OutputFormats)
+}
+
+// Structured reports whether the format is meant for programs or documents
+// rather than people at a terminal. Commands print no messages in
+// structured formats.
+func (f OutputFormat) Structured() bool {
+	switch f {
+	case FormatTable, FormatPlain:
+		return false
+	}
+	return true
+}
+
+const detailDateFormat = "Mon Jan 02, 2006 at 3:04 PM"
+
+// displayLocation is the zone dates are shown in; nil shows them in the
+// local zone.
+var displayLocation *time.Location
+
+// SetLocation sets the zone dates are displayed in. JSON output then
+// carries RFC 3339 timestamps with the zone's offset.
+func SetLocation(loc *time.Location) {
+	displayLocation = loc
+}
+
+// inDisplayZone converts t to the display zone.
+func inDisplayZone(t *time.Time) time.Time {
+	if displayLocation == nil {
+		return t.In(time.Local)
+	}
+	return t.In(displayLocation)
+}
+
+// FormatDate formats a date for display, in the display zone.
+func FormatDate(t time.Time) string {
+	return inDisplayZone(&t).Format(detailDateFormat)
+}
+
+// exportOptions returns the export options matching the display zone.
+func exportOptions() []export.Option {
+	if displayLocation == nil {
+		return nil
 	}
+	return []export.Option{export.WithLocation(displayLocation), export.WithRFC3339()}
 }
 
 // ColorsEnabled returns true if color output should be used.
@@ -45,8 +117,17 @@ func PrintReminders(w io.Writer, reminders []*reminder.Reminder, format OutputFo
 	switch format {
 	case FormatJSON:
 		printRemindersJSON(w, reminders)
+	case FormatNDJSON:
+		export.ExportNDJSON(w, reminders, exportOptions()...)
 	case FormatPlain:
 		printRemindersPlain(w, reminders)
+	case FormatTemplate:
+		printRemindersTemplate(w, reminders)
+	case FormatYAML:
+		WriteYAML(w, remindersJSON(reminders))
+	case FormatCSV, FormatTSV, FormatMarkdown:
+		header, rows := reminderRows(reminders, tableColumns(), format)
+		WriteTable(w, header, rows, format)
 	default:
 		printRemindersTable(w, reminders)
 	}
@@ -56,17 +137,54 @@ func PrintReminders(w io.Writer, reminders []*reminder.Reminder, format OutputFo
 func PrintReminderDetail(w io.Writer, r *reminder.Reminder, format OutputFormat) {
 	switch format {
 	case FormatJSON:
-		jr := export.ToJSON(r)
+		jr := export.ToJSON(r, exportOptions()...)
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		enc.Encode(jr)
+	case FormatNDJSON:
+		json.NewEncoder(w).Encode(export.ToJSON(r, exportOptions()...))
 	case FormatPlain:
 		printReminderPlainDetail(w, r)
+	case FormatTemplate:
+		printRemindersTemplate(w, []*reminder.Reminder{r})
+	case FormatYAML:
+		WriteYAML(w, export.ToJSON(r, exportOptions()...))
+	case FormatCSV, FormatTSV:
+		header, rows := reminderRows([]*reminder.Reminder{r}, allColumns, format)
+		WriteTable(w, header, rows, format)
+	case FormatMarkdown:
+		var rows [][]string
+		for _, c := range allColumns {
+			if v := c.value(r, "2006-01-02 15:04", false); v != "" {
+				rows = append(rows, []string{c.header, v})
+			}
+		}
+		WriteTable(w, []string{"Field", "Value"}, rows, format)
 	default:
 		printReminderRichDetail(w, r)
 	}
 }
 
+// PrintCreated prints the ID and name of something a command created, in
+// a structured format. A template sees a reminder with only ID and Name.
+func PrintCreated(w io.Writer, id, name string, format OutputFormat) {
+	switch format {
+	case FormatYAML:
+		WriteYAML(w, struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}{id, name})
+	case FormatCSV, FormatTSV, FormatMarkdown:
+		WriteTable(w, []string{"id", "name"}, [][]string{{id, name}}, format)
+	case FormatTemplate:
+		printRemindersTemplate(w, []*reminder.Reminder{{ID: id, Name: name}})
+	default:
+		quotedID, _ := json.Marshal(id)
+		quotedName, _ := json.Marshal(name)
+		fmt.Fprintf(w, "{\"id\": %s, \"name\": %s}\n", quotedID, quotedName)
+	}
+}
+
 // PrintLists outputs reminder lists in the specified format.
 func PrintLists(w io.Writer, lists []*reminder.List, format OutputFormat, showCount bool) {
 	switch format {
@@ -74,6 +192,11 @@ func PrintLists(w io.Writer, lists []*reminder.List, format OutputFormat, showCo
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		enc.Encode(lists)
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, l := range lists {
+			enc.Encode(l)
+		}
 	case FormatPlain:
 		for _, l := range lists {
 			if showCount {
@@ -82,6 +205,27 @@ func PrintLists(w io.Writer, lists []*reminder.List, format OutputFormat, showCo
 				fmt.Fprintln(w, l.Name)
 			}
 		}
+	case FormatYAML:
+		WriteYAML(w, lists)
+	case FormatCSV, FormatTSV:
+		rows := make([][]string, len(lists))
+		for i, l := range lists {
+			rows[i] = []string{l.ID, l.Name, l.Color, fmt.Sprint(l.Count)}
+		}
+		WriteTable(w, []string{"id", "name", "color", "count"}, rows, format)
+	case FormatMarkdown:
+		header := []string{"Name"}
+		if showCount {
+			header = append(header, "Reminders")
+		}
+		rows := make([][]string, len(lists))
+		for i, l := range lists {
+			rows[i] = []string{l.Name}
+			if showCount {
+				rows[i] = append(rows[i], fmt.Sprint(l.Count))
+			}
+		}
+		WriteTable(w, header, rows, format)
 	default:
 		printListsTable(w, lists, showCount)
 	}
@@ -107,29 +251,42 @@ func printRemindersTable(w io.Writer, reminders []*reminder.Reminder) {
 		return
 	}
 
+	cols := tableColumns()
+	headers := make([]any, len(cols))
+	for i, c := range cols {
+		headers[i] = c.header
+	}
 	table := newTable(w)
-	table.Header("ID", "Name", "List", "Due", "Priority", "Status")
+	table.Header(headers...)
 
 	for _, r := range reminders {
-		id := shortID(r.ID)
-		dueStr := ""
-		if r.DueDate != nil {
-			dueStr = r.DueDate.Format("Jan 02, 15:04")
+		row := make([]string, len(cols))
+		for i, c := range cols {
+			row[i] = c.value(r, "Jan 02, 15:04", false)
 		}
-		priority := r.Priority.String()
-		status := statusString(r)
-
-		table.Append([]string{id, r.Name, r.ListName, dueStr, priority, status})
+		table.Append(row)
 	}
 
 	table.Render()
 }
 
+// printRemindersPlain prints a line per reminder. With columns chosen by
+// SetColumns, the line is their values separated by tabs.
 func printRemindersPlain(w io.Writer, reminders []*reminder.Reminder) {
+	if columns != nil {
+		for _, r := range reminders {
+			values := make([]string, len(columns))
+			for i, c := range columns {
+				values[i] = c.value(r, "2006-01-02 15:04", false)
+			}
+			fmt.Fprintln(w, strings.Join(values, "\t"))
+		}
+		return
+	}
 	for _, r := range reminders {
 		dueStr := ""
 		if r.DueDate != nil {
-			dueStr = " (due: " + r.DueDate.Format("2006-01-02 15:04") + ")"
+			dueStr = " (due: " + inDisplayZone(r.DueDate).Format("2006-01-02 15:04") + ")"
 		}
 		statusMark := "[ ]"
 		if r.Completed {
@@ -140,7 +297,16 @@ func printRemindersPlain(w io.Writer, reminders []*reminder.Reminder) {
 }
 
 func printRemindersJSON(w io.Writer, reminders []*reminder.Reminder) {
-	export.ExportJSON(w, reminders)
+	export.ExportJSON(w, reminders, exportOptions()...)
+}
+
+// remindersJSON returns reminders as they appear in JSON output.
+func remindersJSON(reminders []*reminder.Reminder) []export.JSONReminder {
+	out := make([]export.JSONReminder, len(reminders))
+	for i, r := range reminders {
+		out[i] = export.ToJSON(r, exportOptions()...)
+	}
+	return out
 }
 
 func printReminderRichDetail(w io.Writer, r *reminder.Reminder) {
@@ -161,10 +327,13 @@ func printReminderRichDetail(w io.Writer, r *reminder.Reminder) {
 		fmt.Fprintf(w, "%s %s\n", bold("URL:"), cyan(r.URL))
 	}
 	if r.DueDate != nil {
-		fmt.Fprintf(w, "%s %s\n", bold("Due:"), r.DueDate.Format("Mon Jan 02, 2006 at 3:04 PM"))
+		fmt.Fprintf(w, "%s %s\n", bold("Due:"), inDisplayZone(r.DueDate).Format(detailDateFormat))
 	}
 	if r.RemindMeDate != nil {
-		fmt.Fprintf(w, "%s %s\n", bold("Remind:"), r.RemindMeDate.Format("Mon Jan 02, 2006 at 3:04 PM"))
+		fmt.Fprintf(w, "%s %s\n", bold("Remind:"), inDisplayZone(r.RemindMeDate).Format(detailDateFormat))
+	}
+	if r.Recurrence != nil {
+		fmt.Fprintf(w, "%s %s\n", bold("Repeats:"), r.Recurrence.String())
 	}
 
 	priorityStr := r.Priority.String()
@@ -189,10 +358,10 @@ func printReminderRichDetail(w io.Writer, r *reminder.Reminder) {
 	}
 
 	if r.CreationDate != nil {
-		fmt.Fprintf(w, "%s %s\n", bold("Created:"), r.CreationDate.Format("Mon Jan 02, 2006 at 3:04 PM"))
+		fmt.Fprintf(w, "%s %s\n", bold("Created:"), inDisplayZone(r.CreationDate).Format(detailDateFormat))
 	}
 	if r.ModificationDate != nil {
-		fmt.Fprintf(w, "%s %s\n", bold("Modified:"), r.ModificationDate.Format("Mon Jan 02, 2006 at 3:04 PM"))
+		fmt.Fprintf(w, "%s %s\n", bold("Modified:"), inDisplayZone(r.ModificationDate).Format(detailDateFormat))
 	}
 }
 
@@ -207,7 +376,10 @@ func printReminderPlainDetail(w io.Writer, r *reminder.Reminder) {
 		fmt.Fprintf(w, "URL: %s\n", r.URL)
 	}
 	if r.DueDate != nil {
-		fmt.Fprintf(w, "Due: %s\n", r.DueDate.Format("2006-01-02 15:04"))
+		fmt.Fprintf(w, "Due: %s\n", inDisplayZone(r.DueDate).Format("2006-01-02 15:04"))
+	}
+	if r.Recurrence != nil {
+		fmt.Fprintf(w, "Repeats: %s\n", r.Recurrence.String())
 	}
 	fmt.Fprintf(w, "Priority: %s\n", r.Priority.String())
 	if r.Completed {
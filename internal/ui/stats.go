@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// Stats are the figures rem stats reports.
+type Stats struct {
+	Total          int              `json:"total"`
+	Completed      int              `json:"completed"`
+	Incomplete     int              `json:"incomplete"`
+	Flagged        int              `json:"flagged"`
+	Overdue        int              `json:"overdue"`
+	CompletionRate Percent          `json:"completion_rate"`
+	Lists          int              `json:"lists"`
+	PerList        []*reminder.List `json:"-"`
+}
+
+// Percent is a percentage, encoded with one decimal place.
+type Percent float64
+
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(p), 'f', 1, 64)), nil
+}
+
+// PrintStats prints s. Structured formats carry the totals; the table and
+// Markdown also show the reminders per list.
+func PrintStats(w io.Writer, s Stats, format OutputFormat) {
+	figures := [][]string{
+		{"total", strconv.Itoa(s.Total)},
+		{"completed", strconv.Itoa(s.Completed)},
+		{"incomplete", strconv.Itoa(s.Incomplete)},
+		{"flagged", strconv.Itoa(s.Flagged)},
+		{"overdue", strconv.Itoa(s.Overdue)},
+		{"completion_rate", strconv.FormatFloat(float64(s.CompletionRate), 'f', 1, 64)},
+		{"lists", strconv.Itoa(s.Lists)},
+	}
+	perList := make([][]string, len(s.PerList))
+	for i, l := range s.PerList {
+		perList[i] = []string{l.Name, strconv.Itoa(l.Count)}
+	}
+
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(s)
+		return
+	case FormatNDJSON:
+		json.NewEncoder(w).Encode(s)
+		return
+	case FormatYAML:
+		WriteYAML(w, s)
+		return
+	case FormatCSV, FormatTSV:
+		WriteTable(w, []string{"stat", "value"}, figures, format)
+		return
+	case FormatMarkdown:
+		WriteTable(w, []string{"Stat", "Value"}, figures, format)
+		if len(perList) > 0 {
+			fmt.Fprintln(w)
+			WriteTable(w, []string{"List", "Reminders"}, perList, format)
+		}
+		return
+	}
+
+	fmt.Fprintln(w, "Reminder Statistics")
+	fmt.Fprintln(w, "===================")
+	fmt.Fprintf(w, "Total:           %d\n", s.Total)
+	fmt.Fprintf(w, "Completed:       %d\n", s.Completed)
+	fmt.Fprintf(w, "Incomplete:      %d\n", s.Incomplete)
+	fmt.Fprintf(w, "Flagged:         %d\n", s.Flagged)
+	fmt.Fprintf(w, "Overdue:         %d\n", s.Overdue)
+	fmt.Fprintf(w, "Completion Rate: %.1f%%\n", float64(s.CompletionRate))
+	fmt.Fprintf(w, "Lists:           %d\n", s.Lists)
+
+	if len(perList) > 0 {
+		fmt.Fprintln(w, "\nPer List:")
+		table := tablewriter.NewTable(w,
+			tablewriter.WithHeaderAlignment(tw.AlignLeft),
+			tablewriter.WithRowAlignment(tw.AlignLeft),
+		)
+		table.Header("List", "Reminders")
+		for _, row := range perList {
+			table.Append(row)
+		}
+		table.Render()
+	}
+}
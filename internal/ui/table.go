@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/BRO3886/rem/internal/reminder"
+)
+
+// WriteTable writes rows under header as CSV, TSV or a GitHub-flavored
+// Markdown table, or as a terminal table for any other format. TSV and
+// Markdown cells are kept to one line.
+func WriteTable(w io.Writer, header []string, rows [][]string, format OutputFormat) error {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	case FormatTSV:
+		for _, row := range append([][]string{header}, rows...) {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = strings.ReplaceAll(oneLine(cell), "\t", " ")
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatMarkdown:
+		line := func(cells []string) string {
+			escaped := make([]string, len(cells))
+			for i, cell := range cells {
+				escaped[i] = strings.ReplaceAll(oneLine(cell), "|", `\|`)
+			}
+			return "| " + strings.Join(escaped, " | ") + " |\n"
+		}
+		var b strings.Builder
+		b.WriteString(line(header))
+		b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+		for _, row := range rows {
+			b.WriteString(line(row))
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	headers := make([]any, len(header))
+	for i, h := range header {
+		headers[i] = h
+	}
+	table := newTable(w)
+	table.Header(headers...)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	return table.Render()
+}
+
+// reminderRows returns the header and rows of cols for reminders. CSV and
+// TSV get column names, full IDs and RFC 3339 dates; other formats get the
+// table's headers and short IDs.
+func reminderRows(reminders []*reminder.Reminder, cols []column, format OutputFormat) ([]string, [][]string) {
+	exact := format == FormatCSV || format == FormatTSV
+	layout := "2006-01-02 15:04"
+	if exact {
+		layout = time.RFC3339
+	}
+
+	header := make([]string, len(cols))
+	for i, c := range cols {
+		header[i] = c.header
+		if exact {
+			header[i] = c.name
+		}
+	}
+	rows := make([][]string, len(reminders))
+	for i, r := range reminders {
+		rows[i] = make([]string, len(cols))
+		for j, c := range cols {
+			rows[i][j] = c.value(r, layout, exact)
+		}
+	}
+	return header, rows
+}
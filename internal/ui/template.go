@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BRO3886/rem/internal/reminder"
+	"github.com/fatih/color"
+)
+
+// templateFormatPrefix starts an -o value that is a template, as in
+// -o template='{{.Name}}'.
+const templateFormatPrefix = "template="
+
+// outputTemplate is the template set with SetTemplate.
+var outputTemplate *template.Template
+
+// SetTemplate parses the template of the template output format. It is
+// executed once per reminder, with the *reminder.Reminder as data, and a
+// newline is added when its output doesn't end in one. Besides the
+// text/template builtins it has these functions:
+//
+//	date T [LAYOUT]    format a date (default "2006-01-02 15:04"); "" if unset
+//	due T [LAYOUT]     the same as date, as in {{due .DueDate "Mon 3pm"}}
+//	relative T         "in 3 days", "2 hours ago", "now"; "" if unset
+//	shortID ID         the first 8 characters of an ID
+//	truncate N S       S cut to N characters, ending in "…"
+//	join LIST SEP      strings.Join, as in {{join .Tags ", "}}
+//	upper, lower       change case
+//	red, green, yellow, blue, magenta, cyan, bold, dim
+//	                   color text (off with NO_COLOR or when not a terminal)
+func SetTemplate(text string) error {
+	t, err := template.New("output").Funcs(templateFuncs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid output template: %w", err)
+	}
+	// Catch misspelled fields and misused functions up front rather than
+	// in the middle of the output.
+	if err := t.Execute(io.Discard, sampleReminder()); err != nil {
+		return fmt.Errorf("invalid output template: %w", err)
+	}
+	outputTemplate = t
+	return nil
+}
+
+// sampleReminder has every field set, so that checking a template with it
+// doesn't trip over unset dates.
+func sampleReminder() *reminder.Reminder {
+	now := time.Now()
+	return &reminder.Reminder{
+		ID:               "x-apple-reminder://00000000-0000-0000-0000-000000000000",
+		Name:             "Sample",
+		Body:             "Notes #tag",
+		ListName:         "Reminders",
+		DueDate:          &now,
+		AllDayDueDate:    &now,
+		RemindMeDate:     &now,
+		CompletionDate:   &now,
+		CreationDate:     &now,
+		ModificationDate: &now,
+		Priority:         reminder.PriorityMedium,
+		URL:              "https://example.com",
+		Recurrence:       &reminder.Recurrence{Frequency: reminder.FrequencyDaily, Interval: 1},
+	}
+}
+
+// TemplateText returns the template of an -o value such as
+// "template={{.Name}}", and whether the value is one.
+func TemplateText(format string) (string, bool) {
+	if len(format) < len(templateFormatPrefix) || !strings.EqualFold(format[:len(templateFormatPrefix)], templateFormatPrefix) {
+		return "", false
+	}
+	return format[len(templateFormatPrefix):], true
+}
+
+func printRemindersTemplate(w io.Writer, reminders []*reminder.Reminder) {
+	if outputTemplate == nil {
+		return
+	}
+	var b strings.Builder
+	for _, r := range reminders {
+		b.Reset()
+		if err := outputTemplate.Execute(&b, r); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		out := b.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		io.WriteString(w, out)
+	}
+}
+
+func templateFuncs() template.FuncMap {
+	colorFunc := func(attrs ...color.Attribute) func(any) string {
+		c := color.New(attrs...)
+		return func(v any) string { return c.Sprint(v) }
+	}
+	return template.FuncMap{
+		"date":     templateDate,
+		"due":      templateDate,
+		"relative": templateRelative,
+		"shortID":  shortID,
+		"truncate": truncate,
+		"join":     strings.Join,
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"red":      colorFunc(color.FgRed),
+		"green":    colorFunc(color.FgGreen),
+		"yellow":   colorFunc(color.FgYellow),
+		"blue":     colorFunc(color.FgBlue),
+		"magenta":  colorFunc(color.FgMagenta),
+		"cyan":     colorFunc(color.FgCyan),
+		"bold":     colorFunc(color.Bold),
+		"dim":      colorFunc(color.Faint),
+	}
+}
+
+// templateTime accepts the date fields of a reminder, which are pointers,
+// as well as plain times. It reports false for an unset date.
+func templateTime(v any) (time.Time, bool, error) {
+	switch t := v.(type) {
+	case nil:
+		return time.Time{}, false, nil
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, false, nil
+		}
+		return inDisplayZone(t), true, nil
+	case time.Time:
+		return inDisplayZone(&t), true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("expected a date, got %T", v)
+}
+
+func templateDate(v any, layout ...string) (string, error) {
+	if len(layout) > 1 {
+		return "", fmt.Errorf("date takes one layout, got %d", len(layout))
+	}
+	t, ok, err := templateTime(v)
+	if !ok {
+		return "", err
+	}
+	if len(layout) == 0 {
+		return t.Format("2006-01-02 15:04"), nil
+	}
+	return t.Format(layout[0]), nil
+}
+
+func templateRelative(v any) (string, error) {
+	t, ok, err := templateTime(v)
+	if !ok {
+		return "", err
+	}
+	return relativeTime(t, time.Now()), nil
+}
+
+// relativeTime describes t relative to now in the largest whole unit, up
+// to weeks.
+func relativeTime(t, now time.Time) string {
+	d := t.Sub(now)
+	future := d > 0
+	if !future {
+		d = -d
+	}
+	var n int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	case d < 14*24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	default:
+		n, unit = int(d/(7*24*time.Hour)), "week"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// truncate shortens s to at most n characters, ending in "…" when cut.
+func truncate(n int, s string) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WriteYAML writes v as a YAML document. v is encoded as JSON first, so it
+// has the same fields, names and order as JSON output.
+func WriteYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n, err := readYAMLNode(dec)
+	if err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	var b strings.Builder
+	n.write(&b, 0)
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// yamlNode is a JSON value with its object keys in order.
+type yamlNode struct {
+	scalar string // YAML text of a scalar
+	keys   []string
+	values []*yamlNode
+	isMap  bool
+	isList bool
+}
+
+func readYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &yamlNode{isMap: t == '{', isList: t == '['}
+		for dec.More() {
+			if n.isMap {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key.(string))
+			}
+			v, err := readYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, v)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	case bool:
+		return &yamlNode{scalar: strconv.FormatBool(t)}, nil
+	case json.Number:
+		return &yamlNode{scalar: t.String()}, nil
+	case string:
+		return &yamlNode{scalar: yamlString(t)}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
+
+// inline returns the node's text when it fits after "key: " or "- ".
+func (n *yamlNode) inline() (string, bool) {
+	switch {
+	case n.isMap && len(n.values) == 0:
+		return "{}", true
+	case n.isList && len(n.values) == 0:
+		return "[]", true
+	case n.isMap || n.isList:
+		return "", false
+	}
+	return n.scalar, true
+}
+
+// write writes a block node at indent. Mappings inside lists start on the
+// line of their "- ".
+func (n *yamlNode) write(b *strings.Builder, indent int) {
+	if s, ok := n.inline(); ok {
+		b.WriteString(s + "\n")
+		return
+	}
+	pad := strings.Repeat(" ", indent)
+	for i, v := range n.values {
+		if n.isMap {
+			b.WriteString(pad + yamlString(n.keys[i]) + ":")
+			if s, ok := v.inline(); ok {
+				b.WriteString(" " + s + "\n")
+			} else {
+				b.WriteString("\n")
+				v.write(b, indent+2)
+			}
+			continue
+		}
+		b.WriteString(pad + "-")
+		if s, ok := v.inline(); ok {
+			b.WriteString(" " + s + "\n")
+			continue
+		}
+		if v.isMap {
+			// Write the mapping at indent+2 and put its first line after "- ".
+			var inner strings.Builder
+			v.write(&inner, indent+2)
+			b.WriteString(" " + strings.TrimPrefix(inner.String(), pad+"  "))
+			continue
+		}
+		b.WriteString("\n")
+		v.write(b, indent+2)
+	}
+}
+
+// yamlTimestamp matches the start of a YAML date or timestamp.
+var yamlTimestamp = regexp.MustCompile(`^[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}`)
+
+// yamlString returns s as a plain scalar when YAML would read it back as
+// the same string, and double-quoted when it would be read as a number,
+// date, boolean or null, or holds characters with a meaning in YAML.
+func yamlString(s string) string {
+	if s == "" || s != strings.TrimSpace(s) || strings.ContainsAny(s, ":#{}[],&*?|<>=!%@`\"'\\\n\t") ||
+		s == "-" || strings.HasPrefix(s, "- ") || strings.HasPrefix(s, "---") || strings.HasPrefix(s, "...") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~",
+		".inf", "+.inf", "-.inf", ".nan":
+		return strconv.Quote(s)
+	}
+	if yamlNumber(s) || yamlTimestamp.MatchString(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// yamlNumber reports whether s would be read as an integer or a float,
+// such as "42", "-7", "0x1F", "0o17", "1_000" or "6.02e23".
+func yamlNumber(s string) bool {
+	if !strings.ContainsAny(s, "0123456789") {
+		return false
+	}
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		return true
+	}
+	_, err := strconv.ParseFloat(strings.ReplaceAll(s, "_", ""), 64)
+	return err == nil || errors.Is(err, strconv.ErrRange)
+}